@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/Arrafll/StockLab-Go/internal/config"
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/reconcile"
 	"github.com/Arrafll/StockLab-Go/internal/routes"
 	_ "github.com/Arrafll/StockLab-Go/internal/routes"
+	"github.com/Arrafll/StockLab-Go/internal/snapshot"
 )
 
 var (
@@ -43,11 +47,30 @@ func main() {
 
 	_, err = db.Connect(cfg)
 	if err != nil {
-		Error.Println("Failed to connect to database: %v", err)
+		Error.Printf("Failed to connect to database: %v", err)
 		os.Exit(1)
 	}
 
 	Info.Println("Connected to database...")
+
+	// Job rekonsiliasi stocks terhadap ledger transactions
+	interval, err := time.ParseDuration(cfg.ReconcileInterval)
+	if err != nil {
+		Error.Printf("Invalid RECONCILE_INTERVAL %q, reconciliation job disabled: %v", cfg.ReconcileInterval, err)
+	} else if interval > 0 {
+		Info.Printf("Stock reconciliation job every %s", interval)
+		go reconcile.Schedule(context.Background(), db.DB, interval, Info, Error)
+	}
+
+	// Snapshot saldo stok berkala untuk laporan stok per tanggal
+	snapshotInterval, err := time.ParseDuration(cfg.SnapshotInterval)
+	if err != nil {
+		Error.Printf("Invalid SNAPSHOT_INTERVAL %q, snapshot job disabled: %v", cfg.SnapshotInterval, err)
+	} else if snapshotInterval > 0 {
+		Info.Printf("Stock snapshot job every %s", snapshotInterval)
+		go snapshot.Schedule(context.Background(), db.DB, snapshotInterval, Info, Error)
+	}
+
 	route := routes.RegisterRoutes(cfg)
 
 	Info.Println("Server running at :8080")
@@ -0,0 +1,116 @@
+// Command reconcile membandingkan stocks.quantity dengan ledger transactions,
+// menyimpan drift report dan opsional memposting adjustment koreksi.
+//
+//	go run ./cmd/reconcile                      # hanya report
+//	go run ./cmd/reconcile -correct -user 1     # report lalu koreksi setelah konfirmasi
+//	go run ./cmd/reconcile -correct -user 1 -yes
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/config"
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/reconcile"
+)
+
+func main() {
+	correct := flag.Bool("correct", false, "post correcting adjustments for the drifts found")
+	userID := flag.Int64("user", 0, "user id recorded on correcting adjustments (required with -correct)")
+	yes := flag.Bool("yes", false, "do not ask for confirmation before correcting")
+	flag.Parse()
+
+	if *correct && *userID == 0 {
+		log.Fatal("-user is required with -correct")
+	}
+
+	cfg := config.Load()
+	conn, err := db.Connect(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// log.Fatal melewati defer, jadi error dikembalikan dulu supaya koneksi ditutup
+	err = run(*correct, *userID, *yes)
+	conn.Close()
+	if err != nil {
+		log.Printf("Reconciliation failed: %v", err)
+		os.Exit(1)
+	}
+}
+
+// run menjalankan reconciliation dan, dengan correct, memposting koreksi dalam satu transaksi.
+// Transaksi di-rollback jika ada koreksi yang gagal, sehingga tidak ada koreksi yang terposting sebagian.
+func run(correct bool, userID int64, yes bool) error {
+	res, err := reconcile.Run(db.DB, reconcile.SourceCommand, 0)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Run #%d: checked %d stock rows, %d drifted\n", res.RunID, res.CheckedRows, res.DriftCount)
+	if res.DriftCount == 0 || !correct {
+		return nil
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := correctDrifts(tx, res, userID, yes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit failed: %w", err)
+	}
+	return nil
+}
+
+// correctDrifts menampilkan drift yang terbuka lalu memposting adjustment koreksinya.
+// Jawaban selain y / yes tidak memposting apa pun dan tidak dianggap error.
+func correctDrifts(tx *sql.Tx, res reconcile.Result, userID int64, yes bool) error {
+	drifts, err := reconcile.OpenDrifts(tx, res.RunID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to load drifts: %w", err)
+	}
+
+	fmt.Printf("%-8s %-10s %-12s %-10s %-10s %s\n", "DRIFT", "PRODUCT", "WAREHOUSE", "LEDGER", "STOCK", "DIFF")
+	for _, d := range drifts {
+		fmt.Printf("%-8d %-10d %-12d %-10d %-10d %+d\n", d.ID, d.ProductID, d.WarehouseID, d.LedgerQuantity, *d.StockQuantity, d.Difference)
+	}
+	if skipped := res.DriftCount - int64(len(drifts)); skipped > 0 {
+		fmt.Printf("%d drift(s) without a stock row cannot be corrected\n", skipped)
+	}
+	if len(drifts) == 0 {
+		return nil
+	}
+
+	if !yes {
+		fmt.Printf("Post %d correcting adjustment(s) so the ledger matches stocks? [y/N] ", len(drifts))
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("Aborted, nothing posted")
+			return nil
+		}
+	}
+
+	for _, d := range drifts {
+		txID, diff, err := reconcile.Correct(tx, d, userID)
+		if err != nil {
+			return fmt.Errorf("failed to correct drift %d: %w", d.ID, err)
+		}
+		if txID == 0 {
+			fmt.Printf("Drift %d already in sync\n", d.ID)
+			continue
+		}
+		fmt.Printf("Drift %d corrected by transaction %d (%+d)\n", d.ID, txID, diff)
+	}
+	return nil
+}
@@ -23,12 +23,7 @@ const docTemplate = `{
     "paths": {
         "/stocklab-api//v1/products/": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Menampilkan list product",
+                "description": "Menampilkan list product. Quantity (on hand), reserved dan available adalah total semua warehouse, atau hanya satu warehouse jika warehouse_id diisi. Stok parent product adalah total stok semua variannya; dengan group_variants=true varian tidak ditampilkan sendiri melainkan di dalam parent-nya. Stok kit adalah jumlah kit yang bisa dirakit dari stok komponen di masing-masing warehouse.",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,6 +34,32 @@ const docTemplate = `{
                     "products"
                 ],
                 "summary": "Product list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only count stock in this warehouse",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include stock breakdown per warehouse",
+                        "name": "per_location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also show stock in this unit (e.g. CTN) for products that have it configured",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Nest variants under their parent product instead of listing them separately",
+                        "name": "group_variants",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -46,23 +67,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/services.ProductSuccessResp"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductFailResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/services.ProductFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api//v1/products/update/{id}": {
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update product (PATCH semantics)",
+                ]
+            }
+        },
+        "/stocklab-api//v1/products/update/{id}": {
+            "patch": {
+                "description": "Update product (PATCH semantics). Mengaktifkan is_lot_tracked memindahkan stok yang ada ke lot UNLABELLED; is_serialized hanya bisa diaktifkan saat stok product nol.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -101,10 +128,58 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "price",
+                        "description": "price: desimal maksimal 2 digit tanpa pemisah ribuan; perubahan dicatat di riwayat harga",
                         "name": "price",
                         "in": "formData"
                     },
+                    {
+                        "type": "string",
+                        "description": "currency: kode ISO 4217",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "costing_method: FIFO atau AVG; beralih ke AVG menggabungkan layer biaya per warehouse menjadi biaya rata-rata",
+                        "name": "costing_method",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sku: harus unik; SKU varian tidak ikut berubah",
+                        "name": "sku",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "reorder_point",
+                        "name": "reorder_point",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "safety_stock",
+                        "name": "safety_stock",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max_level",
+                        "name": "max_level",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is_lot_tracked",
+                        "name": "is_lot_tracked",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is_serialized",
+                        "name": "is_serialized",
+                        "in": "formData"
+                    },
                     {
                         "type": "file",
                         "description": "Product image",
@@ -125,120 +200,97 @@ const docTemplate = `{
                             "$ref": "#/definitions/services.ProductUpdateFailResp"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/services.ProductUpdateFailResp"
                         }
-                    }
-                }
-            }
-        },
-        "/stocklab-api/v1/categories": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all category in the system",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "categories"
-                ],
-                "summary": "Get list of category",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/services.CategorySuccessResp"
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryFailResp"
+                            "$ref": "#/definitions/services.ProductUpdateFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/categories/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a category",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                ]
+            }
+        },
+        "/stocklab-api/v1/assembly-orders": {
+            "get": {
+                "description": "List assembly order beserta quantity rencana dan hasil aktual",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "assembly-orders"
                 ],
-                "summary": "Create category for products",
+                "summary": "List assembly orders",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
+                        "description": "DRAFT | COMPLETED | CANCELLED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Finished product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryCreateSuccessResp"
+                            "$ref": "#/definitions/services.AssemblyOrderListSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryCreateFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderListFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryCreateFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderListFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/categories/delete/{id}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Delete a user by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/stocklab-api/v1/assembly-orders/cancel/{id}": {
+            "post": {
+                "description": "Batalkan assembly order yang belum diselesaikan (DRAFT). Stok tidak berubah.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "assembly-orders"
                 ],
-                "summary": "Delete a user",
+                "summary": "Cancel assembly order",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category Id",
+                        "description": "Assembly order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -248,88 +300,137 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryDeleteSuccessResp"
+                            "$ref": "#/definitions/services.AssemblyOrderStatusSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryDeleteFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderStatusFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderStatusFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderStatusFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderStatusFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryDeleteFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderStatusFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/categories/update/{id}": {
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update an existing category product data",
+                ]
+            }
+        },
+        "/stocklab-api/v1/assembly-orders/complete/{id}": {
+            "post": {
+                "description": "Selesaikan assembly order DRAFT: OUT untuk setiap komponen yang dipakai dan IN untuk product jadi diposting dalam satu DB transaction. Jika stok salah satu komponen kurang seluruh order gagal dengan 409 dan tidak ada stok yang berubah. produced_quantity dan consumed_quantity per komponen boleh berbeda dari rencana; selisih hasil dicatat sebagai yield_variance dan selisih pemakaian komponen terhadap kebutuhan BOM untuk hasil aktual sebagai scrap_quantity.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "assembly-orders"
                 ],
-                "summary": "Update category product",
+                "summary": "Complete assembly order",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Assembly order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Actual output and consumption",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderCompleteRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Name",
-                        "name": "name",
-                        "in": "formData"
+                        "description": "Unique key per movement; retries with the same key return the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryUpdateSuccessResp"
+                            "$ref": "#/definitions/services.AssemblyOrderCompleteSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryUpdateFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderCompleteFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderCompleteFailResp"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryUpdateFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderCompleteFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderCompleteFailResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderCompleteFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.CategoryUpdateFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderCompleteFailResp"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/stocklab-api/v1/login": {
+        "/stocklab-api/v1/assembly-orders/create": {
             "post": {
-                "description": "Login to the system",
+                "description": "Buat assembly order berstatus DRAFT untuk merakit quantity product jadi dari bill of materials di satu warehouse. Kebutuhan komponen dihitung dari BOM dan dibulatkan ke atas; stok belum berubah sampai order diselesaikan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -337,17 +438,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "assembly-orders"
                 ],
-                "summary": "User login",
+                "summary": "Create assembly order",
                 "parameters": [
                     {
-                        "description": "User login credentials",
+                        "description": "Assembly order",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.AuthLoginParamRequest"
+                            "$ref": "#/definitions/services.AssemblyOrderCreateRequest"
                         }
                     }
                 ],
@@ -355,70 +456,57 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.AuthLoginSuccessResponse"
+                            "$ref": "#/definitions/services.AssemblyOrderCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderCreateFailResp"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/services.AuthLoginFailResponse"
+                            "$ref": "#/definitions/services.AssemblyOrderCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderCreateFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/products/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a product",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                ]
+            }
+        },
+        "/stocklab-api/v1/assembly-orders/detail/{id}": {
+            "get": {
+                "description": "Menampilkan header assembly order, komponen rencana vs dipakai beserta scrap, dan transaksi stok yang diposting saat order diselesaikan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "assembly-orders"
                 ],
-                "summary": "Create product for products",
+                "summary": "Assembly order detail",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "category_id",
-                        "name": "category_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "brand",
-                        "name": "brand",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "price",
-                        "name": "price",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Product image",
-                        "name": "image",
-                        "in": "formData",
+                        "description": "Assembly order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -426,84 +514,83 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductCreateData"
+                            "$ref": "#/definitions/services.AssemblyOrderDetailSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductCreateFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.AssemblyOrderDetailFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductCreateFailResp"
+                            "$ref": "#/definitions/services.AssemblyOrderDetailFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/products/delete/{id}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Delete product by ID",
+                ]
+            }
+        },
+        "/stocklab-api/v1/boms": {
+            "get": {
+                "description": "List bill of materials untuk assembly order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "boms"
                 ],
-                "summary": "Delete product",
+                "summary": "List bills of materials",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Finished product ID",
+                        "name": "product_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductDeleteSuccessResp"
+                            "$ref": "#/definitions/services.BomListSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductDeleteFailResp"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/services.ProductDeleteFailResp"
+                            "$ref": "#/definitions/services.BomListFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductDeleteFailResp"
+                            "$ref": "#/definitions/services.BomListFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/products/detail/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Menampilkan detail product berdasarkan ID",
+                ]
+            }
+        },
+        "/stocklab-api/v1/boms/create": {
+            "post": {
+                "description": "Buat bill of materials: komponen (satuan dasar) yang dibutuhkan untuk merakit output_quantity product jadi. Product jadi dan komponen harus product biasa yang punya stok sendiri, bukan kit atau parent product.",
                 "consumes": [
                     "application/json"
                 ],
@@ -511,91 +598,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "boms"
                 ],
-                "summary": "Product detail",
+                "summary": "Create bill of materials",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "BOM header and components",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.BomCreateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductDetailSuccessResp"
+                            "$ref": "#/definitions/services.BomCreateSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductDetailFailResp"
+                            "$ref": "#/definitions/services.BomCreateFailResp"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductDetailFailResp"
+                            "$ref": "#/definitions/services.BomCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.BomCreateFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.ProductDetailFailResp"
+                            "$ref": "#/definitions/services.BomCreateFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/transactions/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a transaction for stock movements",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                ]
+            }
+        },
+        "/stocklab-api/v1/boms/detail/{id}": {
+            "get": {
+                "description": "Menampilkan header bill of materials dan komponennya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "boms"
                 ],
-                "summary": "Create transaction stocks",
+                "summary": "Bill of materials detail",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "product_id",
-                        "name": "product_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "user_id",
-                        "name": "user_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "quantity",
-                        "name": "quantity",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "move_type",
-                        "name": "move_type",
-                        "in": "formData",
+                        "description": "BOM ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -603,32 +674,72 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.TransactionCreateData"
+                            "$ref": "#/definitions/services.BomDetailSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.TransactionCreateFailResp"
+                            "$ref": "#/definitions/services.BomDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.BomDetailFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.TransactionCreateFailResp"
+                            "$ref": "#/definitions/services.BomDetailFailResp"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/stocklab-api/v1/transactions/list": {
-            "post": {
+        "/stocklab-api/v1/categories": {
+            "get": {
+                "description": "Get all category in the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get list of category",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.CategorySuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.CategoryFailResp"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "List a transaction for stock movements",
+                ]
+            }
+        },
+        "/stocklab-api/v1/categories/create": {
+            "post": {
+                "description": "Create a category",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -636,53 +747,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "categories"
                 ],
-                "summary": "List transaction stocks",
+                "summary": "Create category for products",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "sku_code: kode category untuk token {CATEGORY} pola SKU, mis. VIT",
+                        "name": "sku_code",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.TransactionListData"
+                            "$ref": "#/definitions/services.CategoryCreateSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.TransactionListFailResp"
+                            "$ref": "#/definitions/services.CategoryCreateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.CategoryCreateFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.TransactionListFailResp"
+                            "$ref": "#/definitions/services.CategoryCreateFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/users": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all users in the system",
+                ]
+            }
+        },
+        "/stocklab-api/v1/categories/delete/{id}": {
+            "delete": {
+                "description": "Delete a user by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -690,33 +808,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "categories"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get list of users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.UserListSuccessResp"
+                            "$ref": "#/definitions/services.CategoryDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.CategoryDeleteFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.CategoryDeleteFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.UserListFailResp"
+                            "$ref": "#/definitions/services.CategoryDeleteFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/users/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new user and upload avatar",
+                ]
+            }
+        },
+        "/stocklab-api/v1/categories/update/{id}": {
+            "patch": {
+                "description": "Update an existing category product data",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -724,138 +863,209 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "categories"
                 ],
-                "summary": "Create user with avatar",
+                "summary": "Update category product",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Email",
-                        "name": "email",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Password",
-                        "name": "password",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
                         "description": "Name",
                         "name": "name",
-                        "in": "formData",
-                        "required": true
+                        "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Phone",
-                        "name": "phone",
+                        "description": "sku_code: kode category untuk token {CATEGORY} pola SKU; hanya berlaku untuk SKU baru",
+                        "name": "sku_code",
                         "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Avatar image",
-                        "name": "avatar",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.UserCreateSuccessResp"
+                            "$ref": "#/definitions/services.CategoryUpdateSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.UserCreateFailResp"
+                            "$ref": "#/definitions/services.CategoryUpdateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.CategoryUpdateFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.CategoryUpdateFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.UserCreateFailResp"
+                            "$ref": "#/definitions/services.CategoryUpdateFailResp"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/stocklab-api/v1/users/delete/{id}": {
-            "delete": {
+        "/stocklab-api/v1/customers": {
+            "get": {
+                "description": "Get all customers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get list of customers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerSuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerFailResp"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Delete a user by ID",
+                ]
+            }
+        },
+        "/stocklab-api/v1/customers/create": {
+            "post": {
+                "description": "Create a customer that sales orders can be placed for",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "customers"
                 ],
-                "summary": "Delete a user",
+                "summary": "Create customer",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "contact_name",
+                        "name": "contact_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.UserDeleteSuccessResp"
+                            "$ref": "#/definitions/services.CustomerCreateSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.UserDeleteFailResp"
+                            "$ref": "#/definitions/services.CustomerCreateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerCreateFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.UserDeleteFailResp"
+                            "$ref": "#/definitions/services.CustomerCreateFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/users/detail/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get a single user by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/stocklab-api/v1/customers/delete/{id}": {
+            "delete": {
+                "description": "Delete a customer without sales orders. Customers with order history should be deactivated instead.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "customers"
                 ],
-                "summary": "Get detail of a user",
+                "summary": "Delete customer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -865,32 +1075,50 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.UserDetailSuccessResp"
+                            "$ref": "#/definitions/services.CustomerDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerDeleteFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerDeleteFailResp"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/services.UserDetailFailResp"
+                            "$ref": "#/definitions/services.CustomerDeleteFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerDeleteFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.UserDetailFailResp"
+                            "$ref": "#/definitions/services.CustomerDeleteFailResp"
                         }
                     }
-                }
-            }
-        },
-        "/stocklab-api/v1/users/update/{id}": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update an existing user and optionally upload a new avatar",
+                ]
+            }
+        },
+        "/stocklab-api/v1/customers/update/{id}": {
+            "put": {
+                "description": "Update an existing customer. Inactive customers cannot place new sales orders.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -898,45 +1126,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "customers"
                 ],
-                "summary": "Update user with avatar",
+                "summary": "Update customer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Email",
-                        "name": "email",
+                        "description": "code",
+                        "name": "code",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Password",
-                        "name": "password",
+                        "description": "name",
+                        "name": "name",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Name",
-                        "name": "name",
+                        "description": "contact_name",
+                        "name": "contact_name",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Phone",
+                        "description": "phone",
                         "name": "phone",
                         "in": "formData"
                     },
                     {
-                        "type": "file",
-                        "description": "Avatar image",
-                        "name": "avatar",
+                        "type": "string",
+                        "description": "email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is_active",
+                        "name": "is_active",
                         "in": "formData"
                     }
                 ],
@@ -944,84 +1184,10523 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.UserUpdateSuccessResp"
+                            "$ref": "#/definitions/services.CustomerUpdateSuccessResp"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/services.UserUpdateFailResp"
+                            "$ref": "#/definitions/services.CustomerUpdateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerUpdateFailResp"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/services.UserUpdateFailResp"
+                            "$ref": "#/definitions/services.CustomerUpdateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerUpdateFailResp"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/services.UserUpdateFailResp"
+                            "$ref": "#/definitions/services.CustomerUpdateFailResp"
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "services.AuthLoginData": {
-            "type": "object",
-            "properties": {
-                "role": {
-                    "type": "string",
-                    "example": "admin"
-                },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "user_id": {
-                    "type": "string",
-                    "example": "1"
-                }
-            }
-        },
-        "services.AuthLoginFailResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Invalid credentials"
-                },
-                "status": {
-                    "type": "string",
-                    "example": "error"
-                }
-            }
-        },
-        "services.AuthLoginParamRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "andrerafli83@gmail.com"
                 },
-                "password": {
-                    "type": "string",
-                    "example": "password123"
-                }
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "services.AuthLoginSuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.AuthLoginData"
-                },
-                "message": {
+        "/stocklab-api/v1/documents": {
+            "get": {
+                "description": "List dokumen penerimaan/pengeluaran barang",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "List stock movement documents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RECEIPT | ISSUE",
+                        "name": "doc_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start document date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End document date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentListSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/documents/create": {
+            "post": {
+                "description": "Posting dokumen penerimaan (RECEIPT) atau pengeluaran (ISSUE) dengan banyak baris. Semua baris dibukukan dalam satu DB transaction, gagal satu berarti gagal semua. Product lot-tracked wajib lot_number pada RECEIPT; ISSUE tanpa lot_number mengambil lot FEFO. Product serialized wajib serial_numbers sebanyak quantity. Baris kit pada ISSUE dipecah menjadi satu baris per komponen sesuai bill of materials.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Post stock movement document",
+                "parameters": [
+                    {
+                        "description": "Document header and lines",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key per movement; retries with the same key return the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentCreateFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentCreateFailResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/documents/detail/{id}": {
+            "get": {
+                "description": "Menampilkan header dokumen beserta semua barisnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Stock movement document detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentDetailSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentDetailFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.DocumentDetailFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/login": {
+            "post": {
+                "description": "Login to the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "User login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthLoginParamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthLoginSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthLoginFailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocklab-api/v1/logout": {
+            "post": {
+                "description": "Mencabut session saat ini sehingga access token dan refresh token tidak berlaku lagi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "User logout",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthLogoutSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthLogoutFailResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthLogoutFailResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/lots": {
+            "get": {
+                "description": "List lot product lot-tracked beserta quantity dan tanggal kedaluwarsa, urut FEFO (kedaluwarsa terdekat dulu)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lots"
+                ],
+                "summary": "List stock lots",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Tampilkan juga lot yang sudah habis",
+                        "name": "include_empty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.LotListSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.LotListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.LotListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/lots/expiring": {
+            "get": {
+                "description": "List lot yang masih ada stoknya dan kedaluwarsa dalam N hari ke depan, termasuk yang sudah lewat kedaluwarsa. Urut dari kedaluwarsa terdekat.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lots"
+                ],
+                "summary": "Lots expiring soon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah hari ke depan (default 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.LotExpiringSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.LotExpiringFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.LotExpiringFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/barcodes/{id}": {
+            "put": {
+                "description": "Atur barcode GS1 (EAN-8, UPC-A, EAN-13, GTIN-14) product. Check digit divalidasi; unit barcode harus satuan dasar atau satuan alternatif product. Barcode yang tidak dikirim dihapus.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set product barcodes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Barcodes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/by-barcode/{code}": {
+            "get": {
+                "description": "Resolve barcode hasil scan menjadi product dan satuan yang diwakilinya. UPC-A 12 digit dan EAN-13 dengan awalan 0 dianggap sama.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Lookup product by barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Barcode",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodeLookupSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductBarcodesFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/create": {
+            "post": {
+                "description": "Create a product. Jika variants dikirim, product dibuat sebagai parent tanpa stok dan setiap kombinasi atribut dibuat sebagai varian dengan SKU \u003csku parent\u003e-01, -02, ... serta stok sendiri di setiap warehouse. Varian mewarisi harga, ambang stok, tracking dan satuan dasar parent; harga varian bisa diubah lewat update product. Jika components dikirim, product dibuat sebagai kit tanpa stok sendiri; availability kit dihitung dari stok komponennya.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create product for products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "category_id",
+                        "name": "category_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "brand",
+                        "name": "brand",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "price: desimal maksimal 2 digit tanpa pemisah ribuan, mis. 10000 atau 10000.50",
+                        "name": "price",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency: kode ISO 4217 (default IDR)",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "costing_method: FIFO atau AVG (moving weighted average), default FIFO",
+                        "name": "costing_method",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sku: kosong = dibuat otomatis dari pola SKU category (atau pola default)",
+                        "name": "sku",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "reorder_point (default 0)",
+                        "name": "reorder_point",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "safety_stock (default 0)",
+                        "name": "safety_stock",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max_level",
+                        "name": "max_level",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is_lot_tracked: stok dicatat per lot dengan tanggal kedaluwarsa (default false)",
+                        "name": "is_lot_tracked",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "base_unit: unit code stok disimpan (default PCS)",
+                        "name": "base_unit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is_serialized: stok dicatat per unit dengan nomor seri (default false)",
+                        "name": "is_serialized",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "variants: JSON atribut ke daftar nilai, mis. {\\",
+                        "name": "variants",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "components: JSON bill of materials kit, mis. [{\\",
+                        "name": "components",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "barcodes: barcode GS1 satuan dasar, dipisah koma",
+                        "name": "barcodes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Product image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductCreateData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/delete/{id}": {
+            "delete": {
+                "description": "Delete product by ID beserta baris stok kosong, barcode, harga, satuan dan komponen kit-nya dalam satu transaksi. Hanya product tanpa riwayat yang bisa dihapus: product yang masih punya stok, transaksi, cost layer, purchase / sales order line, stock count line atau assembly order ditolak (409). Parent product hanya bisa dihapus setelah semua variannya dihapus; komponen kit dan product yang dipakai di bill of materials tidak bisa dihapus.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDeleteFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDeleteFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDeleteFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDeleteFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDeleteFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/detail/{id}": {
+            "get": {
+                "description": "Menampilkan detail product berdasarkan ID, termasuk total stok (on hand, reserved, available) dan stok per warehouse. Untuk parent product stok adalah total semua varian dan daftar varian beserta stoknya ikut ditampilkan. Untuk kit stok adalah jumlah kit yang bisa dirakit dari stok komponen dan daftar komponen ikut ditampilkan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Product detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also show stock in this unit (e.g. CTN)",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDetailSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDetailFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductDetailFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/kit/{id}": {
+            "put": {
+                "description": "Atur bill of materials kit / bundle. Kit tidak punya stok sendiri: availability dihitung dari stok komponen dan OUT kit mengurangi stok setiap komponen. Product hanya bisa dijadikan kit saat stoknya nol; mengosongkan components mengembalikan product menjadi product biasa dengan stok nol di setiap warehouse. Komponen tidak boleh kit, parent product atau product serialized.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set kit components",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kit components",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductKitUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductKitSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductKitFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductKitFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductKitFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductKitFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/levels/{id}": {
+            "put": {
+                "description": "Override reorder point, safety stock dan max level product di satu warehouse. Field yang kosong kembali mengikuti nilai di product.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set product levels per warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "warehouse_id",
+                        "name": "warehouse_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "reorder_point",
+                        "name": "reorder_point",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "safety_stock",
+                        "name": "safety_stock",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max_level",
+                        "name": "max_level",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductLevelsSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductLevelsFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductLevelsFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductLevelsFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/low-stock": {
+            "get": {
+                "description": "Menampilkan product dengan stok di bawah reorder point beserta shortfall (reorder point - stok) dan saran order (sampai max level, atau sebesar shortfall jika max level kosong). Tanpa filter stok dijumlahkan semua warehouse.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Low stock products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only check stock in this warehouse",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check each warehouse separately using per-location levels",
+                        "name": "per_location",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.LowStockSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.LowStockFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.LowStockFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/prices/{id}": {
+            "get": {
+                "description": "Riwayat harga product, terbaru lebih dulu. Jika at diisi hanya periode yang berlaku pada waktu tersebut yang ditampilkan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Product price history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the price in effect at this time (RFC3339 or YYYY-MM-DD)",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductPriceHistorySuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductPriceHistoryFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductPriceHistoryFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductPriceHistoryFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/units/{id}": {
+            "put": {
+                "description": "Atur satuan dasar dan satuan alternatif product beserta faktor konversinya (1 unit = factor x satuan dasar). Satuan alternatif yang tidak dikirim dihapus. Satuan dasar hanya bisa diganti saat stok product nol karena stok disimpan dalam satuan dasar.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set product units",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Base unit and alternate units",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductUnitsUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductUnitsSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductUnitsFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductUnitsFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductUnitsFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductUnitsFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/products/{id}/ledger": {
+            "get": {
+                "description": "Kartu stok satu product: saldo awal sebelum start_date, lalu setiap movement urut waktu dengan quantity masuk/keluar, saldo berjalan (total dan per warehouse), user dan referensinya. Tanggal dalam UTC. Parent product dan kit tidak punya stok sendiri, gunakan ID varian atau komponen.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Product stock ledger",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), inclusive",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only movements in this warehouse",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries per page, default 50, max 500",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductLedgerSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductLedgerFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductLedgerFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ProductLedgerFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/purchase-orders": {
+            "get": {
+                "description": "List purchase order beserta total dipesan dan diterima",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "List purchase orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DRAFT | SENT | PARTIALLY_RECEIVED | RECEIVED | CANCELLED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only orders past their expected date",
+                        "name": "overdue",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderListSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/purchase-orders/cancel/{id}": {
+            "post": {
+                "description": "Batalkan purchase order yang belum menerima barang (DRAFT atau SENT)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Cancel purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/purchase-orders/create": {
+            "post": {
+                "description": "Buat purchase order berstatus DRAFT ke satu supplier untuk diterima di satu warehouse. Satu product hanya boleh muncul sekali per order. Harga baris desimal (maks. 2 desimal); currency kosong memakai mata uang product dan semua baris harus bermata uang sama.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Create purchase order",
+                "parameters": [
+                    {
+                        "description": "Purchase order header and lines",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderCreateFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/purchase-orders/detail/{id}": {
+            "get": {
+                "description": "Menampilkan header purchase order, quantity dipesan vs diterima per baris beserta status pengirimannya, dan dokumen penerimaan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Purchase order detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDetailSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDetailFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDetailFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/purchase-orders/receive/{id}": {
+            "post": {
+                "description": "Posting penerimaan barang untuk purchase order SENT atau PARTIALLY_RECEIVED. Stok masuk lewat dokumen RECEIPT ke warehouse tujuan order, quantity diterima per baris ditambah, dan kelebihan/kekurangan pengiriman ditandai. Order menjadi RECEIVED jika semua baris terpenuhi atau close=true. Product lot-tracked wajib lot_number; satu product boleh diterima dalam beberapa lot. Product serialized wajib serial_numbers sebanyak quantity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Receive goods against purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Received quantities",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderReceiveRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key per movement; retries with the same key return the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderReceiveSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderReceiveFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderReceiveFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderReceiveFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderReceiveFailResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderReceiveFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderReceiveFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/purchase-orders/send/{id}": {
+            "post": {
+                "description": "Tandai purchase order DRAFT sudah dikirim ke supplier sehingga bisa diterima",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Send purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderStatusFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/reason-codes": {
+            "get": {
+                "description": "Get all reason codes used for stock adjustments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reason-codes"
+                ],
+                "summary": "Get list of reason codes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeSuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/reason-codes/create": {
+            "post": {
+                "description": "Create a reason code for stock adjustments",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reason-codes"
+                ],
+                "summary": "Create reason code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IN | OUT | BOTH (default BOTH)",
+                        "name": "direction",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeCreateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/reason-codes/delete/{id}": {
+            "delete": {
+                "description": "Delete a reason code that has never been used. Used codes should be deactivated instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reason-codes"
+                ],
+                "summary": "Delete reason code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reason code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeDeleteFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeDeleteFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeDeleteFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeDeleteFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeDeleteFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/reason-codes/update/{id}": {
+            "put": {
+                "description": "Update name, direction or active flag of a reason code. The code itself cannot change because it is referenced by history.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reason-codes"
+                ],
+                "summary": "Update reason code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reason code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IN | OUT | BOTH",
+                        "name": "direction",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is_active",
+                        "name": "is_active",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeUpdateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeUpdateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeUpdateFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeUpdateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReasonCodeUpdateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/reconciliations": {
+            "get": {
+                "description": "List run rekonsiliasi stocks terhadap ledger transactions, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliations"
+                ],
+                "summary": "List reconciliation runs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationListSuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/reconciliations/correct/{id}": {
+            "post": {
+                "description": "Posting adjustment ADJ_IN/ADJ_OUT dengan reason RECONCILE supaya ledger kembali sama dengan stocks.quantity. stocks tidak diubah: stok fisik dianggap benar, lakukan stock count jika stocks yang diragukan. Tanpa confirm=true hanya preview yang dikembalikan. Selisih dihitung ulang saat posting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliations"
+                ],
+                "summary": "Correct reconciliation drifts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reconciliation run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Drifts to correct",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationCorrectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationCorrectSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/reconciliations/detail/{id}": {
+            "get": {
+                "description": "Detail run rekonsiliasi beserta semua drift: saldo ledger, stocks.quantity, selisih dan status koreksinya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliations"
+                ],
+                "summary": "Reconciliation drift report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reconciliation run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationDetailSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/reconciliations/run": {
+            "post": {
+                "description": "Bandingkan stocks.quantity setiap product di setiap warehouse dengan jumlah IN - OUT di transactions dan simpan selisihnya sebagai drift report. Tidak ada yang dikoreksi; gunakan endpoint correct.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliations"
+                ],
+                "summary": "Run stock reconciliation",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationRunSuccessResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReconciliationFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sales-orders": {
+            "get": {
+                "description": "List sales order beserta total dipesan dan sudah dikirim",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-orders"
+                ],
+                "summary": "List sales orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DRAFT | CONFIRMED | PARTIALLY_FULFILLED | FULFILLED | CANCELLED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "customer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderListSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sales-orders/cancel/{id}": {
+            "post": {
+                "description": "Batalkan sales order yang belum selesai dikirim. Reservasi untuk sisa baris dilepas; barang yang sudah dikirim tidak dikembalikan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-orders"
+                ],
+                "summary": "Cancel sales order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sales order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sales-orders/confirm/{id}": {
+            "post": {
+                "description": "Reserve stok untuk semua baris sales order DRAFT. Stok yang di-reserve tidak bisa dipakai OUT lain sampai order dikirim atau dibatalkan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-orders"
+                ],
+                "summary": "Confirm sales order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sales order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderStatusFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sales-orders/create": {
+            "post": {
+                "description": "Buat sales order untuk satu customer yang dikirim dari satu warehouse. Order berstatus DRAFT, atau langsung CONFIRMED dengan stok ter-reserve jika confirm=true. Satu product hanya boleh muncul sekali per order. Harga baris desimal (maks. 2 desimal); currency kosong memakai mata uang product dan semua baris harus bermata uang sama.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-orders"
+                ],
+                "summary": "Create sales order",
+                "parameters": [
+                    {
+                        "description": "Sales order header and lines",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderCreateFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sales-orders/detail/{id}": {
+            "get": {
+                "description": "Menampilkan header sales order, quantity dipesan, dikirim dan yang masih di-reserve per baris, beserta dokumen pengeluaran",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-orders"
+                ],
+                "summary": "Sales order detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sales order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderDetailSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderDetailFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderDetailFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sales-orders/fulfil/{id}": {
+            "post": {
+                "description": "Kirim barang untuk sales order CONFIRMED atau PARTIALLY_FULFILLED. Reservasi dilepas dan OUT diposting lewat dokumen ISSUE dari warehouse order. Tanpa lines semua sisa order dikirim. Product lot-tracked dikirim dari lot yang disebut atau FEFO; product serialized wajib menyebut serial_numbers yang dikirim.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-orders"
+                ],
+                "summary": "Fulfil sales order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sales order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shipped quantities",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderFulfilRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key per movement; retries with the same key return the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderFulfilSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderFulfilFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderFulfilFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderFulfilFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderFulfilFailResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderFulfilFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesOrderFulfilFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/serials": {
+            "get": {
+                "description": "List nomor seri product serialized beserta lokasi terakhir dan statusnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "serials"
+                ],
+                "summary": "List serial numbers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IN_STOCK | OUT",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SerialListSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SerialListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SerialListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/serials/lookup/{serial_number}": {
+            "get": {
+                "description": "Cari satu unit berdasarkan nomor seri dan tampilkan seluruh riwayat pergerakannya dari tabel transactions. Nomor seri unik per product, jadi tanpa product_id hasilnya bisa lebih dari satu unit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "serials"
+                ],
+                "summary": "Serial number lookup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Serial number",
+                        "name": "serial_number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SerialLookupSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SerialLookupFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SerialLookupFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SerialLookupFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sku-patterns": {
+            "get": {
+                "description": "Pola penomoran SKU product baru. Token: {CATEGORY} (sku_code category atau 3 huruf pertama namanya), {BRAND} (3 huruf pertama brand), {SEQ} atau {SEQ:n} (nomor urut database, zero-padded n digit). Pola category dipakai jika ada, selain itu pola default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sku-patterns"
+                ],
+                "summary": "Get list of SKU patterns",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternSuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sku-patterns/delete/{id}": {
+            "delete": {
+                "description": "Hapus pola SKU category; product baru di category tersebut kembali memakai pola default. Pola default tidak bisa dihapus, hanya diganti.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sku-patterns"
+                ],
+                "summary": "Delete SKU pattern",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "SKU pattern ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/sku-patterns/save": {
+            "put": {
+                "description": "Buat atau ganti pola SKU untuk satu category, atau pola default jika category_id kosong. Pola harus berisi tepat satu token {SEQ} supaya SKU selalu unik. Hanya berlaku untuk product baru.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sku-patterns"
+                ],
+                "summary": "Save SKU pattern",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "category_id, kosong = pola default",
+                        "name": "category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pattern, mis. {CATEGORY}-{BRAND}-{SEQ:5}",
+                        "name": "pattern",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternSaveSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SKUPatternFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-counts": {
+            "get": {
+                "description": "List sesi stock opname",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "List stock counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "OPEN | APPROVED | CANCELLED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountListSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-counts/approve/{id}": {
+            "post": {
+                "description": "Posting selisih (counted - expected snapshot) sebagai ADJ_IN / ADJ_OUT dengan reason COUNT. Semua baris harus sudah dihitung. Pergerakan stok setelah snapshot tetap dipertahankan karena yang diposting hanya selisihnya. Untuk product serialized yang diposting per nomor seri: expected yang tidak ditemukan dan masih IN_STOCK keluar (ADJ_OUT), yang ditemukan tapi tidak tercatat di warehouse ini masuk (ADJ_IN).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Approve stock count",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-counts/cancel/{id}": {
+            "post": {
+                "description": "Batalkan sesi stock opname yang masih OPEN tanpa posting adjustment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Cancel stock count",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountCancelSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountApproveFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-counts/create": {
+            "post": {
+                "description": "Buka sesi stock opname untuk satu warehouse (opsional dibatasi satu category). Expected quantity dibekukan dari stocks saat sesi dibuat, untuk product serialized juga nomor seri yang IN_STOCK.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Create stock count",
+                "parameters": [
+                    {
+                        "description": "Stock count scope",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountCreateFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-counts/detail/{id}": {
+            "get": {
+                "description": "Menampilkan header stock opname beserta expected, counted dan selisih per product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Stock count detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountDetailSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountDetailFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountDetailFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-counts/submit/{id}": {
+            "post": {
+                "description": "Simpan hasil hitung fisik. Untuk product serialized kirim nomor seri yang ditemukan di serial_numbers. Boleh dikirim berkali-kali selama sesi masih OPEN; nilai terakhir yang dipakai.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Submit counted quantities",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counted quantities",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountSubmitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountSubmitSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountSubmitFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountSubmitFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountSubmitFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountSubmitFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountSubmitFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-counts/variance/{id}": {
+            "get": {
+                "description": "Selisih per product yang sudah dihitung beserta dampak nilainya. Sesi OPEN dinilai dengan variance x biaya satuan saat ini dari cost layer product di warehouse stock opname; sesi yang sudah di-approve dinilai dari cost_value adjustment yang diposting",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Stock count variance report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountVarianceSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountVarianceFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountVarianceFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockCountVarianceFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-snapshots": {
+            "get": {
+                "description": "List snapshot saldo stok, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-snapshots"
+                ],
+                "summary": "List stock snapshots",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotListSuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-snapshots/as-of": {
+            "get": {
+                "description": "Saldo stok per product dan warehouse pada waktu as_of, direkonstruksi dari snapshot terakhir sebelum as_of ditambah transaksi sesudahnya. Setiap baris di-cross-check: saldo sekarang menurut snapshot + ledger dibandingkan dengan stocks.quantity; baris yang tidak cocok selalu ditampilkan walaupun saldonya nol.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-snapshots"
+                ],
+                "summary": "Stock as of date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RFC3339 or YYYY-MM-DD (end of that day); default now",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this product",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this warehouse",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only products in this category",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockAsOfSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-snapshots/create": {
+            "post": {
+                "description": "Simpan saldo stok per product per warehouse pada waktu snapshot_at, dihitung dari snapshot sebelumnya ditambah transaksi sesudahnya. Snapshot mempercepat laporan stok per tanggal; snapshot juga dibuat otomatis setiap SNAPSHOT_INTERVAL, endpoint ini untuk titik tertentu seperti tutup buku akhir bulan. snapshot_at harus minimal 5 menit sebelum sekarang.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-snapshots"
+                ],
+                "summary": "Create stock snapshot",
+                "parameters": [
+                    {
+                        "description": "Snapshot time",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/stock-snapshots/delete/{id}": {
+            "delete": {
+                "description": "Hapus snapshot saldo stok, mis. jika cross-check menunjukkan snapshot tidak cocok dengan ledger. Laporan stok per tanggal tetap benar karena memakai snapshot sebelumnya, hanya lebih lambat.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-snapshots"
+                ],
+                "summary": "Delete stock snapshot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock snapshot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.StockSnapshotFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/suppliers": {
+            "get": {
+                "description": "Get all suppliers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get list of suppliers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierSuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/suppliers/create": {
+            "post": {
+                "description": "Create a supplier that purchase orders can be placed with",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Create supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "contact_name",
+                        "name": "contact_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierCreateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/suppliers/delete/{id}": {
+            "delete": {
+                "description": "Delete a supplier without purchase orders. Suppliers with order history should be deactivated instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Delete supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDeleteFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDeleteFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDeleteFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDeleteFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDeleteFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/suppliers/update/{id}": {
+            "put": {
+                "description": "Update an existing supplier. Inactive suppliers cannot receive new purchase orders.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Update supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "contact_name",
+                        "name": "contact_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is_active",
+                        "name": "is_active",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierUpdateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierUpdateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierUpdateFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierUpdateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierUpdateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierUpdateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/token/refresh": {
+            "post": {
+                "description": "Tukar refresh token dengan access token baru. Refresh token lama tidak berlaku lagi (rotasi).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthRefreshParamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthRefreshSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthRefreshFailResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthRefreshFailResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.AuthRefreshFailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocklab-api/v1/transactions/create": {
+            "post": {
+                "description": "Create a transaction for stock movements. Adjustments (ADJ_IN / ADJ_OUT) require a reason_code. For lot-tracked products IN requires lot_number (and expiry_date for a new lot); OUT takes the named lot or the first-expiring unexpired lots (FEFO). Serialized products need one serial number per unit. quantity may be given in any unit configured for the product; it is stored in the product's base unit. OUT / ADJ_OUT of a kit decrements every component by its bill of materials in the same DB transaction. Inbound movements open a cost layer at unit_cost (default: the current average cost); outbound movements record cost of goods from the product's cost layers (FIFO or moving average).",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Create transaction stocks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "product_id, required unless barcode is given",
+                        "name": "product_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "barcode: scanned GS1 barcode used instead of product_id; unit defaults to the unit the barcode belongs to",
+                        "name": "barcode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "warehouse_id",
+                        "name": "warehouse_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "quantity",
+                        "name": "quantity",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unit code of quantity (e.g. CTN); default is the product's base unit",
+                        "name": "unit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "move_type (IN | OUT | ADJ_IN | ADJ_OUT)",
+                        "name": "move_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "reason_code, required for ADJ_IN / ADJ_OUT",
+                        "name": "reason_code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "notes",
+                        "name": "notes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "lot_number, required for IN of lot-tracked products; optional for OUT",
+                        "name": "lot_number",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expiry_date (YYYY-MM-DD) of the incoming lot",
+                        "name": "expiry_date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "serial_numbers, comma separated; required for serialized products, count must equal quantity",
+                        "name": "serial_numbers",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "unit_cost: purchase cost per 1 unit of quantity (in unit if given, else base unit); only for IN / ADJ_IN",
+                        "name": "unit_cost",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key per movement; retries with the same key return the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionCreateData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionCreateFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionCreateFailResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/transactions/list": {
+            "post": {
+                "description": "List a transaction for stock movements",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "List transaction stocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only lines of this document",
+                        "name": "document_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IN | OUT | ADJ_IN | ADJ_OUT",
+                        "name": "move_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason code",
+                        "name": "reason_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "document: group lines by their movement document",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionListData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransactionListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/transfers": {
+            "get": {
+                "description": "List transfer documents between warehouses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "List stock transfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Source or destination warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferListSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/transfers/create": {
+            "post": {
+                "description": "Pindahkan stok antar warehouse dalam satu DB transaction. Setiap baris dicatat sebagai pasangan OUT/IN di transactions dengan transfer_id yang sama. Untuk product lot-tracked lot diambil FEFO dan dipindah apa adanya ke warehouse tujuan; product serialized wajib menyebut serial_numbers yang dipindah.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Create stock transfer",
+                "parameters": [
+                    {
+                        "description": "Transfer document",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key per movement; retries with the same key return the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferCreateFailResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferCreateFailResp"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/transfers/detail/{id}": {
+            "get": {
+                "description": "Menampilkan header transfer beserta pasangan transaksi OUT/IN per product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Stock transfer detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferDetailSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferDetailFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.TransferDetailFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/units": {
+            "get": {
+                "description": "Get all units of measure that can be used as base or alternate unit of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Get list of units",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitSuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/units/create": {
+            "post": {
+                "description": "Create a unit of measure",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Create unit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitCreateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/units/delete/{id}": {
+            "delete": {
+                "description": "Delete a unit that is not used by any product or transaction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Delete unit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitDeleteFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitDeleteFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitDeleteFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitDeleteFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitDeleteFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/units/update/{id}": {
+            "put": {
+                "description": "Update the name of a unit. The code cannot change because products and history refer to it.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Update unit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitUpdateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitUpdateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitUpdateFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitUpdateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UnitUpdateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/users": {
+            "get": {
+                "description": "Get all users in the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get list of users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserListSuccessResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserListFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserListFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/users/create": {
+            "post": {
+                "description": "Create a new user and upload avatar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create user with avatar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Avatar image",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserCreateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/users/delete/{id}": {
+            "delete": {
+                "description": "Delete a user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserDeleteFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserDeleteFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserDeleteFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/users/detail/{id}": {
+            "get": {
+                "description": "Get a single user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get detail of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserDetailSuccessResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserDetailFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserDetailFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserDetailFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/users/update/{id}": {
+            "put": {
+                "description": "Update an existing user and optionally upload a new avatar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user with avatar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password",
+                        "name": "password",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role (admin | staff)",
+                        "name": "role",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Avatar image",
+                        "name": "avatar",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserUpdateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserUpdateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserUpdateFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserUpdateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.UserUpdateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/valuation": {
+            "get": {
+                "description": "Nilai persediaan per product, category atau warehouse pada waktu as_of, dihitung dari biaya perolehan movement masuk dikurangi COGS movement keluar sampai waktu tersebut. Stok yang sudah ada sebelum costing aktif bernilai nol.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "valuation"
+                ],
+                "summary": "Inventory valuation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Valuation time, RFC3339 or YYYY-MM-DD (end of that day); default now",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "product (default) | category | warehouse",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only stock in this warehouse",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only products in this category",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ValuationSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.ValuationFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.ValuationFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/warehouses": {
+            "get": {
+                "description": "Get all warehouses (stock locations) with their total stock quantity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Get list of warehouses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseSuccessResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/warehouses/create": {
+            "post": {
+                "description": "Create a stock location. A zero stock row is created for every existing product.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Create warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseCreateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseCreateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseCreateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseCreateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseCreateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/warehouses/delete/{id}": {
+            "delete": {
+                "description": "Delete a warehouse. Only allowed when it holds no stock and has no transaction history.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Delete warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseDeleteSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseDeleteFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseDeleteFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseDeleteFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseDeleteFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseDeleteFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stocklab-api/v1/warehouses/update/{id}": {
+            "put": {
+                "description": "Update an existing warehouse",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Update warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseUpdateSuccessResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseUpdateFailResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseUpdateFailResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseUpdateFailResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseUpdateFailResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/services.WarehouseUpdateFailResp"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "services.AssemblyOrderCompleteFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Insufficient stock for product 3 at warehouse 1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.AssemblyOrderCompleteLineRequest": {
+            "type": "object",
+            "properties": {
+                "consumed_quantity": {
+                    "description": "default planned_quantity baris",
+                    "type": "integer",
+                    "example": 104
+                },
+                "lot_number": {
+                    "description": "lot yang dipakai, kosong = FEFO",
+                    "type": "string",
+                    "example": "LOT-2025-11A"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "serial_numbers": {
+                    "description": "wajib untuk komponen serialized",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001"
+                    ]
+                }
+            }
+        },
+        "services.AssemblyOrderCompleteRequest": {
+            "type": "object",
+            "properties": {
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2026-05-31"
+                },
+                "lines": {
+                    "description": "hanya komponen yang dipakai berbeda dari rencana",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.AssemblyOrderCompleteLineRequest"
+                    }
+                },
+                "lot_number": {
+                    "description": "lot product jadi jika lot-tracked",
+                    "type": "string",
+                    "example": "LOT-AO-1"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "2 keranjang rusak"
+                },
+                "produced_quantity": {
+                    "description": "default planned_quantity",
+                    "type": "integer",
+                    "example": 48
+                },
+                "serial_numbers": {
+                    "description": "wajib jika product jadi serialized",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-HMP-0001"
+                    ]
+                }
+            }
+        },
+        "services.AssemblyOrderCompleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.AssemblyOrderDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Assembly order completed successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.AssemblyOrderCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to create assembly order"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.AssemblyOrderCreateRequest": {
+            "type": "object",
+            "properties": {
+                "bom_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Produksi batch 1"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "AO-2025-0001"
+                },
+                "quantity": {
+                    "description": "product jadi yang akan dirakit",
+                    "type": "integer",
+                    "example": 50
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.AssemblyOrderCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.AssemblyOrderDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Assembly order created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.AssemblyOrderDetail": {
+            "type": "object",
+            "properties": {
+                "bom_code": {
+                    "type": "string",
+                    "example": "BOM-GIFT-A"
+                },
+                "bom_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2024-12-15T10:00:00Z"
+                },
+                "completed_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.AssemblyOrderLine"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Produksi batch 1"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "AO-2025-0001"
+                },
+                "output_transaction_id": {
+                    "description": "IN product jadi saat order diselesaikan",
+                    "type": "integer",
+                    "example": 24
+                },
+                "planned_quantity": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "produced_quantity": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Hampers Lebaran A"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-777"
+                },
+                "status": {
+                    "description": "DRAFT | COMPLETED | CANCELLED",
+                    "type": "string",
+                    "example": "COMPLETED"
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "yield_variance": {
+                    "description": "produced - planned, hanya untuk order COMPLETED",
+                    "type": "integer",
+                    "example": -2
+                }
+            }
+        },
+        "services.AssemblyOrderDetailFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch assembly order"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.AssemblyOrderDetailSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.AssemblyOrderDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Assembly order fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.AssemblyOrderLine": {
+            "type": "object",
+            "properties": {
+                "component_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "component_name": {
+                    "type": "string",
+                    "example": "Sirup Marjan 460ml"
+                },
+                "component_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "consumed_quantity": {
+                    "description": "yang benar-benar dipakai",
+                    "type": "integer",
+                    "example": 100
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "planned_quantity": {
+                    "description": "kebutuhan BOM untuk planned_quantity",
+                    "type": "integer",
+                    "example": 100
+                },
+                "scrap_quantity": {
+                    "description": "dipakai - kebutuhan BOM untuk hasil aktual, negatif = hemat",
+                    "type": "integer",
+                    "example": 4
+                },
+                "transaction_id": {
+                    "description": "OUT komponen saat order diselesaikan",
+                    "type": "integer",
+                    "example": 21
+                }
+            }
+        },
+        "services.AssemblyOrderListData": {
+            "type": "object",
+            "properties": {
+                "bom_code": {
+                    "type": "string",
+                    "example": "BOM-GIFT-A"
+                },
+                "bom_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2024-12-15T10:00:00Z"
+                },
+                "completed_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Produksi batch 1"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "AO-2025-0001"
+                },
+                "planned_quantity": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "produced_quantity": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Hampers Lebaran A"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-777"
+                },
+                "status": {
+                    "description": "DRAFT | COMPLETED | CANCELLED",
+                    "type": "string",
+                    "example": "COMPLETED"
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "yield_variance": {
+                    "description": "produced - planned, hanya untuk order COMPLETED",
+                    "type": "integer",
+                    "example": -2
+                }
+            }
+        },
+        "services.AssemblyOrderListFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch assembly orders"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.AssemblyOrderListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.AssemblyOrderListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Assembly orders fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.AssemblyOrderStatusFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Assembly order is already COMPLETED"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.AssemblyOrderStatusSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Assembly order cancelled successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.AuthLoginData": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3f5c0e8b9a..."
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "services.AuthLoginFailResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid credentials"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.AuthLoginParamRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "andrerafli83@gmail.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "services.AuthLoginSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.AuthLoginData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Login successful"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.AuthLogoutFailResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to logout"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.AuthLogoutSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Logout successful"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.AuthRefreshFailResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid refresh token"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.AuthRefreshParamRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3f5c0e8b9a..."
+                }
+            }
+        },
+        "services.AuthRefreshSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.AuthLoginData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Token refreshed successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.BomCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to create bill of materials"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.BomCreateRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "BOM-GIFT-A"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.BomLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Keranjang rotan"
+                },
+                "output_quantity": {
+                    "description": "default 1",
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "services.BomCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.BomDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Bill of materials created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.BomDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "BOM-GIFT-A"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.BomLine"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Keranjang rotan"
+                },
+                "output_quantity": {
+                    "description": "hasil satu kali rakit",
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Hampers Lebaran A"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-777"
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "services.BomDetailFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch bill of materials"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.BomDetailSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.BomDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Bill of materials fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.BomLine": {
+            "type": "object",
+            "properties": {
+                "component_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "component_name": {
+                    "type": "string",
+                    "example": "Sirup Marjan 460ml"
+                },
+                "component_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "description": "per output_quantity product jadi",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.BomLineRequest": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.BomListData": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "BOM-GIFT-A"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Keranjang rotan"
+                },
+                "output_quantity": {
+                    "description": "hasil satu kali rakit",
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Hampers Lebaran A"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-777"
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "services.BomListFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch bills of materials"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.BomListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.BomListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Bills of materials fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.Category": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Vitamin"
+                },
+                "sku_code": {
+                    "description": "kosong = 3 huruf pertama name",
+                    "type": "string",
+                    "example": "VIT"
+                }
+            }
+        },
+        "services.CategoryCreateData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Vitamin"
+                },
+                "sku_code": {
+                    "type": "string",
+                    "example": "VIT"
+                }
+            }
+        },
+        "services.CategoryCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.CategoryCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.CategoryCreateData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Category created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.CategoryDeleteFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to delete category"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.CategoryDeleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Category deleted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.CategoryFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch categories"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.CategorySuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.Category"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Categories fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.CategoryUpdateData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Vitamin"
+                },
+                "sku_code": {
+                    "type": "string",
+                    "example": "VIT"
+                }
+            }
+        },
+        "services.CategoryUpdateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.CategoryUpdateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.CategoryUpdateData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.Customer": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Sudirman No. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "CUS-ALFAMART"
+                },
+                "contact_name": {
+                    "type": "string",
+                    "example": "Siti"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "purchasing@alfamart.co.id"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PT Sumber Alfaria"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08123456789"
+                }
+            }
+        },
+        "services.CustomerCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.CustomerCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.Customer"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Customer created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.CustomerDeleteFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to delete customer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.CustomerDeleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Customer deleted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.CustomerFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch customers"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.CustomerSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.Customer"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Customers fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.CustomerUpdateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.CustomerUpdateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.Customer"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Customer updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.DocumentCreateData": {
+            "type": "object",
+            "properties": {
+                "doc_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "doc_type": {
+                    "type": "string",
+                    "example": "RECEIPT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DocumentLine"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Pengiriman mingguan"
+                },
+                "partner": {
+                    "type": "string",
+                    "example": "PT Indofood"
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.DocumentCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to post document"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.DocumentCreateRequest": {
+            "type": "object",
+            "properties": {
+                "doc_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "doc_type": {
+                    "type": "string",
+                    "example": "RECEIPT"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DocumentLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Pengiriman mingguan"
+                },
+                "partner": {
+                    "type": "string",
+                    "example": "PT Indofood"
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.DocumentCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.DocumentCreateData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Document posted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.DocumentDetail": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "doc_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "doc_type": {
+                    "type": "string",
+                    "example": "RECEIPT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DocumentDetailLine"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Pengiriman mingguan"
+                },
+                "partner": {
+                    "type": "string",
+                    "example": "PT Indofood"
+                },
+                "pic_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 1600
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.DocumentDetailFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch document"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.DocumentDetailLine": {
+            "type": "object",
+            "properties": {
+                "move_type": {
+                    "type": "string",
+                    "example": "IN"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "services.DocumentDetailSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.DocumentDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Document fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.DocumentLine": {
+            "type": "object",
+            "properties": {
+                "kit_product_id": {
+                    "description": "diisi jika baris ini komponen dari kit yang dikeluarkan",
+                    "type": "integer",
+                    "example": 7
+                },
+                "lots": {
+                    "description": "hanya untuk product lot-tracked",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DocumentLot"
+                    }
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "serial_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001"
+                    ]
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "services.DocumentLineRequest": {
+            "type": "object",
+            "properties": {
+                "expiry_date": {
+                    "description": "kedaluwarsa lot yang diterima",
+                    "type": "string",
+                    "example": "2026-05-31"
+                },
+                "lot_number": {
+                    "description": "wajib untuk RECEIPT product lot-tracked; ISSUE tanpa lot = FEFO",
+                    "type": "string",
+                    "example": "LOT-2025-11A"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "serial_numbers": {
+                    "description": "wajib untuk product serialized, satu per unit",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001"
+                    ]
+                }
+            }
+        },
+        "services.DocumentListData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "doc_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "doc_type": {
+                    "type": "string",
+                    "example": "RECEIPT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Pengiriman mingguan"
+                },
+                "partner": {
+                    "type": "string",
+                    "example": "PT Indofood"
+                },
+                "pic_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 1600
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                }
+            }
+        },
+        "services.DocumentListFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch documents"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.DocumentListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DocumentListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Documents fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.DocumentLot": {
+            "type": "object",
+            "properties": {
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2026-05-31"
+                },
+                "lot_number": {
+                    "type": "string",
+                    "example": "LOT-2025-11A"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "services.KitComponentRequest": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.LotExpiringFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "days must be a non-negative number"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.LotExpiringSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.LotListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Expiring lots fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.LotListData": {
+            "type": "object",
+            "properties": {
+                "days_left": {
+                    "description": "negatif jika sudah kedaluwarsa",
+                    "type": "integer",
+                    "example": 21
+                },
+                "expired": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2026-05-31"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lot_number": {
+                    "type": "string",
+                    "example": "LOT-2025-11A"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.LotListFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch lots"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.LotListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.LotListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Lots fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.LowStockFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch low stock products"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.LowStockItem": {
+            "type": "object",
+            "properties": {
+                "below_safety_stock": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_level": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "safety_stock": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "shortfall": {
+                    "type": "integer",
+                    "example": 38
+                },
+                "suggested_order": {
+                    "type": "integer",
+                    "example": 188
+                },
+                "warehouse_code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.LowStockSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.LowStockItem"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Low stock products fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.Product": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "base_unit": {
+                    "description": "satuan quantity, reserved dan available",
+                    "type": "string",
+                    "example": "PCS"
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Mie Sedap"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Mie"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image": {
+                    "type": "string",
+                    "example": "base64imagestring"
+                },
+                "in_unit": {
+                    "description": "hanya jika query unit diisi dan dikonfigurasi untuk product",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.UnitQuantity"
+                        }
+                    ]
+                },
+                "is_kit": {
+                    "description": "stok kit dihitung dari stok komponennya",
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_parent": {
+                    "description": "stok parent adalah total stok variannya",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "parent_id": {
+                    "description": "hanya untuk varian",
+                    "type": "integer",
+                    "example": 10
+                },
+                "price": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "quantity": {
+                    "description": "on hand",
+                    "type": "integer",
+                    "example": 150
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductStock"
+                    }
+                },
+                "variant_attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "variants": {
+                    "description": "hanya jika group_variants=true",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductVariant"
+                    }
+                }
+            }
+        },
+        "services.ProductBarcode": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "8992761111113"
+                },
+                "factor": {
+                    "description": "jumlah satuan dasar per 1 scan",
+                    "type": "integer",
+                    "example": 1
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "PCS"
+                }
+            }
+        },
+        "services.ProductBarcodeLookup": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "barcode": {
+                    "type": "string",
+                    "example": "8992761111113"
+                },
+                "base_unit": {
+                    "type": "string",
+                    "example": "PCS"
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Mie Sedap"
+                },
+                "factor": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "description": "on hand semua warehouse, satuan dasar",
+                    "type": "integer",
+                    "example": 150
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "CTN"
+                }
+            }
+        },
+        "services.ProductBarcodeLookupSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ProductBarcodeLookup"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductBarcodeRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "8992761111113"
+                },
+                "unit": {
+                    "description": "kosong = satuan dasar",
+                    "type": "string",
+                    "example": "CTN"
+                }
+            }
+        },
+        "services.ProductBarcodesFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid barcode \"8992761111112\": check digit should be 3"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductBarcodesSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductBarcode"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product barcodes updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductBarcodesUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "barcodes": {
+                    "description": "menggantikan semua barcode product",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductBarcodeRequest"
+                    }
+                }
+            }
+        },
+        "services.ProductCreateData": {
+            "type": "object",
+            "properties": {
+                "barcodes": {
+                    "description": "hanya jika barcodes dikirim",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductBarcode"
+                    }
+                },
+                "base_unit": {
+                    "type": "string",
+                    "example": "PCS"
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Mie Sedap"
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "components": {
+                    "description": "hanya jika components dikirim",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductKitComponent"
+                    }
+                },
+                "costing_method": {
+                    "type": "string",
+                    "example": "FIFO"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image": {
+                    "type": "string",
+                    "example": "base64imagestring"
+                },
+                "is_kit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_lot_tracked": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_parent": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_serialized": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_level": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "safety_stock": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-000042"
+                },
+                "variants": {
+                    "description": "hanya jika variants dikirim",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductVariant"
+                    }
+                }
+            }
+        },
+        "services.ProductCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to create product"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductDeleteFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to delete product"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductDeleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Product deleted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductDetail": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductBarcode"
+                    }
+                },
+                "base_unit": {
+                    "description": "satuan semua quantity stok",
+                    "type": "string",
+                    "example": "PCS"
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Mie Sedap"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Mie"
+                },
+                "components": {
+                    "description": "hanya untuk kit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductKitComponent"
+                    }
+                },
+                "costing_method": {
+                    "type": "string",
+                    "example": "FIFO"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image": {
+                    "type": "string",
+                    "example": "base64imagestring"
+                },
+                "in_unit": {
+                    "description": "hanya jika query unit diisi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.UnitQuantity"
+                        }
+                    ]
+                },
+                "is_kit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_lot_tracked": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_parent": {
+                    "description": "stok parent adalah total stok variannya",
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_serialized": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_level": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "parent_id": {
+                    "description": "hanya untuk varian",
+                    "type": "integer",
+                    "example": 10
+                },
+                "price": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "quantity": {
+                    "description": "on hand",
+                    "type": "integer",
+                    "example": 150
+                },
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "safety_stock": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "stock_value": {
+                    "description": "nilai stok saat ini dari layer biaya",
+                    "type": "number",
+                    "example": 450000
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductStock"
+                    }
+                },
+                "units": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductUnit"
+                    }
+                },
+                "variant_attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "variants": {
+                    "description": "hanya untuk parent",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductVariant"
+                    }
+                }
+            }
+        },
+        "services.ProductDetailFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch product"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductDetailSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ProductDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch product"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductKitComponent": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity": {
+                    "description": "per 1 kit, satuan dasar komponen",
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                }
+            }
+        },
+        "services.ProductKitFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid kit component: product 3 is serialized"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductKitSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductKitComponent"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product kit updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductKitUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "description": "menggantikan semua komponen; kosong = bukan kit lagi",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.KitComponentRequest"
+                    }
+                }
+            }
+        },
+        "services.ProductLedger": {
+            "type": "object",
+            "properties": {
+                "base_unit": {
+                    "type": "string",
+                    "example": "PCS"
+                },
+                "closing_balance": {
+                    "description": "saldo pada akhir end_date",
+                    "type": "integer",
+                    "example": 138
+                },
+                "current_quantity": {
+                    "description": "stocks.quantity sekarang",
+                    "type": "integer",
+                    "example": 138
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-31"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductLedgerEntry"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "opening_balance": {
+                    "description": "saldo sebelum start_date",
+                    "type": "integer",
+                    "example": 100
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-000042"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-12-01"
+                },
+                "total_entries": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "total_in": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "total_out": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse_id": {
+                    "description": "null = semua warehouse",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.ProductLedgerEntry": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "saldo product (semua warehouse dalam filter) sesudah movement",
+                    "type": "integer",
+                    "example": 138
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-12-14T20:15:30Z"
+                },
+                "entered_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entered_unit": {
+                    "type": "string",
+                    "example": "CTN"
+                },
+                "kit_sku": {
+                    "description": "kit asal jika movement ini komponen kit",
+                    "type": "string",
+                    "example": "SKU-000777"
+                },
+                "move_type": {
+                    "type": "string",
+                    "example": "OUT"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Kardus basah"
+                },
+                "quantity_in": {
+                    "description": "satuan dasar",
+                    "type": "integer",
+                    "example": 0
+                },
+                "quantity_out": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reason_code": {
+                    "type": "string",
+                    "example": "DAMAGE"
+                },
+                "reference_id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                },
+                "reference_type": {
+                    "description": "DOCUMENT | TRANSFER | ASSEMBLY | STOCK_COUNT | MANUAL",
+                    "type": "string",
+                    "example": "DOCUMENT"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "user_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "warehouse_balance": {
+                    "description": "saldo di warehouse movement sesudah movement",
+                    "type": "integer",
+                    "example": 88
+                },
+                "warehouse_code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.ProductLedgerFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "product not found"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductLedgerSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ProductLedger"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product ledger fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductLevelsData": {
+            "type": "object",
+            "properties": {
+                "max_level": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "safety_stock": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.ProductLevelsFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid stock levels"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductLevelsSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ProductLevelsData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product levels updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductPrice": {
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "type": "string",
+                    "example": "Admin"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "2025-12-14T20:15:30Z"
+                },
+                "effective_to": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 3500
+                }
+            }
+        },
+        "services.ProductPriceHistoryFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "product not found"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductPriceHistorySuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductPrice"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Price history fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductStock": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "in_unit": {
+                    "$ref": "#/definitions/services.UnitQuantity"
+                },
+                "max_level": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "quantity": {
+                    "description": "on hand",
+                    "type": "integer",
+                    "example": 50
+                },
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "safety_stock": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "warehouse_code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse_name": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                }
+            }
+        },
+        "services.ProductSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.Product"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductUnit": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "CTN"
+                },
+                "factor": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "is_base": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Karton"
+                }
+            }
+        },
+        "services.ProductUnitRequest": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "CTN"
+                }
+            }
+        },
+        "services.ProductUnitsFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Unit CTN not found"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductUnitsSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductUnit"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product units updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductUnitsUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "base_unit": {
+                    "type": "string",
+                    "example": "PCS"
+                },
+                "units": {
+                    "description": "menggantikan semua satuan alternatif",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ProductUnitRequest"
+                    }
+                }
+            }
+        },
+        "services.ProductUpdateData": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Mie Sedap"
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "costing_method": {
+                    "type": "string",
+                    "example": "FIFO"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image": {
+                    "type": "string",
+                    "example": "base64imagestring"
+                },
+                "is_lot_tracked": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_serialized": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_level": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "reorder_point": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "safety_stock": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-000001"
+                }
+            }
+        },
+        "services.ProductUpdateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to update product"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ProductUpdateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ProductUpdateData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Product updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ProductVariant": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "available": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap - Goreng / 75g"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 3500
+                },
+                "quantity": {
+                    "description": "on hand",
+                    "type": "integer",
+                    "example": 40
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042-01"
+                }
+            }
+        },
+        "services.PurchaseOrderCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to create purchase order"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.PurchaseOrderCreateRequest": {
+            "type": "object",
+            "properties": {
+                "expected_date": {
+                    "type": "string",
+                    "example": "2025-12-21"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PurchaseOrderLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Order mingguan"
+                },
+                "order_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "po_number": {
+                    "type": "string",
+                    "example": "PO-2025-0001"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.PurchaseOrderCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.PurchaseOrderDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Purchase order created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.PurchaseOrderDetail": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "expected_date": {
+                    "type": "string",
+                    "example": "2025-12-21"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PurchaseOrderLine"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Order mingguan"
+                },
+                "order_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "ordered_quantity": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "overdue": {
+                    "description": "lewat expected_date dan belum selesai diterima",
+                    "type": "boolean",
+                    "example": false
+                },
+                "po_number": {
+                    "type": "string",
+                    "example": "PO-2025-0001"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PurchaseOrderReceipt"
+                    }
+                },
+                "received_quantity": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "status": {
+                    "type": "string",
+                    "example": "SENT"
+                },
+                "supplier": {
+                    "type": "string",
+                    "example": "PT Indofood"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_amount": {
+                    "type": "number",
+                    "example": 900150
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.PurchaseOrderDetailFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch purchase order"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.PurchaseOrderDetailSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.PurchaseOrderDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Purchase order fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.PurchaseOrderLine": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "delivery": {
+                    "description": "PENDING | UNDER | COMPLETE | OVER",
+                    "type": "string",
+                    "example": "UNDER"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ordered_quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "outstanding_quantity": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "received_quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 3000.5
+                }
+            }
+        },
+        "services.PurchaseOrderLineRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "kosong = mata uang product",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 3000.5
+                }
+            }
+        },
+        "services.PurchaseOrderListData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "expected_date": {
+                    "type": "string",
+                    "example": "2025-12-21"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Order mingguan"
+                },
+                "order_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "ordered_quantity": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "overdue": {
+                    "description": "lewat expected_date dan belum selesai diterima",
+                    "type": "boolean",
+                    "example": false
+                },
+                "po_number": {
+                    "type": "string",
+                    "example": "PO-2025-0001"
+                },
+                "received_quantity": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "status": {
+                    "type": "string",
+                    "example": "SENT"
+                },
+                "supplier": {
+                    "type": "string",
+                    "example": "PT Indofood"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_amount": {
+                    "type": "number",
+                    "example": 900150
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.PurchaseOrderListFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch purchase orders"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.PurchaseOrderListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PurchaseOrderListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Purchase orders fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.PurchaseOrderReceipt": {
+            "type": "object",
+            "properties": {
+                "doc_date": {
+                    "type": "string",
+                    "example": "2025-12-20"
+                },
+                "document_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                }
+            }
+        },
+        "services.PurchaseOrderReceiveData": {
+            "type": "object",
+            "properties": {
+                "document_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PurchaseOrderReceiveLine"
+                    }
+                },
+                "over_delivery": {
+                    "description": "ada baris yang diterima melebihi pesanan",
+                    "type": "boolean",
+                    "example": false
+                },
+                "purchase_order_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "PARTIALLY_RECEIVED"
+                },
+                "under_delivery": {
+                    "description": "masih ada baris yang kurang",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "services.PurchaseOrderReceiveFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to receive goods"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.PurchaseOrderReceiveLine": {
+            "type": "object",
+            "properties": {
+                "delivery": {
+                    "type": "string",
+                    "example": "UNDER"
+                },
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2026-05-31"
+                },
+                "lot_number": {
+                    "type": "string",
+                    "example": "LOT-2025-11A"
+                },
+                "ordered_quantity": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "received_quantity": {
+                    "description": "total diterima termasuk penerimaan ini",
+                    "type": "integer",
+                    "example": 40
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "services.PurchaseOrderReceiveLineRequest": {
+            "type": "object",
+            "properties": {
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2026-05-31"
+                },
+                "lot_number": {
+                    "description": "wajib untuk product lot-tracked",
+                    "type": "string",
+                    "example": "LOT-2025-11A"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "serial_numbers": {
+                    "description": "wajib untuk product serialized, satu per unit",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001"
+                    ]
+                }
+            }
+        },
+        "services.PurchaseOrderReceiveRequest": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "description": "tutup order walau masih ada kekurangan",
+                    "type": "boolean",
+                    "example": false
+                },
+                "doc_date": {
+                    "type": "string",
+                    "example": "2025-12-20"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PurchaseOrderReceiveLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Pengiriman pertama"
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                }
+            }
+        },
+        "services.PurchaseOrderReceiveSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.PurchaseOrderReceiveData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Goods received successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.PurchaseOrderStatusFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Purchase order is already RECEIVED"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.PurchaseOrderStatusSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Purchase order sent successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ReasonCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "DAMAGE"
+                },
+                "direction": {
+                    "description": "IN | OUT | BOTH",
+                    "type": "string",
+                    "example": "OUT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Barang rusak"
+                }
+            }
+        },
+        "services.ReasonCodeCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ReasonCodeCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ReasonCode"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reason code created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ReasonCodeDeleteFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to delete reason code"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ReasonCodeDeleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Reason code deleted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ReasonCodeFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch reason codes"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ReasonCodeSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ReasonCode"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reason codes fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ReasonCodeUpdateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ReasonCodeUpdateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ReasonCode"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reason code updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ReconciliationCorrectData": {
+            "type": "object",
+            "properties": {
+                "confirmed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "corrections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ReconciliationCorrection"
+                    }
+                },
+                "run_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.ReconciliationCorrectRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "false = hanya preview",
+                    "type": "boolean",
+                    "example": true
+                },
+                "drift_ids": {
+                    "description": "kosong = semua drift terbuka di run ini",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "services.ReconciliationCorrectSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ReconciliationCorrectData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Correcting adjustments posted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ReconciliationCorrection": {
+            "type": "object",
+            "properties": {
+                "drift_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "move_type": {
+                    "description": "kosong jika ledger sudah sama dengan stocks",
+                    "type": "string",
+                    "example": "ADJ_OUT"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "transaction_id": {
+                    "description": "null untuk preview",
+                    "type": "integer",
+                    "example": 501
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.ReconciliationDetail": {
+            "type": "object",
+            "properties": {
+                "checked_rows": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "Admin"
+                },
+                "drift_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "drifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ReconciliationDrift"
+                    }
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2026-01-01T02:00:04Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "open_drifts": {
+                    "description": "drift yang belum dikoreksi",
+                    "type": "integer",
+                    "example": 1
+                },
+                "source": {
+                    "description": "MANUAL | SCHEDULED | COMMAND",
+                    "type": "string",
+                    "example": "SCHEDULED"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-01-01T02:00:00Z"
+                }
+            }
+        },
+        "services.ReconciliationDetailSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ReconciliationDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reconciliation run fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ReconciliationDrift": {
+            "type": "object",
+            "properties": {
+                "corrected_at": {
+                    "type": "string",
+                    "example": "2026-01-02T09:00:00Z"
+                },
+                "corrected_by": {
+                    "type": "string",
+                    "example": "Admin"
+                },
+                "difference": {
+                    "description": "stock - ledger",
+                    "type": "integer",
+                    "example": -2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ledger_quantity": {
+                    "type": "integer",
+                    "example": 140
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "sku": {
+                    "description": "kosong jika product sudah dihapus",
+                    "type": "string",
+                    "example": "SKU-000042"
+                },
+                "status": {
+                    "description": "OPEN | CORRECTED",
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "stock_quantity": {
+                    "description": "null jika baris stocks tidak ada",
+                    "type": "integer",
+                    "example": 138
+                },
+                "transaction_id": {
+                    "description": "adjustment koreksi",
+                    "type": "integer",
+                    "example": 501
+                },
+                "warehouse_code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.ReconciliationFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Reconciliation run not found"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ReconciliationListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ReconciliationRunData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reconciliation runs fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.ReconciliationRunData": {
+            "type": "object",
+            "properties": {
+                "checked_rows": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "Admin"
+                },
+                "drift_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2026-01-01T02:00:04Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "open_drifts": {
+                    "description": "drift yang belum dikoreksi",
+                    "type": "integer",
+                    "example": 1
+                },
+                "source": {
+                    "description": "MANUAL | SCHEDULED | COMMAND",
+                    "type": "string",
+                    "example": "SCHEDULED"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-01-01T02:00:00Z"
+                }
+            }
+        },
+        "services.ReconciliationRunSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ReconciliationRunData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reconciliation finished"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SKUPattern": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Mie"
+                },
+                "example": {
+                    "description": "hasil pola dengan nomor urut 1",
+                    "type": "string",
+                    "example": "MIE-MIE-00001"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "{CATEGORY}-{BRAND}-{SEQ:5}"
+                }
+            }
+        },
+        "services.SKUPatternDeleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "SKU pattern deleted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SKUPatternFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch SKU patterns"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SKUPatternSaveSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.SKUPattern"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "SKU pattern saved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SKUPatternSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SKUPattern"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "SKU patterns fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SalesOrderCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to create sales order"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SalesOrderCreateRequest": {
+            "type": "object",
+            "properties": {
+                "confirm": {
+                    "description": "langsung reserve stok",
+                    "type": "boolean",
+                    "example": true
+                },
+                "customer_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesOrderLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Kirim sebelum jam 10"
+                },
+                "order_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "so_number": {
+                    "type": "string",
+                    "example": "SO-2025-0001"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.SalesOrderCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.SalesOrderDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Sales order created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SalesOrderDetail": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "customer": {
+                    "type": "string",
+                    "example": "PT Sumber Alfaria"
+                },
+                "customer_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "fulfilled_quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesOrderLine"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Kirim sebelum jam 10"
+                },
+                "order_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "ordered_quantity": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "shipments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesOrderShipment"
+                    }
+                },
+                "so_number": {
+                    "type": "string",
+                    "example": "SO-2025-0001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "CONFIRMED"
+                },
+                "total_amount": {
+                    "type": "number",
+                    "example": 210000
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.SalesOrderDetailFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch sales order"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SalesOrderDetailSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.SalesOrderDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Sales order fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SalesOrderFulfilData": {
+            "type": "object",
+            "properties": {
+                "document_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesOrderFulfilLine"
+                    }
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "GI-7"
+                },
+                "sales_order_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "example": "PARTIALLY_FULFILLED"
+                }
+            }
+        },
+        "services.SalesOrderFulfilFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fulfil sales order"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SalesOrderFulfilLine": {
+            "type": "object",
+            "properties": {
+                "fulfilled_quantity": {
+                    "description": "total dikirim termasuk pengiriman ini",
+                    "type": "integer",
+                    "example": 5
+                },
+                "lots": {
+                    "description": "lot yang dikirim, hanya product lot-tracked",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesOrderFulfilLot"
+                    }
+                },
+                "ordered_quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "serial_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001"
+                    ]
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "services.SalesOrderFulfilLineRequest": {
+            "type": "object",
+            "properties": {
+                "lot_number": {
+                    "description": "product lot-tracked: kosong = FEFO",
+                    "type": "string",
+                    "example": "LOT-2025-11A"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "serial_numbers": {
+                    "description": "wajib untuk product serialized, satu per unit",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001"
+                    ]
+                }
+            }
+        },
+        "services.SalesOrderFulfilLot": {
+            "type": "object",
+            "properties": {
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2026-05-31"
+                },
+                "lot_number": {
+                    "type": "string",
+                    "example": "LOT-2025-11A"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "services.SalesOrderFulfilRequest": {
+            "type": "object",
+            "properties": {
+                "doc_date": {
+                    "type": "string",
+                    "example": "2025-12-15"
+                },
+                "lines": {
+                    "description": "kosong = kirim semua sisa order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesOrderFulfilLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Pengiriman pertama"
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "SJ-OUT-2025-0001"
+                }
+            }
+        },
+        "services.SalesOrderFulfilSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.SalesOrderFulfilData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Sales order fulfilled successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SalesOrderLine": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "fulfilled_quantity": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ordered_quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "outstanding_quantity": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "reserved_quantity": {
+                    "description": "outstanding yang sedang di-reserve",
+                    "type": "integer",
+                    "example": 15
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 3500
+                }
+            }
+        },
+        "services.SalesOrderLineRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "kosong = mata uang product",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 3500
+                }
+            }
+        },
+        "services.SalesOrderListData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "customer": {
+                    "type": "string",
+                    "example": "PT Sumber Alfaria"
+                },
+                "customer_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "fulfilled_quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Kirim sebelum jam 10"
+                },
+                "order_date": {
+                    "type": "string",
+                    "example": "2025-12-14"
+                },
+                "ordered_quantity": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "so_number": {
+                    "type": "string",
+                    "example": "SO-2025-0001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "CONFIRMED"
+                },
+                "total_amount": {
+                    "type": "number",
+                    "example": 210000
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.SalesOrderListFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch sales orders"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SalesOrderListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesOrderListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Sales orders fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SalesOrderShipment": {
+            "type": "object",
+            "properties": {
+                "doc_date": {
+                    "type": "string",
+                    "example": "2025-12-15"
+                },
+                "document_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "reference_no": {
+                    "type": "string",
+                    "example": "GI-7"
+                }
+            }
+        },
+        "services.SalesOrderStatusFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Insufficient stock for product 1 at warehouse 1: 4 available"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SalesOrderStatusSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Sales order confirmed successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SerialDetail": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SerialMovement"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Barcode Scanner Zebra DS2208"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "SN-0001"
+                },
+                "status": {
+                    "description": "IN_STOCK | OUT",
+                    "type": "string",
+                    "example": "IN_STOCK"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "description": "lokasi terakhir",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.SerialListData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Barcode Scanner Zebra DS2208"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "serial_number": {
+                    "type": "string",
+                    "example": "SN-0001"
+                },
+                "status": {
+                    "description": "IN_STOCK | OUT",
+                    "type": "string",
+                    "example": "IN_STOCK"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "description": "lokasi terakhir",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.SerialListFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch serial numbers"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SerialListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SerialListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Serial numbers fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SerialLookupFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "serial number not found"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SerialLookupSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SerialDetail"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Serial number fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.SerialMovement": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "document_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "document_ref": {
+                    "type": "string",
+                    "example": "GI-7"
+                },
+                "move_type": {
+                    "type": "string",
+                    "example": "OUT"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Dikirim ke customer"
+                },
+                "pic_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "reason_code": {
+                    "type": "string",
+                    "example": "DAMAGE"
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "transfer_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.StockAsOfProduct": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "description": "total semua warehouse, satuan dasar",
+                    "type": "integer",
+                    "example": 150
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "SKU-000042"
+                },
+                "warehouses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StockAsOfWarehouse"
+                    }
+                }
+            }
+        },
+        "services.StockAsOfReport": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59Z"
+                },
+                "in_sync": {
+                    "description": "semua baris cocok dengan stocks",
+                    "type": "boolean",
+                    "example": true
+                },
+                "mismatches": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StockAsOfProduct"
+                    }
+                },
+                "snapshot_at": {
+                    "type": "string",
+                    "example": "2025-11-30T23:59:59Z"
+                },
+                "snapshot_id": {
+                    "description": "snapshot dasar, null = dihitung dari seluruh ledger",
+                    "type": "integer",
+                    "example": 3
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 1500
+                }
+            }
+        },
+        "services.StockAsOfSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StockAsOfReport"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Stock as of fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockAsOfWarehouse": {
+            "type": "object",
+            "properties": {
+                "in_sync": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "ledger_quantity": {
+                    "description": "saldo sekarang menurut snapshot + ledger",
+                    "type": "integer",
+                    "example": 42
+                },
+                "live_quantity": {
+                    "description": "stocks.quantity sekarang",
+                    "type": "integer",
+                    "example": 42
+                },
+                "quantity": {
+                    "description": "saldo pada as_of",
+                    "type": "integer",
+                    "example": 50
+                },
+                "warehouse_code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse_name": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                }
+            }
+        },
+        "services.StockCountAdjustment": {
+            "type": "object",
+            "properties": {
+                "move_type": {
+                    "type": "string",
+                    "example": "ADJ_OUT"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "serial_numbers": {
+                    "description": "hanya product serialized",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "variance": {
+                    "type": "integer",
+                    "example": -2
+                }
+            }
+        },
+        "services.StockCountApproveData": {
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StockCountAdjustment"
+                    }
+                },
+                "approved_at": {
+                    "type": "string",
+                    "example": "2024-12-15T08:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "example": "APPROVED"
+                }
+            }
+        },
+        "services.StockCountApproveFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to approve stock count"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.StockCountApproveSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StockCountApproveData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Stock count approved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockCountCancelSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Stock count cancelled successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockCountCreateData": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Stock opname akhir bulan"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.StockCountCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to create stock count"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.StockCountCreateRequest": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "0 = semua category",
+                    "type": "integer",
+                    "example": 0
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Stock opname akhir bulan"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.StockCountCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StockCountCreateData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Stock count created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockCountDetail": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string",
+                    "example": "2024-12-15T08:00:00Z"
+                },
+                "approved_by": {
+                    "type": "string",
+                    "example": "Admin"
+                },
+                "category": {
+                    "description": "kosong = semua category",
+                    "type": "string",
+                    "example": "Mie"
+                },
+                "counted_lines": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StockCountLine"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Stock opname akhir bulan"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.StockCountDetailFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch stock count"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.StockCountDetailSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StockCountDetail"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Stock count fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockCountLine": {
+            "type": "object",
+            "properties": {
+                "counted_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "counted_quantity": {
+                    "description": "null = belum dihitung",
+                    "type": "integer",
+                    "example": 38
+                },
+                "expected_quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "transaction_id": {
+                    "description": "adjustment hasil approve, NULL untuk product serialized",
+                    "type": "integer",
+                    "example": 10
+                },
+                "transaction_ids": {
+                    "description": "product serialized: adjustment keluar dan / atau masuk per nomor seri",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "variance": {
+                    "type": "integer",
+                    "example": -2
+                }
+            }
+        },
+        "services.StockCountListData": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string",
+                    "example": "2024-12-15T08:00:00Z"
+                },
+                "approved_by": {
+                    "type": "string",
+                    "example": "Admin"
+                },
+                "category": {
+                    "description": "kosong = semua category",
+                    "type": "string",
+                    "example": "Mie"
+                },
+                "counted_lines": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Stock opname akhir bulan"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.StockCountListFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch stock counts"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.StockCountListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StockCountListData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Stock counts fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockCountSubmitFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to submit counts"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.StockCountSubmitLine": {
+            "type": "object",
+            "properties": {
+                "counted_quantity": {
+                    "type": "integer",
+                    "example": 38
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "serial_numbers": {
+                    "description": "wajib untuk product serialized, jumlahnya = counted_quantity",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "services.StockCountSubmitRequest": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StockCountSubmitLine"
+                    }
+                }
+            }
+        },
+        "services.StockCountSubmitSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Counts submitted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockCountVarianceFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch variance report"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.StockCountVarianceLine": {
+            "type": "object",
+            "properties": {
+                "counted_quantity": {
+                    "type": "integer",
+                    "example": 38
+                },
+                "expected_quantity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "found_serial_numbers": {
+                    "description": "hanya product serialized, akan masuk saat approve",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "missing_serial_numbers": {
+                    "description": "hanya product serialized, akan keluar saat approve",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "SKU-20251214201530-042"
+                },
+                "unit_cost": {
+                    "description": "OPEN: biaya satuan saat ini dari cost layer; selain itu: dari adjustment yang diposting",
+                    "type": "number",
+                    "example": 3000
+                },
+                "value_impact": {
+                    "type": "number",
+                    "example": -6000
+                },
+                "variance": {
+                    "type": "integer",
+                    "example": -2
+                }
+            }
+        },
+        "services.StockCountVarianceReport": {
+            "type": "object",
+            "properties": {
+                "counted_lines": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "gain_value": {
+                    "type": "number",
+                    "example": 3000
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StockCountVarianceLine"
+                    }
+                },
+                "loss_value": {
+                    "type": "number",
+                    "example": -6000
+                },
+                "net_value": {
+                    "type": "number",
+                    "example": -3000
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "stock_count_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "variance_lines": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "services.StockCountVarianceSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StockCountVarianceReport"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Variance report fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockSnapshotCreateRequest": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Tutup buku Desember"
+                },
+                "snapshot_at": {
+                    "description": "RFC3339 atau YYYY-MM-DD (akhir hari tersebut), default akhir hari kemarin",
+                    "type": "string",
+                    "example": "2025-12-31"
+                }
+            }
+        },
+        "services.StockSnapshotCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StockSnapshotData"
+                },
+                "message": {
                     "type": "string",
-                    "example": "Login successful"
+                    "example": "Stock snapshot created successfully"
                 },
                 "status": {
                     "type": "string",
@@ -1029,33 +11708,124 @@ const docTemplate = `{
                 }
             }
         },
-        "services.Category": {
+        "services.StockSnapshotData": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-01T08:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "Admin"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "name": {
+                "notes": {
                     "type": "string",
-                    "example": "Vitamin"
+                    "example": "Tutup buku Desember"
+                },
+                "snapshot_at": {
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59Z"
+                },
+                "total_lines": {
+                    "description": "baris product per warehouse dengan saldo tidak nol",
+                    "type": "integer",
+                    "example": 120
+                },
+                "total_quantity": {
+                    "description": "satuan dasar",
+                    "type": "integer",
+                    "example": 15000
                 }
             }
         },
-        "services.CategoryCreateData": {
+        "services.StockSnapshotDeleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Stock snapshot deleted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.StockSnapshotFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "A snapshot already exists at this time"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.StockSnapshotListSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StockSnapshotData"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Stock snapshots fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.Supplier": {
             "type": "object",
             "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Sudirman No. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SUP-INDOFOOD"
+                },
+                "contact_name": {
+                    "type": "string",
+                    "example": "Budi"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "sales@indofood.co.id"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
                 "name": {
                     "type": "string",
-                    "example": "Vitamin"
+                    "example": "PT Indofood"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08123456789"
                 }
             }
         },
-        "services.CategoryCreateFailResp": {
+        "services.SupplierCreateFailResp": {
             "type": "object",
             "properties": {
                 "message": {
@@ -1068,15 +11838,15 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CategoryCreateSuccessResp": {
+        "services.SupplierCreateSuccessResp": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/services.CategoryCreateData"
+                    "$ref": "#/definitions/services.Supplier"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Category created successfully"
+                    "example": "Supplier created successfully"
                 },
                 "status": {
                     "type": "string",
@@ -1084,12 +11854,12 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CategoryDeleteFailResp": {
+        "services.SupplierDeleteFailResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Failed to delete category"
+                    "example": "Failed to delete supplier"
                 },
                 "status": {
                     "type": "string",
@@ -1097,12 +11867,12 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CategoryDeleteSuccessResp": {
+        "services.SupplierDeleteSuccessResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Category deleted successfully"
+                    "example": "Supplier deleted successfully"
                 },
                 "status": {
                     "type": "string",
@@ -1110,12 +11880,12 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CategoryFailResp": {
+        "services.SupplierFailResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Failed to fetch categories"
+                    "example": "Failed to fetch suppliers"
                 },
                 "status": {
                     "type": "string",
@@ -1123,18 +11893,18 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CategorySuccessResp": {
+        "services.SupplierSuccessResp": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/services.Category"
+                        "$ref": "#/definitions/services.Supplier"
                     }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Categories fetched successfully"
+                    "example": "Suppliers fetched successfully"
                 },
                 "status": {
                     "type": "string",
@@ -1142,25 +11912,281 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CategoryUpdateData": {
+        "services.SupplierUpdateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.SupplierUpdateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.Supplier"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Supplier updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.TransactionCreateData": {
             "type": "object",
             "properties": {
+                "components": {
+                    "description": "hanya untuk kit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TransactionKitComponent"
+                    }
+                },
+                "cost_value": {
+                    "description": "biaya perolehan (masuk) atau COGS (keluar)",
+                    "type": "number",
+                    "example": 240000
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "name": {
+                "lots": {
+                    "description": "hanya untuk product lot-tracked",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TransactionLot"
+                    }
+                },
+                "move_type": {
+                    "description": "IN | OUT | ADJ_IN | ADJ_OUT",
+                    "type": "string",
+                    "example": "in"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Kardus basah"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "description": "dalam satuan dasar product",
+                    "type": "integer",
+                    "example": 80
+                },
+                "reason_code": {
+                    "type": "string",
+                    "example": "DAMAGE"
+                },
+                "serial_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001",
+                        "SN-0002"
+                    ]
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "CTN"
+                },
+                "unit_cost": {
+                    "description": "biaya per satuan dasar",
+                    "type": "number",
+                    "example": 3000
+                },
+                "unit_quantity": {
+                    "description": "quantity seperti yang diinput dalam unit",
+                    "type": "integer",
+                    "example": 2
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "warehouse_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.TransactionCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to create transaction"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.TransactionKitComponent": {
+            "type": "object",
+            "properties": {
+                "lots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TransactionLot"
+                    }
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "quantity": {
+                    "description": "quantity kit x quantity komponen per kit",
+                    "type": "integer",
+                    "example": 4
+                },
+                "transaction_id": {
+                    "type": "integer",
+                    "example": 11
+                }
+            }
+        },
+        "services.TransactionListData": {
+            "type": "object",
+            "properties": {
+                "assembly_order_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "base_unit": {
+                    "type": "string",
+                    "example": "PCS"
+                },
+                "cost_value": {
+                    "description": "biaya perolehan (masuk) atau COGS (keluar)",
+                    "type": "number",
+                    "example": 240000
+                },
+                "created_at": {
+                    "description": "ISO 8601 format",
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "document_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "document_ref": {
+                    "type": "string",
+                    "example": "SJ-2025-0001"
+                },
+                "entered_quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "entered_unit": {
+                    "type": "string",
+                    "example": "CTN"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kit_quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "kit_sku": {
+                    "description": "kit asal jika baris ini komponen kit",
+                    "type": "string",
+                    "example": "SKU-20251214201530-777"
+                },
+                "lot_numbers": {
+                    "description": "lot yang disentuh, hanya product lot-tracked",
+                    "type": "string",
+                    "example": "LOT-2025-11A, LOT-2025-12B"
+                },
+                "move_type": {
+                    "type": "string",
+                    "example": "in"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Kardus basah"
+                },
+                "pic_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "product_brand": {
+                    "type": "string",
+                    "example": "Sedap"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "product_sku": {
+                    "type": "string",
+                    "example": "MIE001"
+                },
+                "quantity": {
+                    "description": "dalam satuan dasar",
+                    "type": "integer",
+                    "example": 80
+                },
+                "reason_code": {
+                    "type": "string",
+                    "example": "DAMAGE"
+                },
+                "reason_name": {
+                    "type": "string",
+                    "example": "Barang rusak"
+                },
+                "serial_numbers": {
+                    "description": "hanya product serialized",
+                    "type": "string",
+                    "example": "SN-0001, SN-0002"
+                },
+                "transfer_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "unit_cost": {
+                    "description": "biaya per satuan dasar",
+                    "type": "number",
+                    "example": 3000
+                },
+                "unit_price": {
+                    "description": "harga product saat movement terjadi",
+                    "type": "number",
+                    "example": 5000
+                },
+                "warehouse": {
                     "type": "string",
-                    "example": "Vitamin"
+                    "example": "Main Warehouse"
                 }
             }
         },
-        "services.CategoryUpdateFailResp": {
+        "services.TransactionListFailResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Invalid parameter"
+                    "example": "Failed to fetch transaction"
                 },
                 "status": {
                     "type": "string",
@@ -1168,67 +12194,122 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CategoryUpdateSuccessResp": {
+        "services.TransactionLot": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.CategoryUpdateData"
-                },
-                "message": {
+                "expiry_date": {
                     "type": "string",
-                    "example": "User updated successfully"
+                    "example": "2026-05-31"
                 },
-                "status": {
+                "lot_number": {
                     "type": "string",
-                    "example": "success"
+                    "example": "LOT-2025-11A"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 40
                 }
             }
         },
-        "services.Product": {
+        "services.TransferCreateData": {
             "type": "object",
             "properties": {
-                "brand": {
+                "created_at": {
                     "type": "string",
-                    "example": "Mie Sedap"
+                    "example": "2024-12-14T20:15:30Z"
                 },
-                "category": {
-                    "type": "string",
-                    "example": "Mie"
+                "from_warehouse_id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "image": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TransferLine"
+                    }
+                },
+                "notes": {
                     "type": "string",
-                    "example": "base64imagestring"
+                    "example": "Restock toko"
                 },
-                "name": {
+                "to_warehouse_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.TransferCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
                     "type": "string",
-                    "example": "Mie Sedap Goreng"
+                    "example": "Failed to create transfer"
                 },
-                "price": {
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.TransferCreateRequest": {
+            "type": "object",
+            "properties": {
+                "from_warehouse_id": {
                     "type": "integer",
-                    "example": 100000
+                    "example": 1
                 },
-                "quantity": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TransferLineRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Restock toko"
+                },
+                "to_warehouse_id": {
                     "type": "integer",
-                    "example": 150
+                    "example": 2
+                }
+            }
+        },
+        "services.TransferCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.TransferCreateData"
                 },
-                "sku": {
+                "message": {
                     "type": "string",
-                    "example": "SKU-20251214201530-042"
+                    "example": "Transfer created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "services.ProductCreateData": {
+        "services.TransferDetail": {
             "type": "object",
             "properties": {
-                "brand": {
+                "created_at": {
                     "type": "string",
-                    "example": "Mie Sedap"
+                    "example": "2024-12-14T20:15:30Z"
                 },
-                "category_id": {
+                "from_warehouse": {
+                    "type": "string",
+                    "example": "Main Warehouse"
+                },
+                "from_warehouse_id": {
                     "type": "integer",
                     "example": 1
                 },
@@ -1236,30 +12317,44 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
-                "image": {
-                    "type": "string",
-                    "example": "base64imagestring"
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TransferDetailLine"
+                    }
                 },
-                "name": {
+                "notes": {
                     "type": "string",
-                    "example": "Mie Sedap Goreng"
+                    "example": "Restock toko"
                 },
-                "price": {
+                "pic_name": {
                     "type": "string",
-                    "example": "10000"
+                    "example": "John Doe"
                 },
-                "sku": {
+                "to_warehouse": {
                     "type": "string",
-                    "example": "SKU-20251214201530-042"
+                    "example": "Toko"
+                },
+                "to_warehouse_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 30
                 }
             }
         },
-        "services.ProductCreateFailResp": {
+        "services.TransferDetailFailResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Failed to create product"
+                    "example": "Failed to fetch transfer"
                 },
                 "status": {
                     "type": "string",
@@ -1267,25 +12362,44 @@ const docTemplate = `{
                 }
             }
         },
-        "services.ProductDeleteFailResp": {
+        "services.TransferDetailLine": {
             "type": "object",
             "properties": {
-                "message": {
+                "in_transaction_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "out_transaction_id": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_name": {
                     "type": "string",
-                    "example": "Failed to delete product"
+                    "example": "Mie Sedap Goreng"
                 },
-                "status": {
+                "product_sku": {
                     "type": "string",
-                    "example": "error"
+                    "example": "SKU-20251214201530-042"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "services.ProductDeleteSuccessResp": {
+        "services.TransferDetailSuccessResp": {
             "type": "object",
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.TransferDetail"
+                },
                 "message": {
                     "type": "string",
-                    "example": "Product deleted successfully"
+                    "example": "Transfer fetched successfully"
                 },
                 "status": {
                     "type": "string",
@@ -1293,78 +12407,102 @@ const docTemplate = `{
                 }
             }
         },
-        "services.ProductDetail": {
+        "services.TransferLine": {
             "type": "object",
             "properties": {
-                "brand": {
-                    "type": "string",
-                    "example": "Mie Sedap"
+                "in_transaction_id": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "category": {
-                    "type": "string",
-                    "example": "Mie"
+                "out_transaction_id": {
+                    "type": "integer",
+                    "example": 11
                 },
-                "id": {
+                "product_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "image": {
-                    "type": "string",
-                    "example": "base64imagestring"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Mie Sedap Goreng"
-                },
-                "price": {
-                    "type": "string",
-                    "example": "10000"
-                },
                 "quantity": {
                     "type": "integer",
-                    "example": 150
+                    "example": 10
                 },
-                "sku": {
-                    "type": "string",
-                    "example": "SKU-20251214201530-042"
+                "serial_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001"
+                    ]
                 }
             }
         },
-        "services.ProductDetailFailResp": {
+        "services.TransferLineRequest": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Failed to fetch product"
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "status": {
-                    "type": "string",
-                    "example": "error"
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "serial_numbers": {
+                    "description": "wajib untuk product serialized, satu per unit",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SN-0001"
+                    ]
                 }
             }
         },
-        "services.ProductDetailSuccessResp": {
+        "services.TransferListData": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.ProductDetail"
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-14T20:15:30Z"
                 },
-                "message": {
+                "from_warehouse": {
                     "type": "string",
-                    "example": "Product fetched successfully"
+                    "example": "Main Warehouse"
                 },
-                "status": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
                     "type": "string",
-                    "example": "success"
+                    "example": "Restock toko"
+                },
+                "pic_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "to_warehouse": {
+                    "type": "string",
+                    "example": "Toko"
+                },
+                "total_lines": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 30
                 }
             }
         },
-        "services.ProductFailResp": {
+        "services.TransferListFailResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Failed to fetch product"
+                    "example": "Failed to fetch transfers"
                 },
                 "status": {
                     "type": "string",
@@ -1372,18 +12510,18 @@ const docTemplate = `{
                 }
             }
         },
-        "services.ProductSuccessResp": {
+        "services.TransferListSuccessResp": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/services.Product"
+                        "$ref": "#/definitions/services.TransferListData"
                     }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Product fetched successfully"
+                    "example": "Transfers fetched successfully"
                 },
                 "status": {
                     "type": "string",
@@ -1391,45 +12529,29 @@ const docTemplate = `{
                 }
             }
         },
-        "services.ProductUpdateData": {
+        "services.Unit": {
             "type": "object",
             "properties": {
-                "brand": {
+                "code": {
                     "type": "string",
-                    "example": "Mie Sedap"
-                },
-                "category_id": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "CTN"
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "image": {
-                    "type": "string",
-                    "example": "base64imagestring"
-                },
                 "name": {
                     "type": "string",
-                    "example": "Mie Sedap Goreng"
-                },
-                "price": {
-                    "type": "integer",
-                    "example": 10000
-                },
-                "sku": {
-                    "type": "string",
-                    "example": "SKU-000001"
+                    "example": "Karton"
                 }
             }
         },
-        "services.ProductUpdateFailResp": {
+        "services.UnitCreateFailResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Failed to update product"
+                    "example": "Invalid parameter"
                 },
                 "status": {
                     "type": "string",
@@ -1437,15 +12559,15 @@ const docTemplate = `{
                 }
             }
         },
-        "services.ProductUpdateSuccessResp": {
+        "services.UnitCreateSuccessResp": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/services.ProductUpdateData"
+                    "$ref": "#/definitions/services.Unit"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Product updated successfully"
+                    "example": "Unit created successfully"
                 },
                 "status": {
                     "type": "string",
@@ -1453,38 +12575,38 @@ const docTemplate = `{
                 }
             }
         },
-        "services.TransactionCreateData": {
+        "services.UnitDeleteFailResp": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "move_type": {
-                    "description": "IN | OUT",
+                "message": {
                     "type": "string",
-                    "example": "in"
-                },
-                "product_id": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "Failed to delete unit"
                 },
-                "quantity": {
-                    "type": "integer",
-                    "example": 100
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.UnitDeleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Unit deleted successfully"
                 },
-                "user_id": {
-                    "type": "integer",
-                    "example": 1
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "services.TransactionCreateFailResp": {
+        "services.UnitFailResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Failed to create transaction"
+                    "example": "Failed to fetch units"
                 },
                 "status": {
                     "type": "string",
@@ -1492,54 +12614,56 @@ const docTemplate = `{
                 }
             }
         },
-        "services.TransactionListData": {
+        "services.UnitQuantity": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "description": "ISO 8601 format",
-                    "type": "string",
-                    "example": "2024-12-14T20:15:30Z"
+                "available": {
+                    "type": "number",
+                    "example": 3
                 },
-                "id": {
+                "factor": {
                     "type": "integer",
-                    "example": 1
+                    "example": 40
                 },
-                "move_type": {
-                    "type": "string",
-                    "example": "in"
+                "quantity": {
+                    "type": "number",
+                    "example": 3.75
                 },
-                "pic_name": {
-                    "type": "string",
-                    "example": "John Doe"
+                "reserved": {
+                    "type": "number",
+                    "example": 0.75
                 },
-                "product_brand": {
+                "unit": {
                     "type": "string",
-                    "example": "Sedap"
+                    "example": "CTN"
+                }
+            }
+        },
+        "services.UnitSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.Unit"
+                    }
                 },
-                "product_name": {
+                "message": {
                     "type": "string",
-                    "example": "Mie Sedap Goreng"
-                },
-                "product_price": {
-                    "type": "integer",
-                    "example": 5000
+                    "example": "Units fetched successfully"
                 },
-                "product_sku": {
+                "status": {
                     "type": "string",
-                    "example": "MIE001"
-                },
-                "quantity": {
-                    "type": "integer",
-                    "example": 10
+                    "example": "success"
                 }
             }
         },
-        "services.TransactionListFailResp": {
+        "services.UnitUpdateFailResp": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Failed to fetch transaction"
+                    "example": "Invalid parameter"
                 },
                 "status": {
                     "type": "string",
@@ -1547,6 +12671,22 @@ const docTemplate = `{
                 }
             }
         },
+        "services.UnitUpdateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.Unit"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Unit updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
         "services.User": {
             "type": "object",
             "properties": {
@@ -1811,6 +12951,255 @@ const docTemplate = `{
                     "example": "success"
                 }
             }
+        },
+        "services.ValuationFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "group_by must be product, category or warehouse"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.ValuationLine": {
+            "type": "object",
+            "properties": {
+                "average_cost": {
+                    "type": "number",
+                    "example": 3000
+                },
+                "code": {
+                    "description": "SKU, sku_code category atau code warehouse",
+                    "type": "string",
+                    "example": "SKU-000042"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mie Sedap Goreng"
+                },
+                "quantity": {
+                    "description": "satuan dasar",
+                    "type": "integer",
+                    "example": 150
+                },
+                "value": {
+                    "type": "number",
+                    "example": 450000
+                }
+            }
+        },
+        "services.ValuationReport": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59Z"
+                },
+                "group_by": {
+                    "type": "string",
+                    "example": "product"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ValuationLine"
+                    }
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "total_value": {
+                    "type": "number",
+                    "example": 4500000
+                }
+            }
+        },
+        "services.ValuationSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ValuationReport"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Valuation fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.Warehouse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Sudirman No. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "WH-JKT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gudang Jakarta"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1500
+                }
+            }
+        },
+        "services.WarehouseCreateData": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Sudirman No. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "WH-JKT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gudang Jakarta"
+                }
+            }
+        },
+        "services.WarehouseCreateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.WarehouseCreateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.WarehouseCreateData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Warehouse created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.WarehouseDeleteFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to delete warehouse"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.WarehouseDeleteSuccessResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Warehouse deleted successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.WarehouseFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to fetch warehouses"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.WarehouseSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.Warehouse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Warehouses fetched successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "services.WarehouseUpdateFailResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid parameter"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "services.WarehouseUpdateSuccessResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.WarehouseCreateData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Warehouse updated successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
         }
     },
     "securityDefinitions": {
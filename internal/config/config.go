@@ -15,6 +15,9 @@ type Config struct {
 	DBPort     string
 	JWTSecret  string
 	SwaggerURL string
+
+	ReconcileInterval string // interval job rekonsiliasi stok, mis. "24h"; "0" = nonaktif
+	SnapshotInterval  string // interval snapshot saldo stok otomatis, mis. "24h"; "0" = nonaktif
 }
 
 func Load() *Config {
@@ -26,6 +29,9 @@ func Load() *Config {
 		DBPort:     getEnv("DB_PORT", "5432"),
 		JWTSecret:  getEnv("JWT_SECRET", "supersecretkey_change_me"),
 		SwaggerURL: getEnv("SWAGGER_URL", "/stocklab-api/"),
+
+		ReconcileInterval: getEnv("RECONCILE_INTERVAL", "24h"),
+		SnapshotInterval:  getEnv("SNAPSHOT_INTERVAL", "24h"),
 	}
 
 }
@@ -0,0 +1,160 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Mata uang product baru jika currency tidak diisi
+const DefaultCurrency = "IDR"
+
+// Jumlah digit desimal yang disimpan (NUMERIC(18,2))
+const Scale = 100
+
+// Nilai maksimum yang muat di NUMERIC(18,2)
+const maxUnits = 9999999999999999
+
+var (
+	ErrInvalidAmount   = errors.New("Invalid amount")
+	ErrInvalidCurrency = errors.New("Invalid currency")
+)
+
+var (
+	amountRe   = regexp.MustCompile(`^(-?)([0-9]+)(?:\.([0-9]{1,2}))?$`)
+	currencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// Amount adalah nilai uang dalam satuan terkecil (1/100), supaya perhitungan
+// tidak kehilangan presisi seperti float. Di JSON ditulis sebagai angka desimal.
+type Amount int64
+
+// Parse membaca harga non-negatif seperti "3500", "3500.5" atau "3500.50".
+// Pemisah ribuan ("10.000" / "10,000") ditolak karena ambigu.
+func Parse(s string) (Amount, error) {
+	a, err := parse(s)
+	if err != nil {
+		return 0, err
+	}
+	if a < 0 {
+		return 0, fmt.Errorf("%w %q: must not be negative", ErrInvalidAmount, s)
+	}
+	return a, nil
+}
+
+func parse(s string) (Amount, error) {
+	m := amountRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("%w %q: use digits with at most 2 decimals and no thousand separators, e.g. 10000.50", ErrInvalidAmount, s)
+	}
+
+	units, err := strconv.ParseInt(m[2], 10, 64)
+	if err != nil || units > maxUnits {
+		return 0, fmt.Errorf("%w %q: too large", ErrInvalidAmount, s)
+	}
+	frac := 0
+	if m[3] != "" {
+		frac, _ = strconv.Atoi((m[3] + "0")[:2])
+	}
+
+	a := Amount(units*Scale + int64(frac))
+	if m[1] == "-" {
+		a = -a
+	}
+	return a, nil
+}
+
+// Mul mengalikan harga dengan quantity
+func (a Amount) Mul(qty int64) Amount {
+	return a * Amount(qty)
+}
+
+// MulDiv menghitung a x num / den dibulatkan ke satuan terkecil terdekat,
+// mis. nilai sebagian layer biaya (nilai layer x quantity diambil / sisa quantity)
+func (a Amount) MulDiv(num, den int64) Amount {
+	if den == 0 {
+		return 0
+	}
+	n := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(num))
+	d := big.NewInt(den)
+	if d.Sign() < 0 {
+		n.Neg(n)
+		d.Neg(d)
+	}
+	// Pembulatan half away from zero
+	half := new(big.Int).Quo(d, big.NewInt(2))
+	if n.Sign() < 0 {
+		n.Sub(n, half)
+	} else {
+		n.Add(n, half)
+	}
+	return Amount(n.Quo(n, d).Int64())
+}
+
+// String menulis nilai dengan 2 desimal, mis. "3500.00"
+func (a Amount) String() string {
+	sign := ""
+	v := int64(a)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/Scale, v%Scale)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON menerima angka (3500.5) maupun string ("3500.50")
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	v, err := Parse(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// Scan membaca kolom NUMERIC
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	case int64:
+		*a = Amount(v * Scale)
+		return nil
+	default:
+		return fmt.Errorf("money: cannot scan %T into Amount", src)
+	}
+}
+
+func (a *Amount) scanString(s string) error {
+	v, err := parse(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// Value menulis nilai ke kolom NUMERIC
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// ValidCurrency memeriksa kode mata uang ISO 4217 (3 huruf besar)
+func ValidCurrency(code string) error {
+	if !currencyRe.MatchString(code) {
+		return fmt.Errorf("%w %q: must be a 3-letter ISO 4217 code, e.g. IDR", ErrInvalidCurrency, code)
+	}
+	return nil
+}
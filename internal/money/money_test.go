@@ -0,0 +1,120 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr bool
+	}{
+		{"3500", 350000, false},
+		{"3500.5", 350050, false},
+		{"3500.50", 350050, false},
+		{"0.05", 5, false},
+		{"0", 0, false},
+		{" 12.30 ", 1230, false},
+		{"9999999999999999.99", 999999999999999999, false},
+		{"10000000000000000", 0, true},
+		{"-1", 0, true},
+		{"10.000", 0, true},
+		{"10,000", 0, true},
+		{"1.234", 0, true},
+		{".5", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Errorf("Parse(%q) error = %v, want ErrInvalidAmount", tt.in, err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        Amount
+		num, den int64
+		want     Amount
+	}{
+		{"exact", 1000, 3, 3, 1000},
+		{"round down", 1000, 1, 3, 333},
+		{"round up", 2000, 1, 3, 667},
+		{"half rounds away from zero", 1, 1, 2, 1},
+		{"negative half rounds away from zero", -1, 1, 2, -1},
+		{"negative denominator", 1000, 1, -3, -333},
+		{"zero denominator", 1000, 1, 0, 0},
+		{"partial layer", 1000000, 7, 30, 233333},
+		{"no overflow on large product", 999999999999999999, 1000, 1000, 999999999999999999},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.MulDiv(tt.num, tt.den); got != tt.want {
+				t.Errorf("%d.MulDiv(%d, %d) = %d, want %d", tt.a, tt.num, tt.den, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		a    Amount
+		want string
+	}{
+		{350000, "3500.00"},
+		{350050, "3500.50"},
+		{5, "0.05"},
+		{0, "0.00"},
+		{-700000, "-7000.00"},
+		{-5, "-0.05"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.String(); got != tt.want {
+			t.Errorf("Amount(%d).String() = %q, want %q", tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  interface{}
+		want Amount
+	}{
+		{"numeric bytes", []byte("3500.50"), 350050},
+		{"negative numeric", "-12.3", -1230},
+		{"integer", int64(42), 4200},
+		{"null", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a Amount
+			if err := a.Scan(tt.src); err != nil {
+				t.Fatalf("Scan(%v) error = %v", tt.src, err)
+			}
+			if a != tt.want {
+				t.Errorf("Scan(%v) = %d, want %d", tt.src, a, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package reconcile
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/lib/pq"
+)
+
+// Asal run rekonsiliasi
+const (
+	SourceManual    = "MANUAL"    // endpoint admin
+	SourceScheduled = "SCHEDULED" // job berkala di server (RECONCILE_INTERVAL)
+	SourceCommand   = "COMMAND"   // cmd/reconcile
+)
+
+// Status drift
+const (
+	StatusOpen      = "OPEN"
+	StatusCorrected = "CORRECTED"
+)
+
+var (
+	ErrRunNotFound      = errors.New("Reconciliation run not found")
+	ErrDriftNotFound    = errors.New("Drift not found")
+	ErrAlreadyCorrected = errors.New("Drift already corrected")
+	ErrNotCorrectable   = errors.New("Drift cannot be corrected")
+)
+
+// Result adalah ringkasan satu run
+type Result struct {
+	RunID       int64
+	CheckedRows int64
+	DriftCount  int64
+}
+
+// Drift adalah satu baris stocks yang tidak sama dengan jumlah movement-nya
+type Drift struct {
+	ID             int64
+	RunID          int64
+	ProductID      int64
+	WarehouseID    int64
+	LedgerQuantity int64
+	StockQuantity  *int64 // nil jika baris stocks tidak ada
+	Difference     int64  // stock - ledger
+	Status         string
+	TransactionID  *int64
+}
+
+// Run membandingkan stocks.quantity dengan jumlah IN - OUT di transactions untuk
+// semua product dan warehouse, lalu menyimpan selisihnya sebagai drift report.
+// Seluruh ledger dijumlahkan (bukan dari stock_snapshots) supaya snapshot yang
+// salah tidak ikut menutupi drift. userID 0 untuk job terjadwal dan command.
+func Run(db *sql.DB, source string, userID int64) (Result, error) {
+	// Repeatable read: hitungan baris dan drift dibaca dari data yang sama
+	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback()
+
+	var res Result
+	err = tx.QueryRow(`
+		INSERT INTO reconciliation_runs (source, created_by) VALUES ($1, $2) RETURNING id
+	`, source, nullID(userID)).Scan(&res.RunID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	compare := `
+		WITH l AS (
+			SELECT tr.product_id, tr.warehouse_id, SUM(` + stock.SignedQuantitySQL("tr") + `) AS quantity
+			FROM transactions tr
+			GROUP BY tr.product_id, tr.warehouse_id
+		)
+		SELECT COALESCE(s.product_id, l.product_id) AS product_id, COALESCE(s.warehouse_id, l.warehouse_id) AS warehouse_id,
+			COALESCE(l.quantity, 0) AS ledger_quantity, s.quantity AS stock_quantity
+		FROM stocks s
+		FULL JOIN l ON l.product_id = s.product_id AND l.warehouse_id = s.warehouse_id
+	`
+
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM (` + compare + `) c`).Scan(&res.CheckedRows); err != nil {
+		return Result{}, err
+	}
+
+	drifts, err := tx.Exec(`
+		INSERT INTO reconciliation_drifts (run_id, product_id, warehouse_id, ledger_quantity, stock_quantity, difference)
+		SELECT $1, c.product_id, c.warehouse_id, c.ledger_quantity, c.stock_quantity, COALESCE(c.stock_quantity, 0) - c.ledger_quantity
+		FROM (`+compare+`) c
+		WHERE COALESCE(c.stock_quantity, 0) <> c.ledger_quantity
+	`, res.RunID)
+	if err != nil {
+		return Result{}, err
+	}
+	res.DriftCount, _ = drifts.RowsAffected()
+
+	_, err = tx.Exec(`
+		UPDATE reconciliation_runs SET checked_rows = $1, drift_count = $2, finished_at = NOW() WHERE id = $3
+	`, res.CheckedRows, res.DriftCount, res.RunID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return res, tx.Commit()
+}
+
+// OpenDrifts mengambil drift run yang belum dikoreksi, urut (product_id, warehouse_id)
+// sesuai urutan lock stocks. ids kosong berarti semua drift run tersebut yang bisa
+// dikoreksi; drift yang diminta tapi tidak ada, sudah dikoreksi atau tidak punya
+// baris stocks menghasilkan error.
+func OpenDrifts(tx *sql.Tx, runID int64, ids []int64) ([]Drift, error) {
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM reconciliation_runs WHERE id = $1)`, runID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", ErrRunNotFound, runID)
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, run_id, product_id, warehouse_id, ledger_quantity, stock_quantity, difference, status, transaction_id
+		FROM reconciliation_drifts
+		WHERE run_id = $1 AND (CARDINALITY($2::bigint[]) = 0 OR id = ANY($2))
+		ORDER BY product_id, warehouse_id
+		FOR UPDATE
+	`, runID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drifts := []Drift{}
+	found := map[int64]bool{}
+	for rows.Next() {
+		var d Drift
+		if err := rows.Scan(&d.ID, &d.RunID, &d.ProductID, &d.WarehouseID, &d.LedgerQuantity, &d.StockQuantity, &d.Difference, &d.Status, &d.TransactionID); err != nil {
+			return nil, err
+		}
+		found[d.ID] = true
+
+		if d.Status != StatusOpen {
+			if len(ids) > 0 {
+				return nil, fmt.Errorf("%w: %d", ErrAlreadyCorrected, d.ID)
+			}
+			continue
+		}
+
+		// Tanpa baris stocks tidak ada saldo fisik yang bisa dijadikan acuan
+		if d.StockQuantity == nil {
+			if len(ids) > 0 {
+				return nil, fmt.Errorf("%w: drift %d has no stock row for product %d at warehouse %d", ErrNotCorrectable, d.ID, d.ProductID, d.WarehouseID)
+			}
+			continue
+		}
+		drifts = append(drifts, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			return nil, fmt.Errorf("%w: %d in run %d", ErrDriftNotFound, id, runID)
+		}
+	}
+	return drifts, nil
+}
+
+// Correct memposting adjustment koreksi untuk drift (lihat stock.PostLedgerCorrection)
+// dan menandai semua drift terbuka untuk baris stocks yang sama sebagai CORRECTED,
+// termasuk dari run lain. Selisih dihitung ulang saat koreksi; jika sudah sama
+// tidak ada transaksi yang diposting dan txID 0.
+func Correct(tx *sql.Tx, d Drift, userID int64) (int64, int64, error) {
+	txID, diff, err := stock.PostLedgerCorrection(tx, stock.Key{ProductID: d.ProductID, WarehouseID: d.WarehouseID}, userID,
+		fmt.Sprintf("Koreksi rekonsiliasi run #%d", d.RunID))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE reconciliation_drifts
+		SET status = $1, transaction_id = $2, corrected_by = $3, corrected_at = NOW()
+		WHERE product_id = $4 AND warehouse_id = $5 AND status = $6
+	`, StatusCorrected, nullID(txID), userID, d.ProductID, d.WarehouseID, StatusOpen)
+	if err != nil {
+		return 0, 0, err
+	}
+	return txID, diff, nil
+}
+
+// Schedule menjalankan Run setiap interval sampai ctx selesai. Hasil dan error
+// dicatat ke logger; drift tidak dikoreksi otomatis.
+func Schedule(ctx context.Context, db *sql.DB, interval time.Duration, info, errLog *log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			res, err := Run(db, SourceScheduled, 0)
+			if err != nil {
+				errLog.Printf("Stock reconciliation failed: %v", err)
+				continue
+			}
+			if res.DriftCount > 0 {
+				errLog.Printf("Stock reconciliation run #%d: %d of %d stock rows drifted from the ledger", res.RunID, res.DriftCount, res.CheckedRows)
+			} else {
+				info.Printf("Stock reconciliation run #%d: %d stock rows in sync", res.RunID, res.CheckedRows)
+			}
+		}
+	}
+}
+
+func nullID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// StatusCode memetakan error rekonsiliasi ke HTTP status
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrRunNotFound), errors.Is(err, ErrDriftNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrAlreadyCorrected):
+		return http.StatusConflict
+	case errors.Is(err, ErrNotCorrectable):
+		return http.StatusBadRequest
+	default:
+		return stock.StatusCode(err)
+	}
+}
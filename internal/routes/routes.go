@@ -5,12 +5,31 @@ import (
 
 	_ "github.com/Arrafll/StockLab-Go/docs" // <-- wajib ada
 	"github.com/Arrafll/StockLab-Go/internal/config"
+	assemblyService "github.com/Arrafll/StockLab-Go/internal/services/assembly"
 	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	bomService "github.com/Arrafll/StockLab-Go/internal/services/bom"
 	categoryService "github.com/Arrafll/StockLab-Go/internal/services/category"
+	customerService "github.com/Arrafll/StockLab-Go/internal/services/customer"
 	dashboardService "github.com/Arrafll/StockLab-Go/internal/services/dashboard"
+	documentService "github.com/Arrafll/StockLab-Go/internal/services/document"
+	idempotencyService "github.com/Arrafll/StockLab-Go/internal/services/idempotency"
+	lotService "github.com/Arrafll/StockLab-Go/internal/services/lot"
 	productService "github.com/Arrafll/StockLab-Go/internal/services/product"
+	purchaseOrderService "github.com/Arrafll/StockLab-Go/internal/services/purchaseorder"
+	reasonCodeService "github.com/Arrafll/StockLab-Go/internal/services/reasoncode"
+	reconciliationService "github.com/Arrafll/StockLab-Go/internal/services/reconciliation"
+	salesOrderService "github.com/Arrafll/StockLab-Go/internal/services/salesorder"
+	serialService "github.com/Arrafll/StockLab-Go/internal/services/serial"
+	skuPatternService "github.com/Arrafll/StockLab-Go/internal/services/skupattern"
+	stockCountService "github.com/Arrafll/StockLab-Go/internal/services/stockcount"
+	stockSnapshotService "github.com/Arrafll/StockLab-Go/internal/services/stocksnapshot"
+	supplierService "github.com/Arrafll/StockLab-Go/internal/services/supplier"
 	transactionService "github.com/Arrafll/StockLab-Go/internal/services/transaction"
+	transferService "github.com/Arrafll/StockLab-Go/internal/services/transfer"
+	unitService "github.com/Arrafll/StockLab-Go/internal/services/unit"
 	userService "github.com/Arrafll/StockLab-Go/internal/services/user"
+	valuationService "github.com/Arrafll/StockLab-Go/internal/services/valuation"
+	warehouseService "github.com/Arrafll/StockLab-Go/internal/services/warehouse"
 	"github.com/go-chi/chi/v5"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
@@ -32,9 +51,15 @@ func RegisterRoutes(cfg *config.Config) http.Handler {
 		r.Post("/login", func(w http.ResponseWriter, r *http.Request) {
 			authService.Login(w, r, cfg)
 		})
+		r.Post("/token/refresh", func(w http.ResponseWriter, r *http.Request) {
+			authService.RefreshToken(w, r, cfg)
+		})
+		r.With(authService.JWTMiddleware(cfg)).Post("/logout", authService.Logout)
+
 		// User routes
 		r.Route("/users", func(r chi.Router) {
 			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Use(authService.RequireRole(authService.RoleAdmin))
 			r.Get("/", userService.GetUserList)
 			r.Post("/create", userService.CreateUser)
 			r.Put("/update/{id}", userService.UpdateUser)
@@ -45,31 +70,241 @@ func RegisterRoutes(cfg *config.Config) http.Handler {
 		r.Route("/categories", func(r chi.Router) {
 			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
 			r.Get("/", categoryService.GetCategoryList)
-			r.Post("/create", categoryService.CreateCategory)
-			r.Put("/update/{id}", categoryService.UpdateCategory)
-			r.Delete("/delete/{id}", categoryService.DeleteCategory)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/create", categoryService.CreateCategory)
+				r.Put("/update/{id}", categoryService.UpdateCategory)
+				r.Delete("/delete/{id}", categoryService.DeleteCategory)
+			})
+		})
+
+		r.Route("/warehouses", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", warehouseService.GetWarehouseList)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/create", warehouseService.CreateWarehouse)
+				r.Put("/update/{id}", warehouseService.UpdateWarehouse)
+				r.Delete("/delete/{id}", warehouseService.DeleteWarehouse)
+			})
+		})
+
+		r.Route("/units", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", unitService.GetUnitList)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/create", unitService.CreateUnit)
+				r.Put("/update/{id}", unitService.UpdateUnit)
+				r.Delete("/delete/{id}", unitService.DeleteUnit)
+			})
+		})
+
+		r.Route("/reason-codes", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", reasonCodeService.GetReasonCodeList)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/create", reasonCodeService.CreateReasonCode)
+				r.Put("/update/{id}", reasonCodeService.UpdateReasonCode)
+				r.Delete("/delete/{id}", reasonCodeService.DeleteReasonCode)
+			})
+		})
+
+		r.Route("/sku-patterns", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", skuPatternService.GetSKUPatternList)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Put("/save", skuPatternService.SaveSKUPattern)
+				r.Delete("/delete/{id}", skuPatternService.DeleteSKUPattern)
+			})
+		})
+
+		r.Route("/suppliers", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", supplierService.GetSupplierList)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/create", supplierService.CreateSupplier)
+				r.Put("/update/{id}", supplierService.UpdateSupplier)
+				r.Delete("/delete/{id}", supplierService.DeleteSupplier)
+			})
+		})
+
+		r.Route("/customers", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", customerService.GetCustomerList)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/create", customerService.CreateCustomer)
+				r.Put("/update/{id}", customerService.UpdateCustomer)
+				r.Delete("/delete/{id}", customerService.DeleteCustomer)
+			})
 		})
 
 		r.Route("/products", func(r chi.Router) {
 			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
 			r.Get("/", productService.GetProductList)
+			r.Get("/low-stock", productService.GetLowStockList)
 			r.Post("/create", productService.CreateProduct)
 			r.Get("/detail/{id}", productService.GetProductDetail)
-			r.Delete("/delete/{id}", productService.DeleteProduct)
+			r.Get("/by-barcode/{code}", productService.GetProductByBarcode)
+			r.With(authService.RequireRole(authService.RoleAdmin)).Delete("/delete/{id}", productService.DeleteProduct)
 			r.Patch("/update/{id}", productService.UpdateProduct)
+			r.Put("/levels/{id}", productService.UpdateProductLevels)
+			r.Put("/units/{id}", productService.UpdateProductUnits)
+			r.Put("/kit/{id}", productService.UpdateProductKit)
+			r.Put("/barcodes/{id}", productService.UpdateProductBarcodes)
+			r.Get("/prices/{id}", productService.GetProductPriceHistory)
+			r.Get("/{id}/ledger", productService.GetProductLedger)
+		})
+
+		r.Route("/lots", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", lotService.GetLotList)
+			r.Get("/expiring", lotService.GetExpiringLots)
+		})
+
+		r.Route("/serials", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", serialService.GetSerialList)
+			r.Get("/lookup/{serial_number}", serialService.GetSerialLookup)
 		})
 
 		r.Route("/transactions", func(r chi.Router) {
 			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
-			r.Post("/create", transactionService.CreateTransaction)
+			r.With(idempotencyService.Middleware).Post("/create", transactionService.CreateTransaction)
 			r.Get("/", transactionService.GetTransactionList)
 		})
 
+		r.Route("/documents", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.With(idempotencyService.Middleware).Post("/create", documentService.CreateDocument)
+			r.Get("/", documentService.GetDocumentList)
+			r.Get("/detail/{id}", documentService.GetDocumentDetail)
+		})
+
+		r.Route("/transfers", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.With(idempotencyService.Middleware).Post("/create", transferService.CreateTransfer)
+			r.Get("/", transferService.GetTransferList)
+			r.Get("/detail/{id}", transferService.GetTransferDetail)
+		})
+
+		r.Route("/purchase-orders", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", purchaseOrderService.GetPurchaseOrderList)
+			r.Post("/create", purchaseOrderService.CreatePurchaseOrder)
+			r.Get("/detail/{id}", purchaseOrderService.GetPurchaseOrderDetail)
+			r.Post("/send/{id}", purchaseOrderService.SendPurchaseOrder)
+			r.With(idempotencyService.Middleware).Post("/receive/{id}", purchaseOrderService.ReceivePurchaseOrder)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/cancel/{id}", purchaseOrderService.CancelPurchaseOrder)
+			})
+		})
+
+		r.Route("/sales-orders", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", salesOrderService.GetSalesOrderList)
+			r.Post("/create", salesOrderService.CreateSalesOrder)
+			r.Get("/detail/{id}", salesOrderService.GetSalesOrderDetail)
+			r.Post("/confirm/{id}", salesOrderService.ConfirmSalesOrder)
+			r.With(idempotencyService.Middleware).Post("/fulfil/{id}", salesOrderService.FulfilSalesOrder)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/cancel/{id}", salesOrderService.CancelSalesOrder)
+			})
+		})
+
+		r.Route("/boms", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", bomService.GetBomList)
+			r.Post("/create", bomService.CreateBom)
+			r.Get("/detail/{id}", bomService.GetBomDetail)
+		})
+
+		r.Route("/assembly-orders", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", assemblyService.GetAssemblyOrderList)
+			r.Post("/create", assemblyService.CreateAssemblyOrder)
+			r.Get("/detail/{id}", assemblyService.GetAssemblyOrderDetail)
+			r.With(idempotencyService.Middleware).Post("/complete/{id}", assemblyService.CompleteAssemblyOrder)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/cancel/{id}", assemblyService.CancelAssemblyOrder)
+			})
+		})
+
+		r.Route("/stock-counts", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", stockCountService.GetStockCountList)
+			r.Post("/create", stockCountService.CreateStockCount)
+			r.Get("/detail/{id}", stockCountService.GetStockCountDetail)
+			r.Get("/variance/{id}", stockCountService.GetStockCountVariance)
+			r.Post("/submit/{id}", stockCountService.SubmitStockCount)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/approve/{id}", stockCountService.ApproveStockCount)
+				r.Post("/cancel/{id}", stockCountService.CancelStockCount)
+			})
+		})
+
 		r.Route("/dashboard", func(r chi.Router) {
 			r.Use(authService.JWTMiddleware(cfg))
 			r.Get("/", dashboardService.DashboardMain)
 		})
 
+		r.Route("/valuation", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", valuationService.GetValuation)
+		})
+
+		r.Route("/stock-snapshots", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Get("/", stockSnapshotService.GetStockSnapshotList)
+			r.Get("/as-of", stockSnapshotService.GetStockAsOf)
+
+			// Admin only
+			r.Group(func(r chi.Router) {
+				r.Use(authService.RequireRole(authService.RoleAdmin))
+				r.Post("/create", stockSnapshotService.CreateStockSnapshot)
+				r.Delete("/delete/{id}", stockSnapshotService.DeleteStockSnapshot)
+			})
+		})
+
+		r.Route("/reconciliations", func(r chi.Router) {
+			r.Use(authService.JWTMiddleware(cfg)) // middleware JWT
+			r.Use(authService.RequireRole(authService.RoleAdmin))
+			r.Get("/", reconciliationService.GetReconciliationList)
+			r.Post("/run", reconciliationService.RunReconciliation)
+			r.Get("/detail/{id}", reconciliationService.GetReconciliationDetail)
+			r.Post("/correct/{id}", reconciliationService.CorrectReconciliation)
+		})
+
 	})
 
 	return r
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type AssemblyOrderCompleteLineRequest struct {
+	ProductID        int64    `json:"product_id" example:"3"`
+	ConsumedQuantity *int64   `json:"consumed_quantity" example:"104"`   // default planned_quantity baris
+	LotNumber        string   `json:"lot_number" example:"LOT-2025-11A"` // lot yang dipakai, kosong = FEFO
+	Serials          []string `json:"serial_numbers" example:"SN-0001"`  // wajib untuk komponen serialized
+}
+
+type AssemblyOrderCompleteRequest struct {
+	ProducedQuantity *int64                             `json:"produced_quantity" example:"48"` // default planned_quantity
+	LotNumber        string                             `json:"lot_number" example:"LOT-AO-1"`  // lot product jadi jika lot-tracked
+	ExpiryDate       string                             `json:"expiry_date" example:"2026-05-31"`
+	Serials          []string                           `json:"serial_numbers" example:"SN-HMP-0001"` // wajib jika product jadi serialized
+	Notes            string                             `json:"notes" example:"2 keranjang rusak"`
+	Lines            []AssemblyOrderCompleteLineRequest `json:"lines"` // hanya komponen yang dipakai berbeda dari rencana
+}
+
+type AssemblyOrderCompleteSuccessResp struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Assembly order completed successfully"`
+	Data    AssemblyOrderDetail `json:"data"`
+}
+
+type AssemblyOrderCompleteFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Insufficient stock for product 3 at warehouse 1"`
+}
+
+// assemblyLine adalah baris assembly order yang sudah di-lock
+type assemblyLine struct {
+	id        int64
+	planned   int64
+	perOutput int64
+	consumed  int64
+	request   AssemblyOrderCompleteLineRequest
+}
+
+// CompleteAssemblyOrder godoc
+// @Summary Complete assembly order
+// @Description Selesaikan assembly order DRAFT: OUT untuk setiap komponen yang dipakai dan IN untuk product jadi diposting dalam satu DB transaction. Jika stok salah satu komponen kurang seluruh order gagal dengan 409 dan tidak ada stok yang berubah. produced_quantity dan consumed_quantity per komponen boleh berbeda dari rencana; selisih hasil dicatat sebagai yield_variance dan selisih pemakaian komponen terhadap kebutuhan BOM untuk hasil aktual sebagai scrap_quantity.
+// @Tags assembly-orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Assembly order ID"
+// @Param body body services.AssemblyOrderCompleteRequest false "Actual output and consumption"
+// @Param Idempotency-Key header string false "Unique key per movement; retries with the same key return the original response"
+// @Success 200 {object} services.AssemblyOrderCompleteSuccessResp
+// @Failure 400 {object} services.AssemblyOrderCompleteFailResp
+// @Failure 401 {object} services.AssemblyOrderCompleteFailResp
+// @Failure 404 {object} services.AssemblyOrderCompleteFailResp
+// @Failure 409 {object} services.AssemblyOrderCompleteFailResp
+// @Failure 422 {object} services.AssemblyOrderCompleteFailResp
+// @Failure 500 {object} services.AssemblyOrderCompleteFailResp
+// @Router /stocklab-api/v1/assembly-orders/complete/{id} [post]
+// @Security BearerAuth
+func CompleteAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid assembly order id")
+		return
+	}
+
+	// Body boleh kosong: selesai sesuai rencana
+	var req AssemblyOrderCompleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var status string
+	var productID, warehouseID, planned, outputQuantity int64
+	err = tx.QueryRow(`
+		SELECT ao.status, ao.product_id, ao.warehouse_id, ao.planned_quantity, b.output_quantity
+		FROM assembly_orders ao
+		JOIN boms b ON b.id = ao.bom_id
+		WHERE ao.id = $1
+		FOR UPDATE OF ao
+	`, orderID).Scan(&status, &productID, &warehouseID, &planned, &outputQuantity)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "assembly order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status != StatusDraft {
+		utils.RespondError(w, http.StatusConflict, "Assembly order is already "+status)
+		return
+	}
+
+	produced := planned
+	if req.ProducedQuantity != nil {
+		produced = *req.ProducedQuantity
+	}
+	if produced < 0 {
+		utils.RespondError(w, http.StatusBadRequest, "produced_quantity must not be negative")
+		return
+	}
+
+	lines, order, err := lockAssemblyLines(tx, orderID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Pemakaian aktual per komponen, default sesuai rencana
+	seen := map[int64]bool{}
+	for i, l := range req.Lines {
+		line, ok := lines[l.ProductID]
+		if !ok {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(l.ProductID, 10)+" is not a component of this assembly order")
+			return
+		}
+		if seen[l.ProductID] {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(l.ProductID, 10)+" appears more than once")
+			return
+		}
+		seen[l.ProductID] = true
+		if l.ConsumedQuantity != nil {
+			if *l.ConsumedQuantity < 0 {
+				utils.RespondError(w, http.StatusBadRequest, "Invalid consumption line "+strconv.Itoa(i+1))
+				return
+			}
+			line.consumed = *l.ConsumedQuantity
+		}
+		line.request = l
+	}
+
+	movements := []stock.Movement{}
+	for _, cid := range order {
+		line := lines[cid]
+		if line.consumed == 0 {
+			continue
+		}
+		movements = append(movements, stock.Movement{
+			Key:        stock.Key{ProductID: cid, WarehouseID: warehouseID},
+			UserID:     principal.UserID,
+			Quantity:   line.consumed,
+			MoveType:   stock.MoveOut,
+			AssemblyID: orderID,
+			Notes:      req.Notes,
+			LotNumber:  strings.TrimSpace(line.request.LotNumber),
+			Serials:    line.request.Serials,
+		})
+	}
+	if produced > 0 {
+		movements = append(movements, stock.Movement{
+			Key:        stock.Key{ProductID: productID, WarehouseID: warehouseID},
+			UserID:     principal.UserID,
+			Quantity:   produced,
+			MoveType:   stock.MoveIn,
+			AssemblyID: orderID,
+			Notes:      req.Notes,
+			LotNumber:  strings.TrimSpace(req.LotNumber),
+			ExpiryDate: strings.TrimSpace(req.ExpiryDate),
+			Serials:    req.Serials,
+		})
+	}
+
+	// Lock baris stok lewat jalur yang sama dengan CreateTransaction
+	balances, err := stock.Lock(tx, stock.MovementKeys(movements))
+	if err != nil {
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
+		return
+	}
+
+	// Komponen dikeluarkan dulu, stok kurang membatalkan seluruh order.
+	// Biaya product jadi adalah total COGS komponen yang terpakai, termasuk scrap.
+	consumedCost := money.Amount(0)
+	for _, m := range movements {
+		if stock.IsInbound(m.MoveType) {
+			m.CostValue = &consumedCost
+		}
+		txID, err := stock.Apply(tx, balances, m)
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+		if !stock.IsInbound(m.MoveType) {
+			cost, err := stock.CostOf(tx, txID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			consumedCost += cost.Value
+		}
+	}
+
+	for _, cid := range order {
+		line := lines[cid]
+		scrap := line.consumed - requiredQuantity(line.perOutput, outputQuantity, produced)
+		if _, err := tx.Exec(`
+			UPDATE assembly_order_lines SET consumed_quantity = $1, scrap_quantity = $2, updated_at = NOW() WHERE id = $3
+		`, line.consumed, scrap, line.id); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update order line: "+err.Error())
+			return
+		}
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE assembly_orders
+		SET status = $1, produced_quantity = $2, completed_by = $3, completed_at = NOW(), updated_at = NOW(),
+			notes = COALESCE(NULLIF($4, ''), notes)
+		WHERE id = $5
+	`, StatusCompleted, produced, principal.UserID, req.Notes, orderID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update assembly order: "+err.Error())
+		return
+	}
+
+	detail, err := loadAssemblyOrder(tx, orderID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, detail, "Assembly order completed successfully")
+}
+
+// lockAssemblyLines mengunci baris assembly order beserta kebutuhan BOM per komponen.
+// order berisi component_id sesuai urutan baris.
+func lockAssemblyLines(tx *sql.Tx, orderID int64) (map[int64]*assemblyLine, []int64, error) {
+	rows, err := tx.Query(`
+		SELECT l.id, l.component_id, l.planned_quantity, COALESCE(bl.quantity, 0)
+		FROM assembly_order_lines l
+		JOIN assembly_orders ao ON ao.id = l.assembly_order_id
+		LEFT JOIN bom_lines bl ON bl.bom_id = ao.bom_id AND bl.component_id = l.component_id
+		WHERE l.assembly_order_id = $1
+		ORDER BY l.id
+		FOR UPDATE OF l
+	`, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	lines := map[int64]*assemblyLine{}
+	order := []int64{}
+	for rows.Next() {
+		var cid int64
+		l := &assemblyLine{}
+		if err := rows.Scan(&l.id, &cid, &l.planned, &l.perOutput); err != nil {
+			return nil, nil, err
+		}
+		l.consumed = l.planned
+		lines[cid] = l
+		order = append(order, cid)
+	}
+
+	return lines, order, rows.Err()
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/lib/pq"
+)
+
+type AssemblyOrderCreateRequest struct {
+	OrderNumber string `json:"order_number" example:"AO-2025-0001"`
+	BomID       int64  `json:"bom_id" example:"1"`
+	WarehouseID int64  `json:"warehouse_id" example:"1"`
+	Quantity    int64  `json:"quantity" example:"50"` // product jadi yang akan dirakit
+	Notes       string `json:"notes" example:"Produksi batch 1"`
+}
+
+type AssemblyOrderCreateSuccessResp struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Assembly order created successfully"`
+	Data    AssemblyOrderDetail `json:"data"`
+}
+
+type AssemblyOrderCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to create assembly order"`
+}
+
+// CreateAssemblyOrder godoc
+// @Summary Create assembly order
+// @Description Buat assembly order berstatus DRAFT untuk merakit quantity product jadi dari bill of materials di satu warehouse. Kebutuhan komponen dihitung dari BOM dan dibulatkan ke atas; stok belum berubah sampai order diselesaikan.
+// @Tags assembly-orders
+// @Accept json
+// @Produce json
+// @Param body body services.AssemblyOrderCreateRequest true "Assembly order"
+// @Success 200 {object} services.AssemblyOrderCreateSuccessResp
+// @Failure 400 {object} services.AssemblyOrderCreateFailResp
+// @Failure 401 {object} services.AssemblyOrderCreateFailResp
+// @Failure 409 {object} services.AssemblyOrderCreateFailResp
+// @Failure 500 {object} services.AssemblyOrderCreateFailResp
+// @Router /stocklab-api/v1/assembly-orders/create [post]
+// @Security BearerAuth
+func CreateAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req AssemblyOrderCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	req.OrderNumber = strings.TrimSpace(req.OrderNumber)
+
+	if req.BomID == 0 || req.WarehouseID == 0 || req.Quantity <= 0 {
+		utils.RespondError(w, http.StatusBadRequest, "bom_id, warehouse_id and a positive quantity are required")
+		return
+	}
+
+	var productID, outputQuantity int64
+	err := db.DB.QueryRow(`SELECT product_id, output_quantity FROM boms WHERE id = $1`, req.BomID).Scan(&productID, &outputQuantity)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusBadRequest, "Bill of materials not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+
+	type bomLine struct {
+		componentID int64
+		perOutput   int64
+	}
+	rows, err := db.DB.Query(`SELECT component_id, quantity FROM bom_lines WHERE bom_id = $1 ORDER BY id`, req.BomID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	lines := []bomLine{}
+	productIDs := []int64{productID}
+	for rows.Next() {
+		var l bomLine
+		if err := rows.Scan(&l.componentID, &l.perOutput); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+			return
+		}
+		lines = append(lines, l)
+		productIDs = append(productIDs, l.componentID)
+	}
+	if err := rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+
+	// Product jadi dan semua komponen harus punya baris stok di warehouse
+	var known int
+	err = db.DB.QueryRow(
+		"SELECT COUNT(*) FROM stocks WHERE warehouse_id = $1 AND product_id = ANY($2::bigint[])",
+		req.WarehouseID, pq.Array(productIDs),
+	).Scan(&known)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if known != len(productIDs) {
+		utils.RespondError(w, http.StatusBadRequest, "Unknown warehouse or product in bill of materials")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var orderID int64
+	err = tx.QueryRow(`
+		INSERT INTO assembly_orders (order_number, bom_id, product_id, warehouse_id, status, planned_quantity, notes, created_by)
+		VALUES (NULLIF($1, ''), $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`, req.OrderNumber, req.BomID, productID, req.WarehouseID, StatusDraft, req.Quantity, req.Notes, principal.UserID).Scan(&orderID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "Order number "+req.OrderNumber+" is already used")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create assembly order: "+err.Error())
+		return
+	}
+
+	// Nomor order otomatis AO-<id> jika tidak diisi
+	if req.OrderNumber == "" {
+		if _, err := tx.Exec(`UPDATE assembly_orders SET order_number = $1 WHERE id = $2`, "AO-"+strconv.FormatInt(orderID, 10), orderID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create assembly order: "+err.Error())
+			return
+		}
+	}
+
+	for _, l := range lines {
+		_, err := tx.Exec(`
+			INSERT INTO assembly_order_lines (assembly_order_id, component_id, planned_quantity)
+			VALUES ($1, $2, $3)
+		`, orderID, l.componentID, requiredQuantity(l.perOutput, outputQuantity, req.Quantity))
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create order lines: "+err.Error())
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	detail, err := loadAssemblyOrder(db.DB, orderID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, detail, "Assembly order created successfully")
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type AssemblyOrderLine struct {
+	ID               int64  `json:"id" example:"1"`
+	ComponentID      int64  `json:"component_id" example:"3"`
+	ComponentName    string `json:"component_name" example:"Sirup Marjan 460ml"`
+	ComponentSKU     string `json:"component_sku" example:"SKU-20251214201530-042"`
+	PlannedQuantity  int64  `json:"planned_quantity" example:"100"`  // kebutuhan BOM untuk planned_quantity
+	ConsumedQuantity int64  `json:"consumed_quantity" example:"100"` // yang benar-benar dipakai
+	ScrapQuantity    int64  `json:"scrap_quantity" example:"4"`      // dipakai - kebutuhan BOM untuk hasil aktual, negatif = hemat
+	TransactionID    *int64 `json:"transaction_id" example:"21"`     // OUT komponen saat order diselesaikan
+}
+
+type AssemblyOrderDetail struct {
+	AssemblyOrderListData
+	OutputTransactionID *int64              `json:"output_transaction_id" example:"24"` // IN product jadi saat order diselesaikan
+	Lines               []AssemblyOrderLine `json:"lines"`
+}
+
+type AssemblyOrderDetailSuccessResp struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Assembly order fetched successfully"`
+	Data    AssemblyOrderDetail `json:"data"`
+}
+
+type AssemblyOrderDetailFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch assembly order"`
+}
+
+// GetAssemblyOrderDetail godoc
+// @Summary Assembly order detail
+// @Description Menampilkan header assembly order, komponen rencana vs dipakai beserta scrap, dan transaksi stok yang diposting saat order diselesaikan
+// @Tags assembly-orders
+// @Produce json
+// @Param id path int true "Assembly order ID"
+// @Success 200 {object} services.AssemblyOrderDetailSuccessResp
+// @Failure 400 {object} services.AssemblyOrderDetailFailResp
+// @Failure 404 {object} services.AssemblyOrderDetailFailResp
+// @Failure 500 {object} services.AssemblyOrderDetailFailResp
+// @Router /stocklab-api/v1/assembly-orders/detail/{id} [get]
+// @Security BearerAuth
+func GetAssemblyOrderDetail(w http.ResponseWriter, r *http.Request) {
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid assembly order id")
+		return
+	}
+
+	d, err := loadAssemblyOrder(db.DB, orderID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "assembly order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, d, "Assembly order fetched successfully")
+}
+
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// loadAssemblyOrder mengambil header dan baris komponen satu assembly order
+func loadAssemblyOrder(q queryer, orderID int64) (AssemblyOrderDetail, error) {
+	var d AssemblyOrderDetail
+	if err := scanAssemblyOrder(q.QueryRow(assemblyOrderSelect+" WHERE ao.id = $1", orderID), &d.AssemblyOrderListData); err != nil {
+		return d, err
+	}
+
+	err := q.QueryRow(`
+		SELECT id FROM transactions WHERE assembly_order_id = $1 AND move_type = $2 ORDER BY id LIMIT 1
+	`, orderID, stock.MoveIn).Scan(&d.OutputTransactionID)
+	if err != nil && err != sql.ErrNoRows {
+		return d, err
+	}
+
+	rows, err := q.Query(`
+		SELECT l.id, l.component_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), l.planned_quantity, l.consumed_quantity, l.scrap_quantity,
+			(SELECT t.id FROM transactions t WHERE t.assembly_order_id = l.assembly_order_id AND t.product_id = l.component_id AND t.move_type = $2 ORDER BY t.id LIMIT 1)
+		FROM assembly_order_lines l
+		LEFT JOIN products p ON p.id = l.component_id
+		WHERE l.assembly_order_id = $1
+		ORDER BY l.id
+	`, orderID, stock.MoveOut)
+	if err != nil {
+		return d, err
+	}
+	defer rows.Close()
+
+	d.Lines = []AssemblyOrderLine{}
+	for rows.Next() {
+		var l AssemblyOrderLine
+		if err := rows.Scan(&l.ID, &l.ComponentID, &l.ComponentName, &l.ComponentSKU, &l.PlannedQuantity, &l.ConsumedQuantity, &l.ScrapQuantity, &l.TransactionID); err != nil {
+			return d, err
+		}
+		d.Lines = append(d.Lines, l)
+	}
+
+	return d, rows.Err()
+}
+
+// requiredQuantity menghitung kebutuhan komponen untuk quantity product jadi,
+// dibulatkan ke atas karena komponen tidak bisa dipakai sebagian
+func requiredQuantity(perOutput, outputQuantity, quantity int64) int64 {
+	return (perOutput*quantity + outputQuantity - 1) / outputQuantity
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+const (
+	StatusDraft     = "DRAFT"
+	StatusCompleted = "COMPLETED"
+	StatusCancelled = "CANCELLED"
+)
+
+type AssemblyOrderListData struct {
+	ID               int64      `json:"id" example:"1"`
+	OrderNumber      string     `json:"order_number" example:"AO-2025-0001"`
+	BomID            int64      `json:"bom_id" example:"1"`
+	BomCode          string     `json:"bom_code" example:"BOM-GIFT-A"`
+	ProductID        int64      `json:"product_id" example:"7"`
+	ProductName      string     `json:"product_name" example:"Hampers Lebaran A"`
+	ProductSKU       string     `json:"product_sku" example:"SKU-20251214201530-777"`
+	WarehouseID      int64      `json:"warehouse_id" example:"1"`
+	Warehouse        string     `json:"warehouse" example:"Main Warehouse"`
+	Status           string     `json:"status" example:"COMPLETED"` // DRAFT | COMPLETED | CANCELLED
+	PlannedQuantity  int64      `json:"planned_quantity" example:"50"`
+	ProducedQuantity int64      `json:"produced_quantity" example:"48"`
+	YieldVariance    *int64     `json:"yield_variance" example:"-2"` // produced - planned, hanya untuk order COMPLETED
+	Notes            string     `json:"notes" example:"Produksi batch 1"`
+	CreatedBy        string     `json:"created_by" example:"John Doe"`
+	CompletedBy      *string    `json:"completed_by" example:"John Doe"`
+	CompletedAt      *time.Time `json:"completed_at" example:"2024-12-15T10:00:00Z"`
+	CreatedAt        time.Time  `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type AssemblyOrderListSuccessResp struct {
+	Status  string                  `json:"status" example:"success"`
+	Message string                  `json:"message" example:"Assembly orders fetched successfully"`
+	Data    []AssemblyOrderListData `json:"data"`
+}
+
+type AssemblyOrderListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch assembly orders"`
+}
+
+const assemblyOrderSelect = `
+	SELECT ao.id, COALESCE(ao.order_number, ''), ao.bom_id, COALESCE(b.code, ''), ao.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''),
+		ao.warehouse_id, COALESCE(wh.name, ''), ao.status, ao.planned_quantity, ao.produced_quantity,
+		COALESCE(ao.notes, ''), COALESCE(u.name, ''), cu.name, ao.completed_at, ao.created_at
+	FROM assembly_orders ao
+	LEFT JOIN boms b ON b.id = ao.bom_id
+	LEFT JOIN products p ON p.id = ao.product_id
+	LEFT JOIN warehouses wh ON wh.id = ao.warehouse_id
+	LEFT JOIN users u ON u.id = ao.created_by
+	LEFT JOIN users cu ON cu.id = ao.completed_by
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAssemblyOrder(row rowScanner, ao *AssemblyOrderListData) error {
+	err := row.Scan(&ao.ID, &ao.OrderNumber, &ao.BomID, &ao.BomCode, &ao.ProductID, &ao.ProductName, &ao.ProductSKU,
+		&ao.WarehouseID, &ao.Warehouse, &ao.Status, &ao.PlannedQuantity, &ao.ProducedQuantity,
+		&ao.Notes, &ao.CreatedBy, &ao.CompletedBy, &ao.CompletedAt, &ao.CreatedAt)
+	if err == nil && ao.Status == StatusCompleted {
+		variance := ao.ProducedQuantity - ao.PlannedQuantity
+		ao.YieldVariance = &variance
+	}
+	return err
+}
+
+// GetAssemblyOrderList godoc
+// @Summary List assembly orders
+// @Description List assembly order beserta quantity rencana dan hasil aktual
+// @Tags assembly-orders
+// @Produce json
+// @Param status query string false "DRAFT | COMPLETED | CANCELLED"
+// @Param product_id query int false "Finished product ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Success 200 {object} services.AssemblyOrderListSuccessResp
+// @Failure 400 {object} services.AssemblyOrderListFailResp
+// @Failure 500 {object} services.AssemblyOrderListFailResp
+// @Router /stocklab-api/v1/assembly-orders [get]
+// @Security BearerAuth
+func GetAssemblyOrderList(w http.ResponseWriter, r *http.Request) {
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+
+	var args []interface{}
+	conditions := []string{}
+
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, "ao.status = $"+strconv.Itoa(len(args)))
+	}
+	for _, param := range []string{"product_id", "warehouse_id"} {
+		val := r.URL.Query().Get(param)
+		if val == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, param+" must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "ao."+param+" = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.DB.Query(assemblyOrderSelect+where+" ORDER BY ao.id DESC", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching assembly orders: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []AssemblyOrderListData{}
+
+	for rows.Next() {
+		var ao AssemblyOrderListData
+		if err := scanAssemblyOrder(rows, &ao); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing assembly orders: "+err.Error())
+			return
+		}
+
+		data = append(data, ao)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading assembly orders: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Assembly orders fetched successfully")
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type AssemblyOrderStatusSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Assembly order cancelled successfully"`
+}
+
+type AssemblyOrderStatusFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Assembly order is already COMPLETED"`
+}
+
+// CancelAssemblyOrder godoc
+// @Summary Cancel assembly order
+// @Description Batalkan assembly order yang belum diselesaikan (DRAFT). Stok tidak berubah.
+// @Tags assembly-orders
+// @Produce json
+// @Param id path int true "Assembly order ID"
+// @Success 200 {object} services.AssemblyOrderStatusSuccessResp
+// @Failure 400 {object} services.AssemblyOrderStatusFailResp
+// @Failure 403 {object} services.AssemblyOrderStatusFailResp
+// @Failure 404 {object} services.AssemblyOrderStatusFailResp
+// @Failure 409 {object} services.AssemblyOrderStatusFailResp
+// @Failure 500 {object} services.AssemblyOrderStatusFailResp
+// @Router /stocklab-api/v1/assembly-orders/cancel/{id} [post]
+// @Security BearerAuth
+func CancelAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid assembly order id")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow("SELECT status FROM assembly_orders WHERE id = $1 FOR UPDATE", orderID).Scan(&status)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "assembly order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status != StatusDraft {
+		utils.RespondError(w, http.StatusConflict, "Assembly order is already "+status)
+		return
+	}
+
+	if _, err := tx.Exec("UPDATE assembly_orders SET status = $1, updated_at = NOW() WHERE id = $2", StatusCancelled, orderID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update assembly order: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, nil, "Assembly order cancelled successfully")
+}
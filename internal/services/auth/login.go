@@ -20,9 +20,11 @@ type AuthLoginParamRequest struct {
 }
 
 type AuthLoginData struct {
-	Token  string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	Role   string `json:"role" example:"admin"`
-	UserId string `json:"user_id" example:"1"`
+	Token        string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"3f5c0e8b9a..."`
+	ExpiresIn    int64  `json:"expires_in" example:"900"`
+	Role         string `json:"role" example:"admin"`
+	UserId       string `json:"user_id" example:"1"`
 }
 
 type AuthLoginSuccessResponse struct {
@@ -75,11 +77,23 @@ func Login(w http.ResponseWriter, r *http.Request, cfg *config.Config) {
 		return
 	}
 
-	tokenStr, err := utils.GenerateJWT(userID, cfg.JWTSecret)
+	sessionID, refreshToken, err := createSession(ctx, userID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create session")
+		return
+	}
+
+	tokenStr, err := utils.GenerateJWT(userID, role, sessionID, cfg.JWTSecret)
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to generate token")
-		http.Error(w, "failed to generate token", http.StatusInternalServerError)
 		return
 	}
-	utils.RespondSuccess(w, map[string]string{"token": tokenStr, "role": role, "user_id": strconv.FormatInt(userID, 10)}, "Login successful")
+
+	utils.RespondSuccess(w, AuthLoginData{
+		Token:        tokenStr,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+		Role:         role,
+		UserId:       strconv.FormatInt(userID, 10),
+	}, "Login successful")
 }
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type AuthLogoutSuccessResponse struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Logout successful"`
+}
+
+type AuthLogoutFailResponse struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to logout"`
+}
+
+// Logout godoc
+// @Summary User logout
+// @Description Mencabut session saat ini sehingga access token dan refresh token tidak berlaku lagi
+// @Tags auth
+// @Produce  json
+// @Success 200 {object} services.AuthLogoutSuccessResponse
+// @Failure 401 {object} services.AuthLogoutFailResponse
+// @Failure 500 {object} services.AuthLogoutFailResponse
+// @Router /stocklab-api/v1/logout [post]
+// @Security BearerAuth
+func Logout(w http.ResponseWriter, r *http.Request) {
+	principal, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	_, err := db.DB.ExecContext(r.Context(), `
+		UPDATE user_sessions
+		SET revoked_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`, principal.SessionID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to logout: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, nil, "Logout successful")
+}
@@ -1,14 +1,20 @@
 package services
 
 import (
-	"context"
 	"net/http"
 	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/config"
+	"github.com/Arrafll/StockLab-Go/internal/db"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 )
 
+// Role yang dikenal sistem (kolom users.role)
+const (
+	RoleAdmin = "admin"
+	RoleStaff = "staff"
+)
+
 // JWTMiddleware membuat middleware untuk memvalidasi token
 func JWTMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -35,12 +41,64 @@ func JWTMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 				return
 			}
 
-			// Simpan user_id di context agar bisa dipakai handler
-			ctx := r.Context()
-			ctx = context.WithValue(ctx, "user_id", claims["user_id"])
+			// Token lama (tanpa role) dianggap tidak valid
+			role, ok := claims["role"].(string)
+			if !ok || role == "" {
+				utils.RespondError(w, http.StatusUnauthorized, "Invalid authorization header")
+				return
+			}
+
+			userID, _ := claims["user_id"].(float64)
+			sessionID, _ := claims["sid"].(float64)
+
+			// Pastikan session belum dicabut (logout, ganti password/role, user dihapus)
+			var active bool
+			err = db.DB.QueryRowContext(r.Context(), `
+				SELECT EXISTS(
+					SELECT 1
+					FROM user_sessions s
+					JOIN users u ON u.id = s.user_id
+					WHERE s.id = $1 AND s.user_id = $2 AND u.role = $3
+					  AND s.revoked_at IS NULL AND s.expires_at > NOW()
+				)
+			`, int64(sessionID), int64(userID), role).Scan(&active)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+				return
+			}
+			if !active {
+				utils.RespondError(w, http.StatusUnauthorized, "Session has been revoked")
+				return
+			}
+
+			// Simpan principal di context agar bisa dipakai handler
+			ctx := WithPrincipal(r.Context(), Principal{
+				UserID:    int64(userID),
+				Role:      role,
+				SessionID: int64(sessionID),
+			})
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// RequireRole membatasi route hanya untuk role tertentu.
+// Harus dipasang setelah JWTMiddleware.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, _ := PrincipalFromContext(r.Context())
+
+			for _, allowed := range roles {
+				if principal.Role == allowed {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			utils.RespondError(w, http.StatusForbidden, "You do not have permission to access this resource")
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+import "context"
+
+// Principal adalah identitas user yang sudah terautentikasi oleh JWTMiddleware
+type Principal struct {
+	UserID    int64
+	Role      string
+	SessionID int64
+}
+
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal menyimpan principal ke dalam context request
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext mengambil principal yang disimpan JWTMiddleware.
+// ok bernilai false jika request tidak melewati JWTMiddleware.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// createSession menyimpan session baru dan mengembalikan id serta refresh token (plain)
+func createSession(ctx context.Context, userID int64) (int64, string, error) {
+	refreshToken, refreshHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return 0, "", err
+	}
+
+	var sessionID int64
+	err = db.DB.QueryRowContext(ctx, `
+		INSERT INTO user_sessions (user_id, refresh_token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, userID, refreshHash, time.Now().Add(utils.RefreshTokenTTL)).Scan(&sessionID)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return sessionID, refreshToken, nil
+}
+
+// RevokeUserSessions mencabut semua session aktif milik user.
+// Dipanggil saat password/role berubah atau user dihapus.
+func RevokeUserSessions(userID int64) error {
+	_, err := db.DB.Exec(`
+		UPDATE user_sessions
+		SET revoked_at = NOW(), updated_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`, userID)
+	return err
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/config"
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type AuthRefreshParamRequest struct {
+	RefreshToken string `json:"refresh_token" example:"3f5c0e8b9a..."`
+}
+
+type AuthRefreshSuccessResponse struct {
+	Status  string        `json:"status" example:"success"`
+	Message string        `json:"message" example:"Token refreshed successfully"`
+	Data    AuthLoginData `json:"data"`
+}
+
+type AuthRefreshFailResponse struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid refresh token"`
+}
+
+// RefreshToken godoc
+// @Summary Refresh access token
+// @Description Tukar refresh token dengan access token baru. Refresh token lama tidak berlaku lagi (rotasi).
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} services.AuthRefreshSuccessResponse
+// @Failure 400 {object} services.AuthRefreshFailResponse
+// @Failure 401 {object} services.AuthRefreshFailResponse
+// @Failure 500 {object} services.AuthRefreshFailResponse
+// @Param body body services.AuthRefreshParamRequest true "Refresh token"
+// @Router /stocklab-api/v1/token/refresh [post]
+func RefreshToken(w http.ResponseWriter, r *http.Request, cfg *config.Config) {
+	var req AuthRefreshParamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var (
+		sessionID int64
+		userID    int64
+		role      string
+		expiresAt time.Time
+		revokedAt sql.NullTime
+	)
+
+	// Lock session agar refresh token yang sama tidak bisa dipakai dua kali bersamaan
+	err = tx.QueryRowContext(ctx, `
+		SELECT s.id, s.user_id, u.role, s.expires_at, s.revoked_at
+		FROM user_sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.refresh_token_hash = $1
+		FOR UPDATE OF s
+	`, utils.HashRefreshToken(req.RefreshToken)).Scan(&sessionID, &userID, &role, &expiresAt, &revokedAt)
+
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusUnauthorized, "Invalid refresh token")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+
+	if revokedAt.Valid || time.Now().After(expiresAt) {
+		utils.RespondError(w, http.StatusUnauthorized, "Refresh token expired or revoked")
+		return
+	}
+
+	// Rotasi refresh token
+	refreshToken, refreshHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE user_sessions
+		SET refresh_token_hash = $1, expires_at = $2, updated_at = NOW()
+		WHERE id = $3
+	`, refreshHash, time.Now().Add(utils.RefreshTokenTTL), sessionID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to rotate refresh token: "+err.Error())
+		return
+	}
+
+	tokenStr, err := utils.GenerateJWT(userID, role, sessionID, cfg.JWTSecret)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, AuthLoginData{
+		Token:        tokenStr,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+		Role:         role,
+		UserId:       strconv.FormatInt(userID, 10),
+	}, "Token refreshed successfully")
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/lib/pq"
+)
+
+type BomLineRequest struct {
+	ProductID int64 `json:"product_id" example:"3"`
+	Quantity  int64 `json:"quantity" example:"2"`
+}
+
+type BomCreateRequest struct {
+	Code           string           `json:"code" example:"BOM-GIFT-A"`
+	ProductID      int64            `json:"product_id" example:"7"`
+	OutputQuantity int64            `json:"output_quantity" example:"1"` // default 1
+	Notes          string           `json:"notes" example:"Keranjang rotan"`
+	Lines          []BomLineRequest `json:"lines"`
+}
+
+type BomCreateSuccessResp struct {
+	Status  string    `json:"status" example:"success"`
+	Message string    `json:"message" example:"Bill of materials created successfully"`
+	Data    BomDetail `json:"data"`
+}
+
+type BomCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to create bill of materials"`
+}
+
+// CreateBom godoc
+// @Summary Create bill of materials
+// @Description Buat bill of materials: komponen (satuan dasar) yang dibutuhkan untuk merakit output_quantity product jadi. Product jadi dan komponen harus product biasa yang punya stok sendiri, bukan kit atau parent product.
+// @Tags boms
+// @Accept json
+// @Produce json
+// @Param body body services.BomCreateRequest true "BOM header and components"
+// @Success 200 {object} services.BomCreateSuccessResp
+// @Failure 400 {object} services.BomCreateFailResp
+// @Failure 401 {object} services.BomCreateFailResp
+// @Failure 409 {object} services.BomCreateFailResp
+// @Failure 500 {object} services.BomCreateFailResp
+// @Router /stocklab-api/v1/boms/create [post]
+// @Security BearerAuth
+func CreateBom(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req BomCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	req.Code = strings.TrimSpace(req.Code)
+	if req.OutputQuantity == 0 {
+		req.OutputQuantity = 1
+	}
+
+	if req.ProductID == 0 || req.OutputQuantity < 0 || len(req.Lines) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "product_id and lines are required")
+		return
+	}
+
+	productIDs := []int64{req.ProductID}
+	seen := map[int64]bool{}
+	for i, line := range req.Lines {
+		if line.ProductID == 0 || line.Quantity <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid BOM line "+strconv.Itoa(i+1))
+			return
+		}
+		if line.ProductID == req.ProductID {
+			utils.RespondError(w, http.StatusBadRequest, "a product cannot be a component of itself")
+			return
+		}
+		if seen[line.ProductID] {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" appears more than once")
+			return
+		}
+		seen[line.ProductID] = true
+		productIDs = append(productIDs, line.ProductID)
+	}
+
+	// Product jadi dan komponen harus punya stok sendiri
+	var known int
+	err := db.DB.QueryRow(
+		"SELECT COUNT(*) FROM products WHERE id = ANY($1::bigint[]) AND NOT is_kit AND NOT is_parent",
+		pq.Array(productIDs),
+	).Scan(&known)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if known != len(productIDs) {
+		utils.RespondError(w, http.StatusBadRequest, "Unknown product, kit or parent product in BOM")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var bomID int64
+	err = tx.QueryRow(`
+		INSERT INTO boms (code, product_id, output_quantity, notes, created_by)
+		VALUES (NULLIF($1, ''), $2, $3, $4, $5)
+		RETURNING id
+	`, req.Code, req.ProductID, req.OutputQuantity, req.Notes, principal.UserID).Scan(&bomID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "BOM code "+req.Code+" is already used")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create bill of materials: "+err.Error())
+		return
+	}
+
+	// Kode BOM otomatis BOM-<id> jika tidak diisi
+	if req.Code == "" {
+		if _, err := tx.Exec(`UPDATE boms SET code = $1 WHERE id = $2`, "BOM-"+strconv.FormatInt(bomID, 10), bomID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create bill of materials: "+err.Error())
+			return
+		}
+	}
+
+	for _, line := range req.Lines {
+		_, err := tx.Exec(`
+			INSERT INTO bom_lines (bom_id, component_id, quantity) VALUES ($1, $2, $3)
+		`, bomID, line.ProductID, line.Quantity)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create BOM lines: "+err.Error())
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	detail, err := loadBom(db.DB, bomID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, detail, "Bill of materials created successfully")
+}
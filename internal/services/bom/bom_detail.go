@@ -0,0 +1,102 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type BomLine struct {
+	ID            int64  `json:"id" example:"1"`
+	ComponentID   int64  `json:"component_id" example:"3"`
+	ComponentName string `json:"component_name" example:"Sirup Marjan 460ml"`
+	ComponentSKU  string `json:"component_sku" example:"SKU-20251214201530-042"`
+	Quantity      int64  `json:"quantity" example:"2"` // per output_quantity product jadi
+}
+
+type BomDetail struct {
+	BomListData
+	Lines []BomLine `json:"lines"`
+}
+
+type BomDetailSuccessResp struct {
+	Status  string    `json:"status" example:"success"`
+	Message string    `json:"message" example:"Bill of materials fetched successfully"`
+	Data    BomDetail `json:"data"`
+}
+
+type BomDetailFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch bill of materials"`
+}
+
+// GetBomDetail godoc
+// @Summary Bill of materials detail
+// @Description Menampilkan header bill of materials dan komponennya
+// @Tags boms
+// @Produce json
+// @Param id path int true "BOM ID"
+// @Success 200 {object} services.BomDetailSuccessResp
+// @Failure 400 {object} services.BomDetailFailResp
+// @Failure 404 {object} services.BomDetailFailResp
+// @Failure 500 {object} services.BomDetailFailResp
+// @Router /stocklab-api/v1/boms/detail/{id} [get]
+// @Security BearerAuth
+func GetBomDetail(w http.ResponseWriter, r *http.Request) {
+	bomID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid bom id")
+		return
+	}
+
+	d, err := loadBom(db.DB, bomID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "bill of materials not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, d, "Bill of materials fetched successfully")
+}
+
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// loadBom mengambil header dan baris satu bill of materials
+func loadBom(q queryer, bomID int64) (BomDetail, error) {
+	var d BomDetail
+	if err := scanBom(q.QueryRow(bomSelect+" WHERE b.id = $1", bomID), &d.BomListData); err != nil {
+		return d, err
+	}
+
+	rows, err := q.Query(`
+		SELECT l.id, l.component_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), l.quantity
+		FROM bom_lines l
+		LEFT JOIN products p ON p.id = l.component_id
+		WHERE l.bom_id = $1
+		ORDER BY l.id
+	`, bomID)
+	if err != nil {
+		return d, err
+	}
+	defer rows.Close()
+
+	d.Lines = []BomLine{}
+	for rows.Next() {
+		var l BomLine
+		if err := rows.Scan(&l.ID, &l.ComponentID, &l.ComponentName, &l.ComponentSKU, &l.Quantity); err != nil {
+			return d, err
+		}
+		d.Lines = append(d.Lines, l)
+	}
+
+	return d, rows.Err()
+}
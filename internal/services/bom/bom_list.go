@@ -0,0 +1,111 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type BomListData struct {
+	ID             int64     `json:"id" example:"1"`
+	Code           string    `json:"code" example:"BOM-GIFT-A"`
+	ProductID      int64     `json:"product_id" example:"7"`
+	ProductName    string    `json:"product_name" example:"Hampers Lebaran A"`
+	ProductSKU     string    `json:"product_sku" example:"SKU-20251214201530-777"`
+	OutputQuantity int64     `json:"output_quantity" example:"1"` // hasil satu kali rakit
+	Notes          string    `json:"notes" example:"Keranjang rotan"`
+	TotalLines     int64     `json:"total_lines" example:"3"`
+	CreatedBy      string    `json:"created_by" example:"John Doe"`
+	CreatedAt      time.Time `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type BomListSuccessResp struct {
+	Status  string        `json:"status" example:"success"`
+	Message string        `json:"message" example:"Bills of materials fetched successfully"`
+	Data    []BomListData `json:"data"`
+}
+
+type BomListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch bills of materials"`
+}
+
+const bomSelect = `
+	SELECT b.id, COALESCE(b.code, ''), b.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), b.output_quantity,
+		COALESCE(b.notes, ''),
+		(SELECT COUNT(*) FROM bom_lines l WHERE l.bom_id = b.id),
+		COALESCE(u.name, ''), b.created_at
+	FROM boms b
+	LEFT JOIN products p ON p.id = b.product_id
+	LEFT JOIN users u ON u.id = b.created_by
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBom(row rowScanner, b *BomListData) error {
+	return row.Scan(&b.ID, &b.Code, &b.ProductID, &b.ProductName, &b.ProductSKU, &b.OutputQuantity,
+		&b.Notes, &b.TotalLines, &b.CreatedBy, &b.CreatedAt)
+}
+
+// GetBomList godoc
+// @Summary List bills of materials
+// @Description List bill of materials untuk assembly order
+// @Tags boms
+// @Produce json
+// @Param product_id query int false "Finished product ID"
+// @Success 200 {object} services.BomListSuccessResp
+// @Failure 400 {object} services.BomListFailResp
+// @Failure 500 {object} services.BomListFailResp
+// @Router /stocklab-api/v1/boms [get]
+// @Security BearerAuth
+func GetBomList(w http.ResponseWriter, r *http.Request) {
+	var args []interface{}
+	conditions := []string{}
+
+	if val := r.URL.Query().Get("product_id"); val != "" {
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "product_id must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "b.product_id = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.DB.Query(bomSelect+where+" ORDER BY b.id DESC", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching bills of materials: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []BomListData{}
+
+	for rows.Next() {
+		var b BomListData
+		if err := scanBom(rows, &b); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing bills of materials: "+err.Error())
+			return
+		}
+
+		data = append(data, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading bills of materials: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Bills of materials fetched successfully")
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/sku"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 )
 
@@ -12,8 +14,9 @@ type CategoryCreate struct {
 }
 
 type CategoryCreateData struct {
-	ID   int64  `json:"id" example:"1"`
-	Name string `json:"name" example:"Vitamin"`
+	ID      int64  `json:"id" example:"1"`
+	Name    string `json:"name" example:"Vitamin"`
+	SKUCode string `json:"sku_code" example:"VIT"`
 }
 
 type CategoryCreateSuccessResp struct {
@@ -34,8 +37,10 @@ type CategoryCreateFailResp struct {
 // @Accept multipart/form-data
 // @Produce json
 // @Param name formData string true "name"
+// @Param sku_code formData string false "sku_code: kode category untuk token {CATEGORY} pola SKU, mis. VIT"
 // @Success 200 {object} services.CategoryCreateSuccessResp
 // @Failure 400 {object} services.CategoryCreateFailResp
+// @Failure 403 {object} services.CategoryCreateFailResp
 // @Failure 500 {object} services.CategoryCreateFailResp
 // @Router /stocklab-api/v1/categories/create [post]
 // @Security BearerAuth
@@ -47,12 +52,20 @@ func CreateCategory(w http.ResponseWriter, r *http.Request) {
 	}
 
 	name := r.FormValue("name")
+	skuCode := strings.ToUpper(strings.TrimSpace(r.FormValue("sku_code")))
 
 	if name == "" {
 		utils.RespondError(w, http.StatusBadRequest, "name is required")
 		return
 	}
 
+	if skuCode != "" {
+		if err := sku.ValidateCode(skuCode); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	// Cek apakah name sudah ada
 	var exists bool
 	err := db.DB.QueryRow(
@@ -70,8 +83,8 @@ func CreateCategory(w http.ResponseWriter, r *http.Request) {
 	}
 	// Insert category ke database
 	var catId int64
-	query := `INSERT INTO categories (name) VALUES ($1) RETURNING id`
-	err = db.DB.QueryRow(query, name).Scan(&catId)
+	query := `INSERT INTO categories (name, sku_code) VALUES ($1, NULLIF($2, '')) RETURNING id`
+	err = db.DB.QueryRow(query, name, skuCode).Scan(&catId)
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to create category: "+err.Error())
 		return
@@ -79,8 +92,9 @@ func CreateCategory(w http.ResponseWriter, r *http.Request) {
 
 	// Response sukses
 	response := CategoryCreateData{
-		ID:   catId,
-		Name: name,
+		ID:      catId,
+		Name:    name,
+		SKUCode: skuCode,
 	}
 
 	utils.RespondSuccess(w, response, "Category created successfully")
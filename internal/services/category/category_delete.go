@@ -29,6 +29,7 @@ type CategoryDeleteFailResp struct {
 // @Produce  json
 // @Success 200 {object} services.CategoryDeleteSuccessResp
 // @Failure 400 {object} services.CategoryDeleteFailResp
+// @Failure 403 {object} services.CategoryDeleteFailResp
 // @Failure 500 {object} services.CategoryDeleteFailResp
 // @Param id path int true "Category Id"
 // @Router /stocklab-api/v1/categories/delete/{id} [delete]
@@ -78,6 +79,12 @@ func DeleteCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Pola SKU category ikut dihapus
+	if _, err := db.DB.Exec("DELETE FROM sku_patterns WHERE category_id=$1", id); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete category SKU pattern: "+err.Error())
+		return
+	}
+
 	// Response sukses
 	response := map[string]interface{}{
 		"id": id,
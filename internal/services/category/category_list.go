@@ -9,8 +9,9 @@ import (
 
 // Category blueprint
 type Category struct {
-	ID   int    `json:"id" example:"1"`
-	Name string `json:"name" example:"Vitamin"`
+	ID      int    `json:"id" example:"1"`
+	Name    string `json:"name" example:"Vitamin"`
+	SKUCode string `json:"sku_code" example:"VIT"` // kosong = 3 huruf pertama name
 }
 
 type CategorySuccessResp struct {
@@ -36,7 +37,7 @@ type CategoryFailResp struct {
 // @Security BearerAuth
 func GetCategoryList(w http.ResponseWriter, r *http.Request) {
 	// Query semua category
-	rows, err := db.DB.Query("SELECT id,name FROM categories ORDER BY id DESC")
+	rows, err := db.DB.Query("SELECT id,name,COALESCE(sku_code, '') FROM categories ORDER BY id DESC")
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch categories: "+err.Error())
 		return
@@ -47,7 +48,7 @@ func GetCategoryList(w http.ResponseWriter, r *http.Request) {
 
 	for rows.Next() {
 		var c Category
-		if err := rows.Scan(&c.ID, &c.Name); err != nil {
+		if err := rows.Scan(&c.ID, &c.Name, &c.SKUCode); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan categories: "+err.Error())
 			return
 		}
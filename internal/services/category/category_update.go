@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/sku"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 	"github.com/go-chi/chi/v5"
 )
@@ -15,8 +16,9 @@ type CategoryUpdate struct {
 }
 
 type CategoryUpdateData struct {
-	ID   int64  `json:"id" example:"1"`
-	Name string `json:"name" example:"Vitamin"`
+	ID      int64  `json:"id" example:"1"`
+	Name    string `json:"name" example:"Vitamin"`
+	SKUCode string `json:"sku_code" example:"VIT"`
 }
 
 type CategoryUpdateSuccessResp struct {
@@ -38,9 +40,11 @@ type CategoryUpdateFailResp struct {
 // @Produce json
 // @Param id path int true "Category ID"
 // @Param name formData string false "Name"
+// @Param sku_code formData string false "sku_code: kode category untuk token {CATEGORY} pola SKU; hanya berlaku untuk SKU baru"
 // @Success 200 {object} services.CategoryUpdateSuccessResp
 // @Failure 400 {object} services.CategoryUpdateFailResp
 // @Failure 404 {object} services.CategoryUpdateFailResp
+// @Failure 403 {object} services.CategoryUpdateFailResp
 // @Failure 500 {object} services.CategoryUpdateFailResp
 // @Router /stocklab-api/v1/categories/update/{id} [patch]
 // @Security BearerAuth
@@ -64,6 +68,13 @@ func UpdateCategory(w http.ResponseWriter, r *http.Request) {
 	}
 
 	name := strings.TrimSpace(r.FormValue("name"))
+	skuCode := strings.ToUpper(strings.TrimSpace(r.FormValue("sku_code")))
+	if skuCode != "" {
+		if err := sku.ValidateCode(skuCode); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 
 	// Cek apakah user ada
 	var exists bool
@@ -102,16 +113,22 @@ func UpdateCategory(w http.ResponseWriter, r *http.Request) {
 		argID++
 	}
 
+	if skuCode != "" {
+		setParts = append(setParts, "sku_code=$"+strconv.Itoa(argID))
+		args = append(args, skuCode)
+		argID++
+	}
+
 	if len(setParts) == 0 {
 		utils.RespondError(w, http.StatusBadRequest, "No fields to update")
 		return
 	}
 
-	query := "UPDATE categories SET " + strings.Join(setParts, ", ") + " WHERE id=$" + strconv.Itoa(argID) + " RETURNING id, name"
+	query := "UPDATE categories SET " + strings.Join(setParts, ", ") + " WHERE id=$" + strconv.Itoa(argID) + " RETURNING id, name, COALESCE(sku_code, '')"
 	args = append(args, catId)
 
 	var updatedCategory CategoryUpdateData
-	err = db.DB.QueryRow(query, args...).Scan(&updatedCategory.ID, &updatedCategory.Name)
+	err = db.DB.QueryRow(query, args...).Scan(&updatedCategory.ID, &updatedCategory.Name, &updatedCategory.SKUCode)
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to update category: "+err.Error())
 		return
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type CustomerDeleteSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Customer deleted successfully"`
+}
+
+type CustomerDeleteFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to delete customer"`
+}
+
+// DeleteCustomer godoc
+// @Summary Delete customer
+// @Description Delete a customer without sales orders. Customers with order history should be deactivated instead.
+// @Tags customers
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} services.CustomerDeleteSuccessResp
+// @Failure 400 {object} services.CustomerDeleteFailResp
+// @Failure 403 {object} services.CustomerDeleteFailResp
+// @Failure 404 {object} services.CustomerDeleteFailResp
+// @Failure 409 {object} services.CustomerDeleteFailResp
+// @Failure 500 {object} services.CustomerDeleteFailResp
+// @Router /stocklab-api/v1/customers/delete/{id} [delete]
+// @Security BearerAuth
+func DeleteCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Customer Id must be a number")
+		return
+	}
+
+	// Cek apakah customer ada
+	var exists bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM customers WHERE id=$1)", customerID).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !exists {
+		utils.RespondError(w, http.StatusNotFound, "Customer not found")
+		return
+	}
+
+	// Cek apakah sudah punya sales order
+	var used bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM sales_orders WHERE customer_id=$1)", customerID).Scan(&used)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if used {
+		utils.RespondError(w, http.StatusConflict, "Cannot delete customer that has sales orders, deactivate it instead")
+		return
+	}
+
+	if _, err = db.DB.Exec("DELETE FROM customers WHERE id=$1", customerID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete customer: "+err.Error())
+		return
+	}
+
+	// Response sukses
+	response := map[string]interface{}{
+		"id": customerID,
+	}
+	utils.RespondSuccess(w, response, "Customer deleted successfully")
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Customer blueprint
+type Customer struct {
+	ID          int64  `json:"id" example:"1"`
+	Code        string `json:"code" example:"CUS-ALFAMART"`
+	Name        string `json:"name" example:"PT Sumber Alfaria"`
+	ContactName string `json:"contact_name" example:"Siti"`
+	Phone       string `json:"phone" example:"08123456789"`
+	Email       string `json:"email" example:"purchasing@alfamart.co.id"`
+	Address     string `json:"address" example:"Jl. Sudirman No. 1"`
+	IsActive    bool   `json:"is_active" example:"true"`
+}
+
+type CustomerSuccessResp struct {
+	Status  string     `json:"status" example:"success"`
+	Message string     `json:"message" example:"Customers fetched successfully"`
+	Data    []Customer `json:"data"`
+}
+
+type CustomerFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch customers"`
+}
+
+const customerColumns = `id, code, name, COALESCE(contact_name, ''), COALESCE(phone, ''), COALESCE(email, ''), COALESCE(address, ''), is_active`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCustomer(row rowScanner, s *Customer) error {
+	return row.Scan(&s.ID, &s.Code, &s.Name, &s.ContactName, &s.Phone, &s.Email, &s.Address, &s.IsActive)
+}
+
+// GetCustomerList godoc
+// @Summary Get list of customers
+// @Description Get all customers
+// @Tags customers
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} services.CustomerSuccessResp
+// @Failure 500 {object} services.CustomerFailResp
+// @Router /stocklab-api/v1/customers [get]
+// @Security BearerAuth
+func GetCustomerList(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.DB.Query("SELECT " + customerColumns + " FROM customers ORDER BY name ASC")
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch customers: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	customers := []Customer{}
+
+	for rows.Next() {
+		var s Customer
+		if err := scanCustomer(rows, &s); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan customers: "+err.Error())
+			return
+		}
+
+		customers = append(customers, s)
+	}
+
+	// Cek apakah ada error saat iterasi rows
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading customers: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, customers, "Customers fetched successfully")
+}
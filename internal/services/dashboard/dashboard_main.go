@@ -2,19 +2,24 @@ package services
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 )
 
 // Dashboard blueprint
 type DashboardData struct {
-	ProductTotal         int                      `json:"product_total"`
-	StockTotal           int                      `json:"stock_total"`
-	LowStockTotal        int                      `json:"low_stock"`
-	NoStockTotal         int                      `json:"no_stock"`
-	ChartActivityDataIn  []map[string]interface{} `json:"chart_activity_data_in"`
-	ChartActivityDataOut []map[string]interface{} `json:"chart_activity_data_out"`
+	ProductTotal          int                      `json:"product_total"`
+	StockTotal            int                      `json:"stock_total"`
+	LowStockTotal         int                      `json:"low_stock"`
+	NoStockTotal          int                      `json:"no_stock"`
+	BelowSafetyStockTotal int                      `json:"below_safety_stock"`
+	ChartActivityDataIn   []map[string]interface{} `json:"chart_activity_data_in"`
+	ChartActivityDataOut  []map[string]interface{} `json:"chart_activity_data_out"`
+	AdjustmentByReason    []map[string]interface{} `json:"adjustment_by_reason"`
 }
 
 type DashboardSuccessResp struct {
@@ -32,20 +37,44 @@ func DashboardMain(w http.ResponseWriter, r *http.Request) {
 	// ✅ PAKAI camelCase
 	var dashboardData DashboardData
 
-	// Widget data
+	// Filter warehouse OPTIONAL, default total semua warehouse
+	var warehouseID interface{}
+	if whVal := r.URL.Query().Get("warehouse_id"); whVal != "" {
+		val, err := strconv.ParseInt(whVal, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		warehouseID = val
+	}
+
+	// Filter reason code OPTIONAL untuk chart aktivitas
+	var reasonCode interface{}
+	if rcVal := strings.ToUpper(r.URL.Query().Get("reason_code")); rcVal != "" {
+		reasonCode = rcVal
+	}
+
+	// Widget data, low stock dihitung terhadap reorder point masing-masing product.
+	// Tanpa filter warehouse stok dijumlahkan per product, dengan filter pakai ambang per lokasi.
 	widgetQuery := `
+		WITH product_stocks AS (
+			SELECT * FROM (` + stock.LevelsSQL(warehouseID != nil) + `) l
+			WHERE $1::bigint IS NULL OR warehouse_id = $1
+		)
 		SELECT 
-			(SELECT COUNT(*) FROM products),
-			(SELECT COALESCE(SUM(quantity),0) FROM stocks),
-			(SELECT COUNT(*) FROM stocks WHERE quantity < 10 AND quantity > 0),
-			(SELECT COUNT(*) FROM stocks WHERE quantity = 0)
+			(SELECT COUNT(*) FROM products WHERE NOT is_parent AND NOT is_kit),
+			(SELECT COALESCE(SUM(quantity),0) FROM product_stocks),
+			(SELECT COUNT(*) FROM product_stocks WHERE quantity < reorder_point AND quantity > 0),
+			(SELECT COUNT(*) FROM product_stocks WHERE quantity = 0),
+			(SELECT COUNT(*) FROM product_stocks WHERE quantity < safety_stock)
 	`
 
-	err := db.DB.QueryRow(widgetQuery).Scan(
+	err := db.DB.QueryRow(widgetQuery, warehouseID).Scan(
 		&dashboardData.ProductTotal,
 		&dashboardData.StockTotal,
 		&dashboardData.LowStockTotal,
 		&dashboardData.NoStockTotal,
+		&dashboardData.BelowSafetyStockTotal,
 	)
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, err.Error())
@@ -53,14 +82,21 @@ func DashboardMain(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Chart IN
-	dashboardData.ChartActivityDataIn, err = DashboardChartByMoveType("IN")
+	dashboardData.ChartActivityDataIn, err = DashboardChartByMoveType("IN", warehouseID, reasonCode)
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	// Chart OUT
-	dashboardData.ChartActivityDataOut, err = DashboardChartByMoveType("OUT")
+	dashboardData.ChartActivityDataOut, err = DashboardChartByMoveType("OUT", warehouseID, reasonCode)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Penyesuaian stok per reason code
+	dashboardData.AdjustmentByReason, err = DashboardAdjustmentByReason(warehouseID)
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -69,7 +105,7 @@ func DashboardMain(w http.ResponseWriter, r *http.Request) {
 	utils.RespondSuccess(w, dashboardData, "Dashboard data fetched successfully")
 }
 
-func DashboardChartByMoveType(moveType string) ([]map[string]interface{}, error) {
+func DashboardChartByMoveType(moveType string, warehouseID interface{}, reasonCode interface{}) ([]map[string]interface{}, error) {
 	query := `
 		SELECT 
 			d::date AS date,
@@ -82,11 +118,13 @@ func DashboardChartByMoveType(moveType string) ([]map[string]interface{}, error)
 		LEFT JOIN transactions tr
 			ON date_trunc('day', tr.created_at) = d::date
 			AND tr.move_type = $1
+			AND ($2::bigint IS NULL OR tr.warehouse_id = $2)
+			AND ($3::text IS NULL OR tr.reason_code_id = (SELECT id FROM reason_codes WHERE UPPER(code) = $3))
 		GROUP BY d
 		ORDER BY d;
 	`
 
-	rows, err := db.DB.Query(query, moveType)
+	rows, err := db.DB.Query(query, moveType, warehouseID, reasonCode)
 	if err != nil {
 		return nil, err
 	}
@@ -110,3 +148,49 @@ func DashboardChartByMoveType(moveType string) ([]map[string]interface{}, error)
 
 	return result, nil
 }
+
+// DashboardAdjustmentByReason menjumlahkan pergerakan ber-reason code 7 hari terakhir
+func DashboardAdjustmentByReason(warehouseID interface{}) ([]map[string]interface{}, error) {
+	query := `
+		SELECT
+			rc.code,
+			rc.name,
+			COUNT(tr.id) AS total,
+			COALESCE(SUM(tr.quantity) FILTER (WHERE tr.move_type IN ('IN', 'ADJ_IN')), 0) AS quantity_in,
+			COALESCE(SUM(tr.quantity) FILTER (WHERE tr.move_type IN ('OUT', 'ADJ_OUT')), 0) AS quantity_out
+		FROM reason_codes rc
+		JOIN transactions tr
+			ON tr.reason_code_id = rc.id
+			AND tr.created_at >= CURRENT_DATE - INTERVAL '6 days'
+			AND ($1::bigint IS NULL OR tr.warehouse_id = $1)
+		GROUP BY rc.id
+		ORDER BY rc.code;
+	`
+
+	rows, err := db.DB.Query(query, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []map[string]interface{}{}
+
+	for rows.Next() {
+		var code, name string
+		var total, qtyIn, qtyOut int
+
+		if err := rows.Scan(&code, &name, &total, &qtyIn, &qtyOut); err != nil {
+			return nil, err
+		}
+
+		result = append(result, map[string]interface{}{
+			"reason_code":  code,
+			"reason_name":  name,
+			"total":        total,
+			"quantity_in":  qtyIn,
+			"quantity_out": qtyOut,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/lib/pq"
+)
+
+const (
+	DocTypeReceipt = "RECEIPT" // goods receipt, semua baris IN
+	DocTypeIssue   = "ISSUE"   // goods issue, semua baris OUT
+)
+
+type DocumentLineRequest struct {
+	ProductID  int64    `json:"product_id" example:"1"`
+	Quantity   int64    `json:"quantity" example:"40"`
+	LotNumber  string   `json:"lot_number" example:"LOT-2025-11A"` // wajib untuk RECEIPT product lot-tracked; ISSUE tanpa lot = FEFO
+	ExpiryDate string   `json:"expiry_date" example:"2026-05-31"`  // kedaluwarsa lot yang diterima
+	Serials    []string `json:"serial_numbers" example:"SN-0001"`  // wajib untuk product serialized, satu per unit
+}
+
+type DocumentCreateRequest struct {
+	DocType     string                `json:"doc_type" example:"RECEIPT"`
+	ReferenceNo string                `json:"reference_no" example:"SJ-2025-0001"`
+	Partner     string                `json:"partner" example:"PT Indofood"`
+	Notes       string                `json:"notes" example:"Pengiriman mingguan"`
+	DocDate     string                `json:"doc_date" example:"2025-12-14"`
+	WarehouseID int64                 `json:"warehouse_id" example:"1"`
+	Lines       []DocumentLineRequest `json:"lines"`
+}
+
+type DocumentLine struct {
+	TransactionID int64         `json:"transaction_id" example:"10"`
+	ProductID     int64         `json:"product_id" example:"1"`
+	Quantity      int64         `json:"quantity" example:"40"`
+	Lots          []DocumentLot `json:"lots,omitempty"` // hanya untuk product lot-tracked
+	Serials       []string      `json:"serial_numbers,omitempty" example:"SN-0001"`
+	KitProductID  int64         `json:"kit_product_id,omitempty" example:"7"` // diisi jika baris ini komponen dari kit yang dikeluarkan
+}
+
+type DocumentLot struct {
+	LotNumber  string `json:"lot_number" example:"LOT-2025-11A"`
+	ExpiryDate string `json:"expiry_date,omitempty" example:"2026-05-31"`
+	Quantity   int64  `json:"quantity" example:"40"`
+}
+
+type DocumentCreateData struct {
+	ID          int64          `json:"id" example:"1"`
+	DocType     string         `json:"doc_type" example:"RECEIPT"`
+	ReferenceNo string         `json:"reference_no" example:"SJ-2025-0001"`
+	Partner     string         `json:"partner" example:"PT Indofood"`
+	Notes       string         `json:"notes" example:"Pengiriman mingguan"`
+	DocDate     string         `json:"doc_date" example:"2025-12-14"`
+	WarehouseID int64          `json:"warehouse_id" example:"1"`
+	UserID      int64          `json:"user_id" example:"1"`
+	Lines       []DocumentLine `json:"lines"`
+}
+
+type DocumentCreateSuccessResp struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Document posted successfully"`
+	Data    DocumentCreateData `json:"data"`
+}
+
+type DocumentCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to post document"`
+}
+
+// CreateDocument godoc
+// @Summary Post stock movement document
+// @Description Posting dokumen penerimaan (RECEIPT) atau pengeluaran (ISSUE) dengan banyak baris. Semua baris dibukukan dalam satu DB transaction, gagal satu berarti gagal semua. Product lot-tracked wajib lot_number pada RECEIPT; ISSUE tanpa lot_number mengambil lot FEFO. Product serialized wajib serial_numbers sebanyak quantity. Baris kit pada ISSUE dipecah menjadi satu baris per komponen sesuai bill of materials.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param body body services.DocumentCreateRequest true "Document header and lines"
+// @Param Idempotency-Key header string false "Unique key per movement; retries with the same key return the original response"
+// @Success 200 {object} services.DocumentCreateSuccessResp
+// @Failure 400 {object} services.DocumentCreateFailResp
+// @Failure 401 {object} services.DocumentCreateFailResp
+// @Failure 409 {object} services.DocumentCreateFailResp
+// @Failure 422 {object} services.DocumentCreateFailResp
+// @Failure 500 {object} services.DocumentCreateFailResp
+// @Router /stocklab-api/v1/documents/create [post]
+// @Security BearerAuth
+func CreateDocument(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req DocumentCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	req.DocType = strings.ToUpper(strings.TrimSpace(req.DocType))
+	req.ReferenceNo = strings.TrimSpace(req.ReferenceNo)
+
+	var moveType string
+	switch req.DocType {
+	case DocTypeReceipt:
+		moveType = stock.MoveIn
+	case DocTypeIssue:
+		moveType = stock.MoveOut
+	default:
+		utils.RespondError(w, http.StatusBadRequest, "doc_type must be RECEIPT or ISSUE")
+		return
+	}
+
+	if req.WarehouseID == 0 || len(req.Lines) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "warehouse_id and lines are required")
+		return
+	}
+
+	if req.DocDate == "" {
+		req.DocDate = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", req.DocDate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "doc_date must be YYYY-MM-DD")
+		return
+	}
+
+	for i, line := range req.Lines {
+		if line.ProductID == 0 || line.Quantity <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid document line "+strconv.Itoa(i+1))
+			return
+		}
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	// Baris kit dipecah menjadi movement per komponen
+	movements := make([][]stock.Movement, len(req.Lines))
+	keys := []stock.Key{}
+	for i, line := range req.Lines {
+		movements[i], err = stock.ExpandKit(tx, stock.Movement{
+			Key:        stock.Key{ProductID: line.ProductID, WarehouseID: req.WarehouseID},
+			UserID:     principal.UserID,
+			Quantity:   line.Quantity,
+			MoveType:   moveType,
+			LotNumber:  strings.TrimSpace(line.LotNumber),
+			ExpiryDate: strings.TrimSpace(line.ExpiryDate),
+			Serials:    line.Serials,
+		})
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+		keys = append(keys, stock.MovementKeys(movements[i])...)
+	}
+
+	// Lock semua baris stok sekaligus dengan urutan yang deterministik
+	balances, err := stock.Lock(tx, keys)
+	if err != nil {
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
+		return
+	}
+
+	resp := DocumentCreateData{
+		DocType:     req.DocType,
+		ReferenceNo: req.ReferenceNo,
+		Partner:     req.Partner,
+		Notes:       req.Notes,
+		DocDate:     req.DocDate,
+		WarehouseID: req.WarehouseID,
+		UserID:      principal.UserID,
+		Lines:       []DocumentLine{},
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO stock_documents (doc_type, reference_no, partner, notes, doc_date, warehouse_id, user_id)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7)
+		RETURNING id
+	`, req.DocType, req.ReferenceNo, req.Partner, req.Notes, req.DocDate, req.WarehouseID, principal.UserID).Scan(&resp.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "Reference number "+req.ReferenceNo+" is already posted")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create document: "+err.Error())
+		return
+	}
+
+	// Nomor referensi otomatis GR-/GI-<id> jika tidak diisi
+	if req.ReferenceNo == "" {
+		prefix := "GR-"
+		if req.DocType == DocTypeIssue {
+			prefix = "GI-"
+		}
+		resp.ReferenceNo = prefix + strconv.FormatInt(resp.ID, 10)
+		if _, err := tx.Exec(`UPDATE stock_documents SET reference_no = $1 WHERE id = $2`, resp.ReferenceNo, resp.ID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create document: "+err.Error())
+			return
+		}
+	}
+
+	for i := range req.Lines {
+		for _, m := range movements[i] {
+			m.DocumentID = resp.ID
+			txID, err := stock.Apply(tx, balances, m)
+			if err != nil {
+				utils.RespondError(w, stock.StatusCode(err), err.Error())
+				return
+			}
+
+			lots, err := stock.TransactionLots(tx, txID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			resp.Lines = append(resp.Lines, DocumentLine{
+				TransactionID: txID,
+				ProductID:     m.ProductID,
+				Quantity:      m.Quantity,
+				KitProductID:  m.KitProductID,
+				Lots:          DocumentLots(lots),
+				Serials:       m.Serials,
+			})
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Document posted successfully")
+}
+
+// DocumentLots mengubah lot hasil stock.Apply menjadi bentuk response
+func DocumentLots(lots []stock.LotQty) []DocumentLot {
+	result := make([]DocumentLot, 0, len(lots))
+	for _, l := range lots {
+		result = append(result, DocumentLot{LotNumber: l.LotNumber, ExpiryDate: l.ExpiryDate, Quantity: l.Quantity})
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type DocumentDetailLine struct {
+	TransactionID int64  `json:"transaction_id" example:"10"`
+	ProductID     int64  `json:"product_id" example:"1"`
+	ProductName   string `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU    string `json:"product_sku" example:"SKU-20251214201530-042"`
+	Quantity      int64  `json:"quantity" example:"40"`
+	MoveType      string `json:"move_type" example:"IN"`
+}
+
+type DocumentDetail struct {
+	DocumentListData
+	WarehouseID int64                `json:"warehouse_id" example:"1"`
+	Lines       []DocumentDetailLine `json:"lines"`
+}
+
+type DocumentDetailSuccessResp struct {
+	Status  string         `json:"status" example:"success"`
+	Message string         `json:"message" example:"Document fetched successfully"`
+	Data    DocumentDetail `json:"data"`
+}
+
+type DocumentDetailFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch document"`
+}
+
+// GetDocumentDetail godoc
+// @Summary Stock movement document detail
+// @Description Menampilkan header dokumen beserta semua barisnya
+// @Tags documents
+// @Produce json
+// @Param id path int true "Document ID"
+// @Success 200 {object} services.DocumentDetailSuccessResp
+// @Failure 400 {object} services.DocumentDetailFailResp
+// @Failure 404 {object} services.DocumentDetailFailResp
+// @Failure 500 {object} services.DocumentDetailFailResp
+// @Router /stocklab-api/v1/documents/detail/{id} [get]
+// @Security BearerAuth
+func GetDocumentDetail(w http.ResponseWriter, r *http.Request) {
+	documentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid document id")
+		return
+	}
+
+	var d DocumentDetail
+	err = db.DB.QueryRow(`
+		SELECT d.id, d.doc_type, COALESCE(d.reference_no, ''), COALESCE(d.partner, ''), COALESCE(d.notes, ''),
+			TO_CHAR(d.doc_date, 'YYYY-MM-DD'), d.warehouse_id, COALESCE(wh.name, ''), COALESCE(u.name, ''), d.created_at
+		FROM stock_documents d
+		LEFT JOIN warehouses wh ON wh.id = d.warehouse_id
+		LEFT JOIN users u ON u.id = d.user_id
+		WHERE d.id = $1
+	`, documentID).Scan(&d.ID, &d.DocType, &d.ReferenceNo, &d.Partner, &d.Notes, &d.DocDate, &d.WarehouseID, &d.Warehouse, &d.PICName, &d.CreatedAt)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "document not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	rows, err := db.DB.Query(`
+		SELECT tr.id, tr.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), tr.quantity, tr.move_type
+		FROM transactions tr
+		LEFT JOIN products p ON p.id = tr.product_id
+		WHERE tr.document_id = $1
+		ORDER BY tr.id
+	`, documentID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer rows.Close()
+
+	d.Lines = []DocumentDetailLine{}
+	for rows.Next() {
+		var l DocumentDetailLine
+		if err := rows.Scan(&l.TransactionID, &l.ProductID, &l.ProductName, &l.ProductSKU, &l.Quantity, &l.MoveType); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		d.Lines = append(d.Lines, l)
+		d.TotalLines++
+		d.TotalQuantity += l.Quantity
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, d, "Document fetched successfully")
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type DocumentListData struct {
+	ID            int64     `json:"id" example:"1"`
+	DocType       string    `json:"doc_type" example:"RECEIPT"`
+	ReferenceNo   string    `json:"reference_no" example:"SJ-2025-0001"`
+	Partner       string    `json:"partner" example:"PT Indofood"`
+	Notes         string    `json:"notes" example:"Pengiriman mingguan"`
+	DocDate       string    `json:"doc_date" example:"2025-12-14"`
+	Warehouse     string    `json:"warehouse" example:"Main Warehouse"`
+	PICName       string    `json:"pic_name" example:"John Doe"`
+	TotalLines    int64     `json:"total_lines" example:"40"`
+	TotalQuantity int64     `json:"total_quantity" example:"1600"`
+	CreatedAt     time.Time `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type DocumentListSuccessResp struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Documents fetched successfully"`
+	Data    []DocumentListData `json:"data"`
+}
+
+type DocumentListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch documents"`
+}
+
+// GetDocumentList godoc
+// @Summary List stock movement documents
+// @Description List dokumen penerimaan/pengeluaran barang
+// @Tags documents
+// @Produce json
+// @Param doc_type query string false "RECEIPT | ISSUE"
+// @Param start_date query string false "Start document date (YYYY-MM-DD)"
+// @Param end_date query string false "End document date (YYYY-MM-DD)"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Success 200 {object} services.DocumentListSuccessResp
+// @Failure 400 {object} services.DocumentListFailResp
+// @Failure 500 {object} services.DocumentListFailResp
+// @Router /stocklab-api/v1/documents [get]
+// @Security BearerAuth
+func GetDocumentList(w http.ResponseWriter, r *http.Request) {
+	docType := strings.ToUpper(r.URL.Query().Get("doc_type"))
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	warehouseID := r.URL.Query().Get("warehouse_id")
+
+	query := `
+		SELECT d.id, d.doc_type, COALESCE(d.reference_no, ''), COALESCE(d.partner, ''), COALESCE(d.notes, ''),
+			TO_CHAR(d.doc_date, 'YYYY-MM-DD'), COALESCE(wh.name, ''), COALESCE(u.name, ''),
+			COUNT(tr.id), COALESCE(SUM(tr.quantity), 0), d.created_at
+		FROM stock_documents d
+		LEFT JOIN warehouses wh ON wh.id = d.warehouse_id
+		LEFT JOIN users u ON u.id = d.user_id
+		LEFT JOIN transactions tr ON tr.document_id = d.id
+	`
+
+	var args []interface{}
+	conditions := []string{}
+
+	if docType != "" {
+		args = append(args, docType)
+		conditions = append(conditions, "d.doc_type = $"+strconv.Itoa(len(args)))
+	}
+	if startDate != "" {
+		args = append(args, startDate)
+		conditions = append(conditions, "d.doc_date >= $"+strconv.Itoa(len(args)))
+	}
+	if endDate != "" {
+		args = append(args, endDate)
+		conditions = append(conditions, "d.doc_date <= $"+strconv.Itoa(len(args)))
+	}
+	if warehouseID != "" {
+		id, err := strconv.ParseInt(warehouseID, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "d.warehouse_id = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.DB.Query(query+where+" GROUP BY d.id, wh.name, u.name ORDER BY d.doc_date DESC, d.id DESC", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching documents: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []DocumentListData{}
+
+	for rows.Next() {
+		var d DocumentListData
+		if err := rows.Scan(&d.ID, &d.DocType, &d.ReferenceNo, &d.Partner, &d.Notes, &d.DocDate, &d.Warehouse, &d.PICName, &d.TotalLines, &d.TotalQuantity, &d.CreatedAt); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing documents: "+err.Error())
+			return
+		}
+
+		data = append(data, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading documents: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Documents fetched successfully")
+}
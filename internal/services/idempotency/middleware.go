@@ -0,0 +1,251 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+)
+
+// claimTimeout adalah umur klaim tanpa response (status_code NULL) yang dianggap
+// ditinggalkan, mis. proses mati di tengah request, sehingga key boleh diklaim ulang
+const claimTimeout = "1 minute"
+
+// responseRecorder meneruskan response ke client sekaligus menyimpan salinannya
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// Middleware membuat POST yang membawa header Idempotency-Key aman untuk di-retry.
+// Request pertama diproses normal dan response-nya disimpan; retry dengan key dan
+// payload yang sama mendapat response yang sama tanpa membukukan stok lagi.
+// Key yang sama dengan payload berbeda ditolak. Harus dipasang setelah JWTMiddleware.
+// Klaim key dan response tersimpan tidak atomik dengan transaksi handler: jika response
+// gagal disimpan setelah handler commit, klaim dilepas dan retry akan diproses ulang.
+// Klaim juga dilepas jika handler panic, dan klaim tanpa response yang lebih tua dari
+// claimTimeout (proses mati di tengah request) boleh diambil alih oleh retry.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimSpace(r.Header.Get(HeaderKey))
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > 255 {
+			utils.RespondError(w, http.StatusBadRequest, HeaderKey+" is too long")
+			return
+		}
+
+		principal, ok := authService.PrincipalFromContext(r.Context())
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		requestHash, err := hashRequest(r)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid data: "+err.Error())
+			return
+		}
+
+		// Key kadaluarsa boleh dipakai ulang
+		_, err = db.DB.ExecContext(r.Context(), `
+			DELETE FROM idempotency_keys
+			WHERE user_id = $1 AND idempotency_key = $2 AND created_at < NOW() - INTERVAL '24 hours'
+		`, principal.UserID, key)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+			return
+		}
+
+		// Klaim key, hanya satu request yang berhasil insert. Klaim lama yang tidak
+		// pernah mendapat response diambil alih supaya retry tidak tertahan 409.
+		var claimID int64
+		err = db.DB.QueryRowContext(r.Context(), `
+			INSERT INTO idempotency_keys (user_id, idempotency_key, request_path, request_hash)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, idempotency_key) DO UPDATE
+			SET request_path = EXCLUDED.request_path, request_hash = EXCLUDED.request_hash,
+				created_at = NOW(), updated_at = NOW()
+			WHERE idempotency_keys.status_code IS NULL
+				AND idempotency_keys.created_at < NOW() - INTERVAL '`+claimTimeout+`'
+			RETURNING id
+		`, principal.UserID, key, r.URL.Path, requestHash).Scan(&claimID)
+
+		if err == sql.ErrNoRows {
+			replay(w, r, principal.UserID, key, requestHash)
+			return
+		} else if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+			return
+		}
+
+		// Handler yang panic tidak boleh meninggalkan klaim tanpa response
+		defer func() {
+			if p := recover(); p != nil {
+				releaseClaim(claimID, key)
+				panic(p)
+			}
+		}()
+
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		// Error server tidak disimpan supaya client bisa retry dengan key yang sama
+		if rec.status == 0 || rec.status >= http.StatusInternalServerError {
+			releaseClaim(claimID, key)
+			return
+		}
+
+		// Response disimpan setelah handler commit transaksinya sendiri, jadi keduanya tidak atomik.
+		// Jika gagal disimpan, klaim dilepas: retry akan diproses ulang, bukan tertahan 409 selamanya.
+		_, err = db.DB.Exec(`
+			UPDATE idempotency_keys
+			SET status_code = $1, response_body = $2, updated_at = NOW()
+			WHERE id = $3
+		`, rec.status, rec.body.Bytes(), claimID)
+		if err != nil {
+			log.Printf("idempotency: failed to store response for key %q: %v", key, err)
+			releaseClaim(claimID, key)
+		}
+	})
+}
+
+// releaseClaim menghapus klaim key supaya request yang sama bisa di-retry
+func releaseClaim(claimID int64, key string) {
+	if _, err := db.DB.Exec(`DELETE FROM idempotency_keys WHERE id = $1`, claimID); err != nil {
+		log.Printf("idempotency: failed to release key %q: %v", key, err)
+	}
+}
+
+func replay(w http.ResponseWriter, r *http.Request, userID int64, key string, requestHash string) {
+	var (
+		storedPath string
+		storedHash string
+		statusCode sql.NullInt64
+		body       []byte
+	)
+	err := db.DB.QueryRowContext(r.Context(), `
+		SELECT request_path, request_hash, status_code, response_body
+		FROM idempotency_keys
+		WHERE user_id = $1 AND idempotency_key = $2
+	`, userID, key).Scan(&storedPath, &storedHash, &statusCode, &body)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusConflict, "A request with this "+HeaderKey+" is still being processed")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+
+	if storedPath != r.URL.Path || storedHash != requestHash {
+		utils.RespondError(w, http.StatusUnprocessableEntity, HeaderKey+" was already used with a different payload")
+		return
+	}
+
+	if !statusCode.Valid {
+		utils.RespondError(w, http.StatusConflict, "A request with this "+HeaderKey+" is still being processed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(int(statusCode.Int64))
+	w.Write(body)
+}
+
+// hashRequest menghitung hash payload. Form multipart di-hash dari nilai field-nya,
+// bukan raw body, karena boundary bisa berbeda di setiap retry.
+func hashRequest(r *http.Request) (string, error) {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+"\n")
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "multipart/form-data", "application/x-www-form-urlencoded":
+		if mediaType == "multipart/form-data" {
+			if err := r.ParseMultipartForm(10 << 20); err != nil {
+				return "", err
+			}
+		} else if err := r.ParseForm(); err != nil {
+			return "", err
+		}
+
+		// PostForm sudah berisi field multipart maupun urlencoded
+		writeValues(h, r.PostForm)
+		if r.MultipartForm != nil {
+			names := make([]string, 0, len(r.MultipartForm.File))
+			for name := range r.MultipartForm.File {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				for _, fh := range r.MultipartForm.File[name] {
+					f, err := fh.Open()
+					if err != nil {
+						return "", err
+					}
+					io.WriteString(h, "file:"+name+"\n")
+					_, err = io.Copy(h, f)
+					f.Close()
+					if err != nil {
+						return "", err
+					}
+				}
+			}
+		}
+	default:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return "", err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		h.Write(body)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeValues(h io.Writer, values map[string][]string) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range values[name] {
+			io.WriteString(h, name+"="+v+"\n")
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Default jendela kedaluwarsa jika days tidak diisi
+const defaultExpiringDays = 30
+
+type LotExpiringSuccessResp struct {
+	Status  string        `json:"status" example:"success"`
+	Message string        `json:"message" example:"Expiring lots fetched successfully"`
+	Data    []LotListData `json:"data"`
+}
+
+type LotExpiringFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"days must be a non-negative number"`
+}
+
+// GetExpiringLots godoc
+// @Summary Lots expiring soon
+// @Description List lot yang masih ada stoknya dan kedaluwarsa dalam N hari ke depan, termasuk yang sudah lewat kedaluwarsa. Urut dari kedaluwarsa terdekat.
+// @Tags lots
+// @Produce json
+// @Param days query int false "Jumlah hari ke depan (default 30)"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param product_id query int false "Product ID"
+// @Success 200 {object} services.LotExpiringSuccessResp
+// @Failure 400 {object} services.LotExpiringFailResp
+// @Failure 500 {object} services.LotExpiringFailResp
+// @Router /stocklab-api/v1/lots/expiring [get]
+// @Security BearerAuth
+func GetExpiringLots(w http.ResponseWriter, r *http.Request) {
+	days := int64(defaultExpiringDays)
+	if val := r.URL.Query().Get("days"); val != "" {
+		parsed, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || parsed < 0 {
+			utils.RespondError(w, http.StatusBadRequest, "days must be a non-negative number")
+			return
+		}
+		days = parsed
+	}
+
+	args := []interface{}{days}
+	where := " WHERE l.quantity > 0 AND l.expiry_date IS NOT NULL AND l.expiry_date <= CURRENT_DATE + $1::int"
+
+	for _, param := range []string{"warehouse_id", "product_id"} {
+		val := r.URL.Query().Get(param)
+		if val == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, param+" must be number")
+			return
+		}
+		args = append(args, id)
+		where += " AND l." + param + " = $" + strconv.Itoa(len(args))
+	}
+
+	data, err := queryLots(lotSelect+where+" ORDER BY l.expiry_date, l.product_id, l.warehouse_id", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching lots: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Expiring lots fetched successfully")
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type LotListData struct {
+	ID          int64   `json:"id" example:"1"`
+	ProductID   int64   `json:"product_id" example:"1"`
+	ProductName string  `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU  string  `json:"product_sku" example:"SKU-20251214201530-042"`
+	WarehouseID int64   `json:"warehouse_id" example:"1"`
+	Warehouse   string  `json:"warehouse" example:"Main Warehouse"`
+	LotNumber   string  `json:"lot_number" example:"LOT-2025-11A"`
+	ExpiryDate  *string `json:"expiry_date" example:"2026-05-31"`
+	Quantity    int64   `json:"quantity" example:"40"`
+	DaysLeft    *int64  `json:"days_left" example:"21"` // negatif jika sudah kedaluwarsa
+	Expired     bool    `json:"expired" example:"false"`
+}
+
+type LotListSuccessResp struct {
+	Status  string        `json:"status" example:"success"`
+	Message string        `json:"message" example:"Lots fetched successfully"`
+	Data    []LotListData `json:"data"`
+}
+
+type LotListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch lots"`
+}
+
+const lotSelect = `
+	SELECT l.id, l.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), l.warehouse_id, COALESCE(wh.name, ''),
+		l.lot_number, TO_CHAR(l.expiry_date, 'YYYY-MM-DD'), l.quantity,
+		l.expiry_date - CURRENT_DATE, COALESCE(l.expiry_date < CURRENT_DATE, FALSE)
+	FROM stock_lots l
+	LEFT JOIN products p ON p.id = l.product_id
+	LEFT JOIN warehouses wh ON wh.id = l.warehouse_id
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLot(row rowScanner, l *LotListData) error {
+	return row.Scan(&l.ID, &l.ProductID, &l.ProductName, &l.ProductSKU, &l.WarehouseID, &l.Warehouse,
+		&l.LotNumber, &l.ExpiryDate, &l.Quantity, &l.DaysLeft, &l.Expired)
+}
+
+// GetLotList godoc
+// @Summary List stock lots
+// @Description List lot product lot-tracked beserta quantity dan tanggal kedaluwarsa, urut FEFO (kedaluwarsa terdekat dulu)
+// @Tags lots
+// @Produce json
+// @Param product_id query int false "Product ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param include_empty query bool false "Tampilkan juga lot yang sudah habis"
+// @Success 200 {object} services.LotListSuccessResp
+// @Failure 400 {object} services.LotListFailResp
+// @Failure 500 {object} services.LotListFailResp
+// @Router /stocklab-api/v1/lots [get]
+// @Security BearerAuth
+func GetLotList(w http.ResponseWriter, r *http.Request) {
+	var args []interface{}
+	conditions := []string{}
+
+	if r.URL.Query().Get("include_empty") != "true" {
+		conditions = append(conditions, "l.quantity > 0")
+	}
+	for _, param := range []string{"product_id", "warehouse_id"} {
+		val := r.URL.Query().Get(param)
+		if val == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, param+" must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "l."+param+" = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	data, err := queryLots(lotSelect+where+" ORDER BY l.product_id, l.warehouse_id, l.expiry_date ASC NULLS LAST, l.id", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching lots: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Lots fetched successfully")
+}
+
+func queryLots(query string, args ...interface{}) ([]LotListData, error) {
+	rows, err := db.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := []LotListData{}
+	for rows.Next() {
+		var l LotListData
+		if err := scanLot(rows, &l); err != nil {
+			return nil, err
+		}
+		data = append(data, l)
+	}
+
+	return data, rows.Err()
+}
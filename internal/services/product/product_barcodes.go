@@ -0,0 +1,276 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// Barcode GS1 yang terdaftar untuk product, per satuan
+type ProductBarcode struct {
+	Barcode string `json:"barcode" example:"8992761111113"`
+	Unit    string `json:"unit" example:"PCS"`
+	Factor  int64  `json:"factor" example:"1"` // jumlah satuan dasar per 1 scan
+}
+
+type ProductBarcodeRequest struct {
+	Barcode string `json:"barcode" example:"8992761111113"`
+	Unit    string `json:"unit" example:"CTN"` // kosong = satuan dasar
+}
+
+type ProductBarcodesUpdateRequest struct {
+	Barcodes []ProductBarcodeRequest `json:"barcodes"` // menggantikan semua barcode product
+}
+
+type ProductBarcodesSuccessResp struct {
+	Status  string           `json:"status" example:"success"`
+	Message string           `json:"message" example:"Product barcodes updated successfully"`
+	Data    []ProductBarcode `json:"data"`
+}
+
+type ProductBarcodesFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid barcode \"8992761111112\": check digit should be 3"`
+}
+
+// Hasil scan barcode: product, satuan yang diwakili barcode dan stoknya
+type ProductBarcodeLookup struct {
+	Barcode   string `json:"barcode" example:"8992761111113"`
+	ProductID int64  `json:"product_id" example:"1"`
+	SKU       string `json:"sku" example:"SKU-20251214201530-042"`
+	Name      string `json:"name" example:"Mie Sedap Goreng"`
+	Brand     string `json:"brand" example:"Mie Sedap"`
+	Unit      string `json:"unit" example:"CTN"`
+	Factor    int64  `json:"factor" example:"40"`
+	BaseUnit  string `json:"base_unit" example:"PCS"`
+	Quantity  int32  `json:"quantity" example:"150"` // on hand semua warehouse, satuan dasar
+	Reserved  int32  `json:"reserved" example:"30"`
+	Available int32  `json:"available" example:"120"`
+}
+
+type ProductBarcodeLookupSuccessResp struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Product fetched successfully"`
+	Data    ProductBarcodeLookup `json:"data"`
+}
+
+// UpdateProductBarcodes godoc
+// @Summary Set product barcodes
+// @Description Atur barcode GS1 (EAN-8, UPC-A, EAN-13, GTIN-14) product. Check digit divalidasi; unit barcode harus satuan dasar atau satuan alternatif product. Barcode yang tidak dikirim dihapus.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param body body services.ProductBarcodesUpdateRequest true "Barcodes"
+// @Success 200 {object} services.ProductBarcodesSuccessResp
+// @Failure 400 {object} services.ProductBarcodesFailResp
+// @Failure 404 {object} services.ProductBarcodesFailResp
+// @Failure 409 {object} services.ProductBarcodesFailResp
+// @Failure 500 {object} services.ProductBarcodesFailResp
+// @Router /stocklab-api/v1/products/barcodes/{id} [put]
+// @Security BearerAuth
+func UpdateProductBarcodes(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req ProductBarcodesUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var isParent bool
+	err = tx.QueryRow(`SELECT is_parent FROM products WHERE id = $1 FOR UPDATE`, productID).Scan(&isParent)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "product not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if isParent && len(req.Barcodes) > 0 {
+		utils.RespondError(w, http.StatusBadRequest, "Barcodes belong to variants, not to the parent product")
+		return
+	}
+
+	if _, err := tx.Exec(`DELETE FROM product_barcodes WHERE product_id = $1`, productID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update product barcodes: "+err.Error())
+		return
+	}
+	if status, err := saveBarcodes(tx, productID, req.Barcodes); err != nil {
+		utils.RespondError(w, status, err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	barcodes, err := loadProductBarcodes(productID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if barcodes[productID] == nil {
+		barcodes[productID] = []ProductBarcode{}
+	}
+
+	utils.RespondSuccess(w, barcodes[productID], "Product barcodes updated successfully")
+}
+
+// GetProductByBarcode godoc
+// @Summary Lookup product by barcode
+// @Description Resolve barcode hasil scan menjadi product dan satuan yang diwakilinya. UPC-A 12 digit dan EAN-13 dengan awalan 0 dianggap sama.
+// @Tags products
+// @Produce json
+// @Param code path string true "Barcode"
+// @Success 200 {object} services.ProductBarcodeLookupSuccessResp
+// @Failure 400 {object} services.ProductBarcodesFailResp
+// @Failure 404 {object} services.ProductBarcodesFailResp
+// @Failure 500 {object} services.ProductBarcodesFailResp
+// @Router /stocklab-api/v1/products/by-barcode/{code} [get]
+// @Security BearerAuth
+func GetProductByBarcode(w http.ResponseWriter, r *http.Request) {
+	code := strings.TrimSpace(chi.URLParam(r, "code"))
+	if err := stock.ValidBarcode(code); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var l ProductBarcodeLookup
+	err := db.DB.QueryRow(`
+		SELECT pb.barcode, p.id, p.sku, p.brand, p.name,
+			COALESCE(u.code, bu.code, ''), COALESCE(pu.factor, 1), COALESCE(bu.code, ''),
+			COALESCE((SELECT SUM(s.quantity) FROM stocks s WHERE s.product_id = p.id), 0),
+			COALESCE((SELECT SUM(s.reserved_quantity) FROM stocks s WHERE s.product_id = p.id), 0)
+		FROM product_barcodes pb
+		JOIN products p ON p.id = pb.product_id
+		LEFT JOIN units bu ON bu.id = p.base_unit_id
+		LEFT JOIN units u ON u.id = pb.unit_id
+		LEFT JOIN product_units pu ON pu.product_id = p.id AND pu.unit_id = pb.unit_id
+		WHERE pb.barcode = ANY($1::text[])
+		LIMIT 1
+	`, pq.Array(stock.BarcodeVariants(code))).Scan(
+		&l.Barcode, &l.ProductID, &l.SKU, &l.Brand, &l.Name,
+		&l.Unit, &l.Factor, &l.BaseUnit, &l.Quantity, &l.Reserved,
+	)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "barcode not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	l.Available = l.Quantity - l.Reserved
+
+	utils.RespondSuccess(w, l, "Product fetched successfully")
+}
+
+// saveBarcodes memvalidasi dan menyimpan barcode product,
+// mengembalikan HTTP status yang sesuai jika gagal
+func saveBarcodes(tx *sql.Tx, productID int64, barcodes []ProductBarcodeRequest) (int, error) {
+	for _, b := range barcodes {
+		code := strings.TrimSpace(b.Barcode)
+		if err := stock.ValidBarcode(code); err != nil {
+			return http.StatusBadRequest, err
+		}
+
+		var unitID sql.NullInt64
+		if unit := strings.ToUpper(strings.TrimSpace(b.Unit)); unit != "" {
+			// Unit harus satuan dasar (disimpan NULL) atau satuan alternatif product
+			var isBase, configured bool
+			var id int64
+			err := tx.QueryRow(`
+				SELECT u.id, p.base_unit_id = u.id, pu.unit_id IS NOT NULL
+				FROM units u
+				JOIN products p ON p.id = $1
+				LEFT JOIN product_units pu ON pu.product_id = p.id AND pu.unit_id = u.id
+				WHERE UPPER(u.code) = $2
+			`, productID, unit).Scan(&id, &isBase, &configured)
+			if err == sql.ErrNoRows {
+				return http.StatusBadRequest, fmt.Errorf("%w: %s", stock.ErrUnitNotFound, unit)
+			} else if err != nil {
+				return http.StatusInternalServerError, err
+			}
+			if !isBase && !configured {
+				return http.StatusBadRequest, fmt.Errorf("%w: %s is not configured for product %d", stock.ErrUnitNotAllowed, unit, productID)
+			}
+			if !isBase {
+				unitID = sql.NullInt64{Int64: id, Valid: true}
+			}
+		}
+
+		// UPC-A dan EAN-13 berawalan 0 adalah barcode yang sama
+		var taken bool
+		err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM product_barcodes WHERE barcode = ANY($1::text[]))`,
+			pq.Array(stock.BarcodeVariants(code))).Scan(&taken)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if taken {
+			return http.StatusConflict, fmt.Errorf("Barcode %s is already registered", code)
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO product_barcodes (product_id, unit_id, barcode) VALUES ($1, $2, $3)
+		`, productID, unitID, code); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return http.StatusConflict, fmt.Errorf("Barcode %s is already registered", code)
+			}
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// loadProductBarcodes mengambil barcode, dikelompokkan per product.
+// productID 0 berarti semua product.
+func loadProductBarcodes(productID int64) (map[int64][]ProductBarcode, error) {
+	rows, err := db.DB.Query(`
+		SELECT pb.product_id, pb.barcode, COALESCE(u.code, bu.code, ''), COALESCE(pu.factor, 1)
+		FROM product_barcodes pb
+		JOIN products p ON p.id = pb.product_id
+		LEFT JOIN units bu ON bu.id = p.base_unit_id
+		LEFT JOIN units u ON u.id = pb.unit_id
+		LEFT JOIN product_units pu ON pu.product_id = p.id AND pu.unit_id = pb.unit_id
+		WHERE $1 = 0 OR pb.product_id = $1
+		ORDER BY pb.product_id, pb.id
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int64][]ProductBarcode{}
+	for rows.Next() {
+		var pid int64
+		var b ProductBarcode
+		if err := rows.Scan(&pid, &b.Barcode, &b.Unit, &b.Factor); err != nil {
+			return nil, err
+		}
+		result[pid] = append(result[pid], b)
+	}
+
+	return result, rows.Err()
+}
@@ -1,27 +1,48 @@
 package services
 
 import (
+	"database/sql"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"math/rand"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/sku"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/lib/pq"
 )
 
 // Product blueprint
 type ProductCreateData struct {
-	ID         int64  `json:"id" example:"1"`
-	Name       string `json:"name" example:"Mie Sedap Goreng"`
-	CategoryId int    `json:"category_id" example:"1"`
-	SKU        string `json:"sku" example:"SKU-20251214201530-042"`
-	Brand      string `json:"brand" example:"Mie Sedap"`
-	Price      string `json:"price" example:"10000"`
-	Image      string `json:"image" form:"image" example:"base64imagestring"`
+	ID           int64        `json:"id" example:"1"`
+	Name         string       `json:"name" example:"Mie Sedap Goreng"`
+	CategoryId   int          `json:"category_id" example:"1"`
+	SKU          string       `json:"sku" example:"SKU-000042"`
+	Brand        string       `json:"brand" example:"Mie Sedap"`
+	Price        money.Amount `json:"price" swaggertype:"number" example:"10000.00"`
+	Currency     string       `json:"currency" example:"IDR"`
+	Costing      string       `json:"costing_method" example:"FIFO"`
+	ReorderPoint int          `json:"reorder_point" example:"50"`
+	SafetyStock  int          `json:"safety_stock" example:"20"`
+	MaxLevel     *int         `json:"max_level" example:"200"`
+	IsLotTracked bool         `json:"is_lot_tracked" example:"true"`
+	IsSerialized bool         `json:"is_serialized" example:"false"`
+	BaseUnit     string       `json:"base_unit" example:"PCS"`
+	IsParent     bool         `json:"is_parent" example:"false"`
+	IsKit        bool         `json:"is_kit" example:"false"`
+	Image        string       `json:"image" form:"image" example:"base64imagestring"`
+
+	Variants   []ProductVariant      `json:"variants,omitempty"`   // hanya jika variants dikirim
+	Components []ProductKitComponent `json:"components,omitempty"` // hanya jika components dikirim
+	Barcodes   []ProductBarcode      `json:"barcodes,omitempty"`   // hanya jika barcodes dikirim
 }
 
 type ProductCreateSuccessResp struct {
@@ -37,14 +58,26 @@ type ProductCreateFailResp struct {
 
 // CreateCategory godoc
 // @Summary Create product for products
-// @Description Create a product
+// @Description Create a product. Jika variants dikirim, product dibuat sebagai parent tanpa stok dan setiap kombinasi atribut dibuat sebagai varian dengan SKU <sku parent>-01, -02, ... serta stok sendiri di setiap warehouse. Varian mewarisi harga, ambang stok, tracking dan satuan dasar parent; harga varian bisa diubah lewat update product. Jika components dikirim, product dibuat sebagai kit tanpa stok sendiri; availability kit dihitung dari stok komponennya.
 // @Tags products
 // @Accept multipart/form-data
 // @Produce json
 // @Param name formData string true "name"
 // @Param category_id formData int true "category_id"
 // @Param brand formData string true "brand"
-// @Param price formData string true "price"
+// @Param price formData string true "price: desimal maksimal 2 digit tanpa pemisah ribuan, mis. 10000 atau 10000.50"
+// @Param currency formData string false "currency: kode ISO 4217 (default IDR)"
+// @Param costing_method formData string false "costing_method: FIFO atau AVG (moving weighted average), default FIFO"
+// @Param sku formData string false "sku: kosong = dibuat otomatis dari pola SKU category (atau pola default)"
+// @Param reorder_point formData int false "reorder_point (default 0)"
+// @Param safety_stock formData int false "safety_stock (default 0)"
+// @Param max_level formData int false "max_level"
+// @Param is_lot_tracked formData bool false "is_lot_tracked: stok dicatat per lot dengan tanggal kedaluwarsa (default false)"
+// @Param base_unit formData string false "base_unit: unit code stok disimpan (default PCS)"
+// @Param is_serialized formData bool false "is_serialized: stok dicatat per unit dengan nomor seri (default false)"
+// @Param variants formData string false "variants: JSON atribut ke daftar nilai, mis. {\"flavour\": [\"Goreng\", \"Soto\"], \"size\": [\"75g\"]}"
+// @Param components formData string false "components: JSON bill of materials kit, mis. [{\"product_id\": 3, \"quantity\": 2}]"
+// @Param barcodes formData string false "barcodes: barcode GS1 satuan dasar, dipisah koma"
 // @Param image formData file true "Product image"
 // @Success 200 {object} services.ProductCreateData
 // @Failure 400 {object} services.ProductCreateFailResp
@@ -52,6 +85,12 @@ type ProductCreateFailResp struct {
 // @Router /stocklab-api/v1/products/create [post]
 // @Security BearerAuth
 func CreateProduct(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	// Parse multipart form (max 10MB)
 	if err := r.ParseMultipartForm(10 << 20); err != nil {
 		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
@@ -61,7 +100,7 @@ func CreateProduct(w http.ResponseWriter, r *http.Request) {
 	name := r.FormValue("name")
 	catId := r.FormValue("category_id")
 	brand := r.FormValue("brand")
-	price := r.FormValue("price")
+	customSKU := strings.TrimSpace(r.FormValue("sku"))
 
 	categoryId, err := strconv.Atoi(catId)
 	if err != nil {
@@ -69,6 +108,125 @@ func CreateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	price, err := money.Parse(r.FormValue("price"))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Mata uang OPTIONAL, default IDR
+	currency := strings.ToUpper(strings.TrimSpace(r.FormValue("currency")))
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	if err := money.ValidCurrency(currency); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Metode costing OPTIONAL, default FIFO
+	costing := strings.ToUpper(strings.TrimSpace(r.FormValue("costing_method")))
+	if costing == "" {
+		costing = stock.CostFIFO
+	}
+	if err := stock.ValidCostingMethod(costing); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// SKU OPTIONAL, divalidasi jika diisi manual
+	if customSKU != "" {
+		if err := sku.Validate(customSKU); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Ambang stok OPTIONAL, default tanpa reorder point
+	levels, err := parseLevelsForm(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	zero := 0
+	levels = levels.merge(levelsForm{ReorderPoint: &zero, SafetyStock: &zero})
+	if err := stock.ValidateLevels(*levels.ReorderPoint, *levels.SafetyStock, levels.MaxLevel); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Lot tracking OPTIONAL, default tidak
+	lotTracked := false
+	if val := r.FormValue("is_lot_tracked"); val != "" {
+		lotTracked, err = strconv.ParseBool(val)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "is_lot_tracked must be true or false")
+			return
+		}
+	}
+
+	// Serial tracking OPTIONAL, default tidak
+	serialized := false
+	if val := r.FormValue("is_serialized"); val != "" {
+		serialized, err = strconv.ParseBool(val)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "is_serialized must be true or false")
+			return
+		}
+	}
+
+	// Satuan dasar OPTIONAL, default PCS
+	baseUnit := strings.ToUpper(strings.TrimSpace(r.FormValue("base_unit")))
+	if baseUnit == "" {
+		baseUnit = defaultBaseUnit
+	}
+	baseUnitID, err := findUnitID(db.DB, baseUnit)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusBadRequest, "Unit "+baseUnit+" not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+
+	// Matriks varian OPTIONAL
+	var combos []map[string]string
+	var axes []string
+	if raw := strings.TrimSpace(r.FormValue("variants")); raw != "" {
+		combos, axes, err = parseVariantMatrix(raw)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	isParent := len(combos) > 0
+
+	// Bill of materials kit OPTIONAL
+	var components []KitComponentRequest
+	if raw := strings.TrimSpace(r.FormValue("components")); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &components); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "components must be a JSON array of {\"product_id\", \"quantity\"}")
+			return
+		}
+	}
+	isKit := len(components) > 0
+	if isKit && (isParent || lotTracked || serialized) {
+		utils.RespondError(w, http.StatusBadRequest, "A kit cannot have variants, lot tracking or serial tracking")
+		return
+	}
+
+	// Barcode satuan dasar OPTIONAL, dipisah koma
+	var barcodes []ProductBarcodeRequest
+	for _, code := range strings.Split(r.FormValue("barcodes"), ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			barcodes = append(barcodes, ProductBarcodeRequest{Barcode: code})
+		}
+	}
+	if len(barcodes) > 0 && isParent {
+		utils.RespondError(w, http.StatusBadRequest, "Barcodes belong to variants, not to the parent product")
+		return
+	}
+
 	// Ambil file image
 	file, _, err := r.FormFile("image")
 	if err != nil {
@@ -83,43 +241,160 @@ func CreateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sku := GenerateSKU()
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	// SKU manual harus unik, tanpa SKU dibuat dari pola
+	productSKU := customSKU
+	if productSKU != "" {
+		taken, err := sku.Exists(tx, productSKU, 0)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+			return
+		}
+		if taken {
+			utils.RespondError(w, http.StatusConflict, "SKU "+productSKU+" already exists")
+			return
+		}
+	} else {
+		productSKU, err = sku.Generate(tx, int64(categoryId), brand)
+		if err != nil {
+			utils.RespondError(w, sku.StatusCode(err), "Failed to generate SKU: "+err.Error())
+			return
+		}
+	}
 
 	// Insert product ke database
 	var productId int64
-	query := `INSERT INTO products (name, category_id, sku, brand, price, image) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
-	err = db.DB.QueryRow(query, name, categoryId, sku, brand, price, imageBytes).Scan(&productId)
+	query := `INSERT INTO products (name, category_id, sku, brand, price, currency, image, reorder_point, safety_stock, max_level, is_lot_tracked, is_serialized, base_unit_id, is_parent, is_kit, costing_method) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) RETURNING id`
+	err = tx.QueryRow(query, name, categoryId, productSKU, brand, price, currency, imageBytes, *levels.ReorderPoint, *levels.SafetyStock, levels.MaxLevel, lotTracked, serialized, baseUnitID, isParent, isKit, costing).Scan(&productId)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "SKU "+productSKU+" already exists")
+			return
+		}
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to create product: "+err.Error())
 		return
 	}
 
-	// insert stock product
-	stockQuery := `INSERT INTO stocks (product_id, quantity) VALUES ($1, $2)`
-	_, err = db.DB.Exec(stockQuery, productId, 0)
+	if err := recordPrice(tx, productId, price, currency, principal.UserID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to record price: "+err.Error())
+		return
+	}
 
-	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, "Failed to create stock: "+err.Error())
+	// insert stock product di setiap warehouse, parent dan kit tidak punya stok sendiri
+	stockQuery := `INSERT INTO stocks (product_id, warehouse_id, quantity) SELECT $1, id, $2 FROM warehouses`
+	if !isParent && !isKit {
+		if _, err = tx.Exec(stockQuery, productId, 0); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create stock: "+err.Error())
+			return
+		}
+	}
+
+	if isKit {
+		if err := saveKitComponents(tx, productId, components); err != nil {
+			if errors.Is(err, errInvalidKit) {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create kit: "+err.Error())
+			return
+		}
+	}
+
+	if status, err := saveBarcodes(tx, productId, barcodes); err != nil {
+		utils.RespondError(w, status, err.Error())
+		return
+	}
+
+	// Buat setiap kombinasi atribut sebagai varian
+	variants := []ProductVariant{}
+	for i, attrs := range combos {
+		attrJSON, err := json.Marshal(attrs)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		v := ProductVariant{
+			SKU:        fmt.Sprintf("%s-%02d", productSKU, i+1),
+			Name:       variantName(name, axes, attrs),
+			Attributes: attrs,
+			Price:      price,
+			Currency:   currency,
+		}
+		err = tx.QueryRow(`
+			INSERT INTO products (name, category_id, sku, brand, price, currency, image, reorder_point, safety_stock, max_level, is_lot_tracked, is_serialized, base_unit_id, parent_id, variant_attributes, costing_method)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			RETURNING id
+		`, v.Name, categoryId, v.SKU, brand, price, currency, imageBytes, *levels.ReorderPoint, *levels.SafetyStock, levels.MaxLevel, lotTracked, serialized, baseUnitID, productId, attrJSON, costing).Scan(&v.ID)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				utils.RespondError(w, http.StatusConflict, "SKU "+v.SKU+" already exists")
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create variant: "+err.Error())
+			return
+		}
+		if err := recordPrice(tx, v.ID, price, currency, principal.UserID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to record price: "+err.Error())
+			return
+		}
+		if _, err = tx.Exec(stockQuery, v.ID, 0); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create stock: "+err.Error())
+			return
+		}
+		variants = append(variants, v)
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
 		return
 	}
 
 	// Response sukses
 	response := ProductCreateData{
-		ID:         productId,
-		Name:       name,
-		CategoryId: categoryId,
-		SKU:        sku,
-		Brand:      brand,
-		Price:      price,
-		Image:      base64.StdEncoding.EncodeToString(imageBytes),
+		ID:           productId,
+		Name:         name,
+		CategoryId:   categoryId,
+		SKU:          productSKU,
+		Brand:        brand,
+		Price:        price,
+		Currency:     currency,
+		Costing:      costing,
+		ReorderPoint: *levels.ReorderPoint,
+		SafetyStock:  *levels.SafetyStock,
+		MaxLevel:     levels.MaxLevel,
+		IsLotTracked: lotTracked,
+		IsSerialized: serialized,
+		BaseUnit:     baseUnit,
+		IsParent:     isParent,
+		IsKit:        isKit,
+		Image:        base64.StdEncoding.EncodeToString(imageBytes),
+	}
+	if isParent {
+		response.Variants = variants
+	}
+	if isKit {
+		kits, err := loadKitComponents(productId)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.Components = kits[productId]
+	}
+	if len(barcodes) > 0 {
+		codes, err := loadProductBarcodes(productId)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.Barcodes = codes[productId]
 	}
 
 	utils.RespondSuccess(w, response, "Product created successfully")
 }
-
-// Simple generate SKU
-func GenerateSKU() string {
-	ts := time.Now().UTC().Format("20060102150405") // yyyyMMddHHmmss
-	randPart := rand.Intn(1000)                     // 000–999
-	return fmt.Sprintf("SKU-%s-%03d", ts, randPart)
-}
@@ -21,13 +21,15 @@ type ProductDeleteFailResp struct {
 
 // DeleteProduct godoc
 // @Summary Delete product
-// @Description Delete product by ID
+// @Description Delete product by ID beserta baris stok kosong, barcode, harga, satuan dan komponen kit-nya dalam satu transaksi. Hanya product tanpa riwayat yang bisa dihapus: product yang masih punya stok, transaksi, cost layer, purchase / sales order line, stock count line atau assembly order ditolak (409). Parent product hanya bisa dihapus setelah semua variannya dihapus; komponen kit dan product yang dipakai di bill of materials tidak bisa dihapus.
 // @Tags products
 // @Produce json
 // @Param id path int true "Product ID"
 // @Success 200 {object} services.ProductDeleteSuccessResp
 // @Failure 400 {object} services.ProductDeleteFailResp
 // @Failure 404 {object} services.ProductDeleteFailResp
+// @Failure 403 {object} services.ProductDeleteFailResp
+// @Failure 409 {object} services.ProductDeleteFailResp
 // @Failure 500 {object} services.ProductDeleteFailResp
 // @Router /stocklab-api/v1/products/delete/{id} [delete]
 // @Security BearerAuth
@@ -40,14 +42,16 @@ func DeleteProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	query := `
-		DELETE FROM products
-		WHERE id = $1
-		RETURNING id
-	`
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
 
-	var deletedID int64
-	err = db.DB.QueryRow(query, productID).Scan(&deletedID)
+	// Kunci product supaya tidak ada movement baru selama dihapus
+	var lockedID int64
+	err = tx.QueryRow(`SELECT id FROM products WHERE id = $1 FOR UPDATE`, productID).Scan(&lockedID)
 	if err != nil {
 		// Jika ID tidak ditemukan
 		if err.Error() == "sql: no rows in result set" {
@@ -59,6 +63,102 @@ func DeleteProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Varian akan kehilangan parent-nya jika parent dihapus lebih dulu
+	var variantCount int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM products WHERE parent_id = $1`, productID).Scan(&variantCount)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if variantCount > 0 {
+		utils.RespondError(w, http.StatusConflict, "product still has "+strconv.Itoa(variantCount)+" variant(s)")
+		return
+	}
+
+	// Kit yang memakai product ini akan kehilangan komponennya
+	var kitCount int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM kit_components WHERE component_id = $1`, productID).Scan(&kitCount)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if kitCount > 0 {
+		utils.RespondError(w, http.StatusConflict, "product is a component of "+strconv.Itoa(kitCount)+" kit(s)")
+		return
+	}
+
+	// Bill of materials assembly yang memakai product ini sebagai hasil atau komponen
+	var bomCount int
+	err = tx.QueryRow(`
+		SELECT COUNT(DISTINCT b.id) FROM boms b
+		LEFT JOIN bom_lines l ON l.bom_id = b.id
+		WHERE b.product_id = $1 OR l.component_id = $1
+	`, productID).Scan(&bomCount)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if bomCount > 0 {
+		utils.RespondError(w, http.StatusConflict, "product is used in "+strconv.Itoa(bomCount)+" bill(s) of materials")
+		return
+	}
+
+	// Stok yang masih ada akan hilang dari laporan jika product dihapus
+	var onHand, reserved int64
+	err = tx.QueryRow(`
+		SELECT COALESCE(SUM(quantity), 0), COALESCE(SUM(reserved_quantity), 0) FROM stocks WHERE product_id = $1
+	`, productID).Scan(&onHand, &reserved)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if onHand != 0 || reserved != 0 {
+		utils.RespondError(w, http.StatusConflict, "product still has stock, adjust it to zero first")
+		return
+	}
+
+	// Ledger, cost layer dan dokumen yang mereferensikan product adalah riwayat, tidak boleh ikut terhapus
+	var hasHistory bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM transactions WHERE product_id = $1)
+			OR EXISTS (SELECT 1 FROM cost_layers WHERE product_id = $1)
+			OR EXISTS (SELECT 1 FROM purchase_order_lines WHERE product_id = $1)
+			OR EXISTS (SELECT 1 FROM sales_order_lines WHERE product_id = $1)
+			OR EXISTS (SELECT 1 FROM stock_count_lines WHERE product_id = $1)
+			OR EXISTS (SELECT 1 FROM assembly_orders WHERE product_id = $1)
+			OR EXISTS (SELECT 1 FROM assembly_order_lines WHERE component_id = $1)
+			OR EXISTS (SELECT 1 FROM reconciliation_drifts WHERE product_id = $1)
+	`, productID).Scan(&hasHistory)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if hasHistory {
+		utils.RespondError(w, http.StatusConflict, "Cannot delete product, it has transaction, order or stock count history")
+		return
+	}
+
+	// Product tanpa riwayat: data turunannya dihapus lebih dulu, product paling akhir, dalam satu transaksi
+	dependents := []string{
+		`DELETE FROM stocks WHERE product_id = $1`,
+		`DELETE FROM kit_components WHERE kit_id = $1`,
+		`DELETE FROM product_barcodes WHERE product_id = $1`,
+		`DELETE FROM product_prices WHERE product_id = $1`,
+		`DELETE FROM product_units WHERE product_id = $1`,
+		`DELETE FROM products WHERE id = $1`,
+	}
+	for _, query := range dependents {
+		if _, err := tx.Exec(query, productID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to delete product: "+err.Error())
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
 	// Response sukses
 	response := map[string]interface{}{
 		"id": productID,
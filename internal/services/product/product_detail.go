@@ -2,24 +2,64 @@ package services
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 	"github.com/go-chi/chi/v5"
 )
 
 // Product Detail blueprint
 type ProductDetail struct {
-	ID       int64  `json:"id" example:"1"`
-	Name     string `json:"name" example:"Mie Sedap Goreng"`
-	Category string `json:"category" example:"Mie"`
-	SKU      string `json:"sku" example:"SKU-20251214201530-042"`
-	Brand    string `json:"brand" example:"Mie Sedap"`
-	Price    string `json:"price" example:"10000"`
-	Quantity int32  `json:"quantity" example:"150"`
-	Image    string `json:"image" example:"base64imagestring"`
+	ID           int64            `json:"id" example:"1"`
+	Name         string           `json:"name" example:"Mie Sedap Goreng"`
+	Category     string           `json:"category" example:"Mie"`
+	SKU          string           `json:"sku" example:"SKU-20251214201530-042"`
+	Brand        string           `json:"brand" example:"Mie Sedap"`
+	Price        money.Amount     `json:"price" swaggertype:"number" example:"10000.00"`
+	Currency     string           `json:"currency" example:"IDR"`
+	Costing      string           `json:"costing_method" example:"FIFO"`
+	StockValue   money.Amount     `json:"stock_value" swaggertype:"number" example:"450000.00"` // nilai stok saat ini dari layer biaya
+	Quantity     int32            `json:"quantity" example:"150"`                               // on hand
+	Reserved     int32            `json:"reserved" example:"30"`
+	Available    int32            `json:"available" example:"120"`
+	ReorderPoint int              `json:"reorder_point" example:"50"`
+	SafetyStock  int              `json:"safety_stock" example:"20"`
+	MaxLevel     *int             `json:"max_level" example:"200"`
+	IsLotTracked bool             `json:"is_lot_tracked" example:"true"`
+	IsSerialized bool             `json:"is_serialized" example:"false"`
+	BaseUnit     string           `json:"base_unit" example:"PCS"` // satuan semua quantity stok
+	Units        []ProductUnit    `json:"units"`
+	Barcodes     []ProductBarcode `json:"barcodes"`
+	InUnit       *UnitQuantity    `json:"in_unit,omitempty"` // hanya jika query unit diisi
+	Stocks       []ProductStock   `json:"stocks"`
+	Image        string           `json:"image" example:"base64imagestring"`
+
+	ParentID          *int64            `json:"parent_id,omitempty" example:"10"` // hanya untuk varian
+	IsParent          bool              `json:"is_parent" example:"false"`        // stok parent adalah total stok variannya
+	VariantAttributes map[string]string `json:"variant_attributes,omitempty"`
+	Variants          []ProductVariant  `json:"variants,omitempty"` // hanya untuk parent
+
+	IsKit      bool                  `json:"is_kit" example:"false"`
+	Components []ProductKitComponent `json:"components,omitempty"` // hanya untuk kit
+}
+
+// Stok product di satu warehouse beserta ambang yang berlaku di lokasi tersebut
+type ProductStock struct {
+	WarehouseID   int64         `json:"warehouse_id" example:"1"`
+	WarehouseCode string        `json:"warehouse_code" example:"MAIN"`
+	WarehouseName string        `json:"warehouse_name" example:"Main Warehouse"`
+	Quantity      int32         `json:"quantity" example:"50"` // on hand
+	Reserved      int32         `json:"reserved" example:"10"`
+	Available     int32         `json:"available" example:"40"`
+	ReorderPoint  int           `json:"reorder_point" example:"50"`
+	SafetyStock   int           `json:"safety_stock" example:"20"`
+	MaxLevel      *int          `json:"max_level" example:"200"`
+	InUnit        *UnitQuantity `json:"in_unit,omitempty"`
 }
 
 type ProductDetailSuccessResp struct {
@@ -35,11 +75,12 @@ type ProductDetailFailResp struct {
 
 // GetProductDetail godoc
 // @Summary Product detail
-// @Description Menampilkan detail product berdasarkan ID
+// @Description Menampilkan detail product berdasarkan ID, termasuk total stok (on hand, reserved, available) dan stok per warehouse. Untuk parent product stok adalah total semua varian dan daftar varian beserta stoknya ikut ditampilkan. Untuk kit stok adalah jumlah kit yang bisa dirakit dari stok komponen dan daftar komponen ikut ditampilkan.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path int true "Product ID"
+// @Param unit query string false "Also show stock in this unit (e.g. CTN)"
 // @Success 200 {object} services.ProductDetailSuccessResp
 // @Failure 400 {object} services.ProductDetailFailResp
 // @Failure 404 {object} services.ProductDetailFailResp
@@ -61,18 +102,39 @@ func GetProductDetail(w http.ResponseWriter, r *http.Request) {
 			p.name,
 			p.sku,
 			p.brand,
-			COALESCE(p.price, '0') as price,
+			COALESCE(p.price, 0) as price,
+			p.currency,
+			p.costing_method,
+			COALESCE((SELECT SUM(cl.remaining_value) FROM cost_layers cl WHERE cl.product_id = p.id), 0),
 			COALESCE(c.name, 'N/A') AS category,
-			COALESCE(s.quantity, 0) as quantity,
-			p.image
+			COALESCE(
+				(SELECT SUM(s.quantity) FROM stocks s JOIN products sp ON sp.id = s.product_id WHERE sp.id = p.id OR sp.parent_id = p.id),
+				(SELECT SUM(kw.quantity) FROM (` + kitWarehouseStockSQL + `) kw WHERE kw.kit_id = p.id),
+				0) as quantity,
+			COALESCE(
+				(SELECT SUM(s.reserved_quantity) FROM stocks s JOIN products sp ON sp.id = s.product_id WHERE sp.id = p.id OR sp.parent_id = p.id),
+				(SELECT SUM(kw.quantity - kw.available) FROM (` + kitWarehouseStockSQL + `) kw WHERE kw.kit_id = p.id),
+				0) as reserved,
+			p.reorder_point,
+			p.safety_stock,
+			p.max_level,
+			p.is_lot_tracked,
+			p.is_serialized,
+			COALESCE(u.code, ''),
+			p.image,
+			p.parent_id,
+			p.is_parent,
+			p.variant_attributes,
+			p.is_kit
 		FROM products p
 		LEFT JOIN categories c ON c.id = p.category_id
-		LEFT JOIN stocks s ON s.product_id = p.id
+		LEFT JOIN units u ON u.id = p.base_unit_id
 		WHERE p.id = $1
 	`
 
 	var (
 		imageBytes []byte
+		attrs      []byte
 		product    ProductDetail
 	)
 
@@ -82,9 +144,23 @@ func GetProductDetail(w http.ResponseWriter, r *http.Request) {
 		&product.SKU,
 		&product.Brand,
 		&product.Price,
+		&product.Currency,
+		&product.Costing,
+		&product.StockValue,
 		&product.Category,
 		&product.Quantity,
+		&product.Reserved,
+		&product.ReorderPoint,
+		&product.SafetyStock,
+		&product.MaxLevel,
+		&product.IsLotTracked,
+		&product.IsSerialized,
+		&product.BaseUnit,
 		&imageBytes,
+		&product.ParentID,
+		&product.IsParent,
+		&attrs,
+		&product.IsKit,
 	)
 
 	if err != nil {
@@ -96,6 +172,15 @@ func GetProductDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	product.Available = product.Quantity - product.Reserved
+
+	if attrs != nil {
+		if err := json.Unmarshal(attrs, &product.VariantAttributes); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
 	// Convert image ke base64 jika ada
 	if imageBytes != nil {
 		product.Image = base64.StdEncoding.EncodeToString(imageBytes)
@@ -103,5 +188,123 @@ func GetProductDetail(w http.ResponseWriter, r *http.Request) {
 		product.Image = ""
 	}
 
+	stocks, err := loadProductStocks(productID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	product.Stocks = stocks[productID]
+	if product.Stocks == nil {
+		product.Stocks = []ProductStock{}
+	}
+
+	if product.IsParent {
+		variants, err := loadVariants(productID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		product.Variants = variants[productID]
+		if product.Variants == nil {
+			product.Variants = []ProductVariant{}
+		}
+	}
+
+	if product.IsKit {
+		components, err := loadKitComponents(productID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		product.Components = components[productID]
+		if product.Components == nil {
+			product.Components = []ProductKitComponent{}
+		}
+	}
+
+	units, err := loadProductUnits(productID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	product.Units = units[productID]
+	if product.Units == nil {
+		product.Units = []ProductUnit{}
+	}
+
+	barcodes, err := loadProductBarcodes(productID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	product.Barcodes = barcodes[productID]
+	if product.Barcodes == nil {
+		product.Barcodes = []ProductBarcode{}
+	}
+
+	// Konversi ke satuan yang diminta
+	unit := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("unit")))
+	if unit != "" {
+		product.InUnit = inUnit(product.Units, unit, product.Quantity, product.Reserved)
+		if product.InUnit == nil {
+			utils.RespondError(w, http.StatusBadRequest, "Unit "+unit+" is not configured for this product")
+			return
+		}
+		for i := range product.Stocks {
+			product.Stocks[i].InUnit = inUnit(product.Units, unit, product.Stocks[i].Quantity, product.Stocks[i].Reserved)
+		}
+	}
+
 	utils.RespondSuccess(w, product, "Product fetched successfully")
 }
+
+// loadProductStocks mengambil stok per warehouse, dikelompokkan per product.
+// Parent product mendapat total stok variannya per warehouse dengan ambang milik parent,
+// kit mendapat jumlah yang bisa dirakit dari stok komponen di warehouse tersebut.
+// productID 0 berarti semua product.
+func loadProductStocks(productID int64) (map[int64][]ProductStock, error) {
+	rows, err := db.DB.Query(`
+		SELECT s.product_id, w.id, w.code, w.name, s.quantity, s.reserved_quantity,
+			COALESCE(s.reorder_point, p.reorder_point),
+			COALESCE(s.safety_stock, p.safety_stock),
+			COALESCE(s.max_level, p.max_level)
+		FROM stocks s
+		JOIN warehouses w ON w.id = s.warehouse_id
+		JOIN products p ON p.id = s.product_id
+		WHERE $1 = 0 OR s.product_id = $1
+		UNION ALL
+		SELECT o.id, w.id, w.code, w.name, SUM(s.quantity), SUM(s.reserved_quantity),
+			o.reorder_point, o.safety_stock, o.max_level
+		FROM stocks s
+		JOIN warehouses w ON w.id = s.warehouse_id
+		JOIN products p ON p.id = s.product_id
+		JOIN products o ON o.id = p.parent_id
+		WHERE $1 = 0 OR o.id = $1
+		GROUP BY o.id, w.id
+		UNION ALL
+		SELECT kw.kit_id, w.id, w.code, w.name, kw.quantity, kw.quantity - kw.available,
+			p.reorder_point, p.safety_stock, p.max_level
+		FROM (`+kitWarehouseStockSQL+`) kw
+		JOIN warehouses w ON w.id = kw.warehouse_id
+		JOIN products p ON p.id = kw.kit_id
+		WHERE $1 = 0 OR kw.kit_id = $1
+		ORDER BY 1, 2
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int64][]ProductStock{}
+	for rows.Next() {
+		var pid int64
+		var ps ProductStock
+		if err := rows.Scan(&pid, &ps.WarehouseID, &ps.WarehouseCode, &ps.WarehouseName, &ps.Quantity, &ps.Reserved, &ps.ReorderPoint, &ps.SafetyStock, &ps.MaxLevel); err != nil {
+			return nil, err
+		}
+		ps.Available = ps.Quantity - ps.Reserved
+		result[pid] = append(result[pid], ps)
+	}
+
+	return result, rows.Err()
+}
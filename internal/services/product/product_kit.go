@@ -0,0 +1,259 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// errInvalidKit menandai bill of materials yang tidak valid (400)
+var errInvalidKit = errors.New("Invalid kit component")
+
+// kitWarehouseStockSQL menghitung stok kit per warehouse dari stok komponennya:
+// jumlah kit yang bisa dirakit = MIN(stok komponen / quantity komponen per kit).
+// Kolom: kit_id, warehouse_id, quantity (dari on hand), available (dari stok yang belum di-reserve).
+const kitWarehouseStockSQL = `
+	SELECT kc.kit_id, s.warehouse_id,
+		MIN(s.quantity / kc.quantity) AS quantity,
+		MIN(GREATEST(s.quantity - s.reserved_quantity, 0) / kc.quantity) AS available
+	FROM kit_components kc
+	JOIN stocks s ON s.product_id = kc.component_id
+	GROUP BY kc.kit_id, s.warehouse_id`
+
+// Komponen kit beserta stok komponen yang tersedia di semua warehouse
+type ProductKitComponent struct {
+	ProductID int64  `json:"product_id" example:"3"`
+	SKU       string `json:"sku" example:"SKU-20251214201530-042"`
+	Name      string `json:"name" example:"Mie Sedap Goreng"`
+	Quantity  int64  `json:"quantity" example:"2"` // per 1 kit, satuan dasar komponen
+	Available int64  `json:"available" example:"120"`
+}
+
+type KitComponentRequest struct {
+	ProductID int64 `json:"product_id" example:"3"`
+	Quantity  int64 `json:"quantity" example:"2"`
+}
+
+type ProductKitUpdateRequest struct {
+	Components []KitComponentRequest `json:"components"` // menggantikan semua komponen; kosong = bukan kit lagi
+}
+
+type ProductKitSuccessResp struct {
+	Status  string                `json:"status" example:"success"`
+	Message string                `json:"message" example:"Product kit updated successfully"`
+	Data    []ProductKitComponent `json:"data"`
+}
+
+type ProductKitFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid kit component: product 3 is serialized"`
+}
+
+// UpdateProductKit godoc
+// @Summary Set kit components
+// @Description Atur bill of materials kit / bundle. Kit tidak punya stok sendiri: availability dihitung dari stok komponen dan OUT kit mengurangi stok setiap komponen. Product hanya bisa dijadikan kit saat stoknya nol; mengosongkan components mengembalikan product menjadi product biasa dengan stok nol di setiap warehouse. Komponen tidak boleh kit, parent product atau product serialized.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param body body services.ProductKitUpdateRequest true "Kit components"
+// @Success 200 {object} services.ProductKitSuccessResp
+// @Failure 400 {object} services.ProductKitFailResp
+// @Failure 404 {object} services.ProductKitFailResp
+// @Failure 409 {object} services.ProductKitFailResp
+// @Failure 500 {object} services.ProductKitFailResp
+// @Router /stocklab-api/v1/products/kit/{id} [put]
+// @Security BearerAuth
+func UpdateProductKit(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req ProductKitUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var isKit, isParent, lotTracked, serialized bool
+	err = tx.QueryRow(`
+		SELECT is_kit, is_parent, is_lot_tracked, is_serialized FROM products WHERE id = $1 FOR UPDATE
+	`, productID).Scan(&isKit, &isParent, &lotTracked, &serialized)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "product not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	becomesKit := len(req.Components) > 0
+	if becomesKit && !isKit {
+		if isParent || lotTracked || serialized {
+			utils.RespondError(w, http.StatusBadRequest, "Parent, lot-tracked and serialized products cannot be kits")
+			return
+		}
+
+		var usedAsComponent bool
+		err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM kit_components WHERE component_id = $1)`, productID).Scan(&usedAsComponent)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if usedAsComponent {
+			utils.RespondError(w, http.StatusBadRequest, "Product is a component of another kit and cannot be a kit itself")
+			return
+		}
+
+		// Kit tidak punya baris stocks, jadi stok lama harus sudah habis
+		var onHand, reserved int64
+		err = tx.QueryRow(`
+			SELECT COALESCE(SUM(quantity), 0), COALESCE(SUM(reserved_quantity), 0) FROM stocks WHERE product_id = $1
+		`, productID).Scan(&onHand, &reserved)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if onHand != 0 || reserved != 0 {
+			utils.RespondError(w, http.StatusConflict, "Product must have no stock before it becomes a kit")
+			return
+		}
+		if _, err := tx.Exec(`DELETE FROM stocks WHERE product_id = $1`, productID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update stock: "+err.Error())
+			return
+		}
+	}
+
+	// Kit yang dikosongkan kembali menjadi product biasa dengan stok sendiri
+	if !becomesKit && isKit {
+		if _, err := tx.Exec(`
+			INSERT INTO stocks (product_id, warehouse_id, quantity) SELECT $1, id, 0 FROM warehouses
+		`, productID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create stock: "+err.Error())
+			return
+		}
+	}
+
+	if err := saveKitComponents(tx, productID, req.Components); err != nil {
+		if errors.Is(err, errInvalidKit) {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update kit: "+err.Error())
+		return
+	}
+
+	if _, err := tx.Exec(`UPDATE products SET is_kit = $1 WHERE id = $2`, becomesKit, productID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update product: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	components, err := loadKitComponents(productID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if components[productID] == nil {
+		components[productID] = []ProductKitComponent{}
+	}
+
+	utils.RespondSuccess(w, components[productID], "Product kit updated successfully")
+}
+
+// saveKitComponents menggantikan bill of materials kit.
+// Komponen yang tidak valid dikembalikan sebagai errInvalidKit.
+func saveKitComponents(tx *sql.Tx, kitID int64, components []KitComponentRequest) error {
+	if _, err := tx.Exec(`DELETE FROM kit_components WHERE kit_id = $1`, kitID); err != nil {
+		return err
+	}
+
+	seen := map[int64]bool{}
+	for i, c := range components {
+		if c.ProductID == 0 || c.Quantity <= 0 {
+			return fmt.Errorf("%w: line %d needs product_id and a positive quantity", errInvalidKit, i+1)
+		}
+		if c.ProductID == kitID {
+			return fmt.Errorf("%w: a kit cannot contain itself", errInvalidKit)
+		}
+		if seen[c.ProductID] {
+			return fmt.Errorf("%w: product %d appears more than once", errInvalidKit, c.ProductID)
+		}
+		seen[c.ProductID] = true
+
+		// Komponen harus punya stok sendiri dan bisa dikeluarkan tanpa nomor seri
+		var isKit, isParent, serialized bool
+		err := tx.QueryRow(`SELECT is_kit, is_parent, is_serialized FROM products WHERE id = $1`, c.ProductID).
+			Scan(&isKit, &isParent, &serialized)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: product %d not found", errInvalidKit, c.ProductID)
+		} else if err != nil {
+			return err
+		}
+		switch {
+		case isKit:
+			return fmt.Errorf("%w: product %d is a kit", errInvalidKit, c.ProductID)
+		case isParent:
+			return fmt.Errorf("%w: product %d is a parent product, use one of its variants", errInvalidKit, c.ProductID)
+		case serialized:
+			return fmt.Errorf("%w: product %d is serialized", errInvalidKit, c.ProductID)
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO kit_components (kit_id, component_id, quantity) VALUES ($1, $2, $3)
+		`, kitID, c.ProductID, c.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadKitComponents mengambil komponen kit, dikelompokkan per kit.
+// kitID 0 berarti semua kit.
+func loadKitComponents(kitID int64) (map[int64][]ProductKitComponent, error) {
+	rows, err := db.DB.Query(`
+		SELECT kc.kit_id, p.id, p.sku, p.name, kc.quantity,
+			COALESCE((SELECT SUM(s.quantity - s.reserved_quantity) FROM stocks s WHERE s.product_id = p.id), 0)
+		FROM kit_components kc
+		JOIN products p ON p.id = kc.component_id
+		WHERE $1 = 0 OR kc.kit_id = $1
+		ORDER BY kc.kit_id, kc.id
+	`, kitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int64][]ProductKitComponent{}
+	for rows.Next() {
+		var kid int64
+		var c ProductKitComponent
+		if err := rows.Scan(&kid, &c.ProductID, &c.SKU, &c.Name, &c.Quantity, &c.Available); err != nil {
+			return nil, err
+		}
+		result[kid] = append(result[kid], c)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultLedgerPageSize = 50
+	maxLedgerPageSize     = 500
+)
+
+// Asal movement di ledger
+const (
+	RefDocument   = "DOCUMENT"
+	RefTransfer   = "TRANSFER"
+	RefAssembly   = "ASSEMBLY"
+	RefStockCount = "STOCK_COUNT"
+	RefManual     = "MANUAL"
+)
+
+// Satu movement product di ledger beserta saldo sesudahnya
+type ProductLedgerEntry struct {
+	TransactionID    int64     `json:"transaction_id" example:"120"`
+	CreatedAt        time.Time `json:"created_at" example:"2025-12-14T20:15:30Z"`
+	WarehouseID      int64     `json:"warehouse_id" example:"1"`
+	WarehouseCode    string    `json:"warehouse_code" example:"MAIN"`
+	MoveType         string    `json:"move_type" example:"OUT"`
+	QuantityIn       int64     `json:"quantity_in" example:"0"` // satuan dasar
+	QuantityOut      int64     `json:"quantity_out" example:"12"`
+	Balance          int64     `json:"balance" example:"138"`          // saldo product (semua warehouse dalam filter) sesudah movement
+	WarehouseBalance int64     `json:"warehouse_balance" example:"88"` // saldo di warehouse movement sesudah movement
+	EnteredUnit      *string   `json:"entered_unit" example:"CTN"`
+	EnteredQuantity  *int64    `json:"entered_quantity" example:"1"`
+	UserID           int64     `json:"user_id" example:"2"`
+	UserName         string    `json:"user_name" example:"John Doe"`
+	ReferenceType    string    `json:"reference_type" example:"DOCUMENT"` // DOCUMENT | TRANSFER | ASSEMBLY | STOCK_COUNT | MANUAL
+	ReferenceID      *int64    `json:"reference_id" example:"15"`
+	ReferenceNo      *string   `json:"reference_no" example:"SJ-2025-0001"`
+	KitSKU           *string   `json:"kit_sku" example:"SKU-000777"` // kit asal jika movement ini komponen kit
+	ReasonCode       *string   `json:"reason_code" example:"DAMAGE"`
+	Notes            *string   `json:"notes" example:"Kardus basah"`
+}
+
+type ProductLedger struct {
+	ProductID       int64                `json:"product_id" example:"1"`
+	SKU             string               `json:"sku" example:"SKU-000042"`
+	Name            string               `json:"name" example:"Mie Sedap Goreng"`
+	BaseUnit        string               `json:"base_unit" example:"PCS"`
+	WarehouseID     *int64               `json:"warehouse_id" example:"1"` // null = semua warehouse
+	StartDate       string               `json:"start_date" example:"2025-12-01"`
+	EndDate         string               `json:"end_date" example:"2025-12-31"`
+	OpeningBalance  int64                `json:"opening_balance" example:"100"` // saldo sebelum start_date
+	TotalIn         int64                `json:"total_in" example:"80"`
+	TotalOut        int64                `json:"total_out" example:"42"`
+	ClosingBalance  int64                `json:"closing_balance" example:"138"`  // saldo pada akhir end_date
+	CurrentQuantity int64                `json:"current_quantity" example:"138"` // stocks.quantity sekarang
+	Page            int                  `json:"page" example:"1"`
+	PageSize        int                  `json:"page_size" example:"50"`
+	TotalEntries    int64                `json:"total_entries" example:"24"`
+	TotalPages      int64                `json:"total_pages" example:"1"`
+	Entries         []ProductLedgerEntry `json:"entries"`
+}
+
+type ProductLedgerSuccessResp struct {
+	Status  string        `json:"status" example:"success"`
+	Message string        `json:"message" example:"Product ledger fetched successfully"`
+	Data    ProductLedger `json:"data"`
+}
+
+type ProductLedgerFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"product not found"`
+}
+
+// GetProductLedger godoc
+// @Summary Product stock ledger
+// @Description Kartu stok satu product: saldo awal sebelum start_date, lalu setiap movement urut waktu dengan quantity masuk/keluar, saldo berjalan (total dan per warehouse), user dan referensinya. Tanggal dalam UTC. Parent product dan kit tidak punya stok sendiri, gunakan ID varian atau komponen.
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD), inclusive"
+// @Param warehouse_id query int false "Only movements in this warehouse"
+// @Param page query int false "Page number, default 1"
+// @Param page_size query int false "Entries per page, default 50, max 500"
+// @Success 200 {object} services.ProductLedgerSuccessResp
+// @Failure 400 {object} services.ProductLedgerFailResp
+// @Failure 404 {object} services.ProductLedgerFailResp
+// @Failure 500 {object} services.ProductLedgerFailResp
+// @Router /stocklab-api/v1/products/{id}/ledger [get]
+// @Security BearerAuth
+func GetProductLedger(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	q := r.URL.Query()
+	ledger := ProductLedger{ProductID: productID, Page: 1, PageSize: defaultLedgerPageSize, Entries: []ProductLedgerEntry{}}
+
+	// Rentang tanggal OPTIONAL, end_date ikut dihitung sampai akhir hari
+	var from, until interface{}
+	if val := q.Get("start_date"); val != "" {
+		t, err := time.Parse("2006-01-02", val)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "start_date must be YYYY-MM-DD")
+			return
+		}
+		from, ledger.StartDate = t, val
+	}
+	if val := q.Get("end_date"); val != "" {
+		t, err := time.Parse("2006-01-02", val)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "end_date must be YYYY-MM-DD")
+			return
+		}
+		if from != nil && t.Before(from.(time.Time)) {
+			utils.RespondError(w, http.StatusBadRequest, "end_date must not be before start_date")
+			return
+		}
+		until, ledger.EndDate = t.Add(24*time.Hour), val
+	}
+
+	var warehouseID interface{}
+	if val := q.Get("warehouse_id"); val != "" {
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		warehouseID, ledger.WarehouseID = id, &id
+	}
+
+	if val := q.Get("page"); val != "" {
+		page, err := strconv.Atoi(val)
+		if err != nil || page < 1 {
+			utils.RespondError(w, http.StatusBadRequest, "page must be a positive number")
+			return
+		}
+		ledger.Page = page
+	}
+	if val := q.Get("page_size"); val != "" {
+		size, err := strconv.Atoi(val)
+		if err != nil || size < 1 || size > maxLedgerPageSize {
+			utils.RespondError(w, http.StatusBadRequest, "page_size must be between 1 and "+strconv.Itoa(maxLedgerPageSize))
+			return
+		}
+		ledger.PageSize = size
+	}
+
+	var isParent, isKit bool
+	err = db.DB.QueryRow(`
+		SELECT p.sku, p.name, COALESCE(u.code, ''), p.is_parent, p.is_kit
+		FROM products p
+		LEFT JOIN units u ON u.id = p.base_unit_id
+		WHERE p.id = $1
+	`, productID).Scan(&ledger.SKU, &ledger.Name, &ledger.BaseUnit, &isParent, &isKit)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			utils.RespondError(w, http.StatusNotFound, "product not found")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if isParent {
+		utils.RespondError(w, http.StatusBadRequest, "parent product has no stock of its own, use a variant id")
+		return
+	}
+	if isKit {
+		utils.RespondError(w, http.StatusBadRequest, "kit stock is kept on its components, use a component id")
+		return
+	}
+
+	// Saldo awal per warehouse tepat sebelum start_date, memakai snapshot terakhir jika ada
+	opening := map[int64]int64{}
+	if from != nil {
+		rows, err := db.DB.Query(`
+			SELECT b.warehouse_id, b.quantity
+			FROM (`+stock.BalanceAsOfSQL("($1::timestamptz - INTERVAL '1 microsecond')")+`) b
+			WHERE b.product_id = $2 AND ($3::bigint IS NULL OR b.warehouse_id = $3)
+		`, from, productID, warehouseID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		for rows.Next() {
+			var whID, qty int64
+			if err := rows.Scan(&whID, &qty); err != nil {
+				rows.Close()
+				utils.RespondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			opening[whID] = qty
+			ledger.OpeningBalance += qty
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	const rangeCond = `tr.product_id = $1 AND ($2::bigint IS NULL OR tr.warehouse_id = $2)
+		AND ($3::timestamptz IS NULL OR tr.created_at >= $3) AND ($4::timestamptz IS NULL OR tr.created_at < $4)`
+
+	err = db.DB.QueryRow(`
+		SELECT COUNT(*),
+			COALESCE(SUM(tr.quantity) FILTER (WHERE tr.move_type IN ('`+stock.MoveIn+`', '`+stock.MoveAdjustIn+`')), 0),
+			COALESCE(SUM(tr.quantity) FILTER (WHERE tr.move_type NOT IN ('`+stock.MoveIn+`', '`+stock.MoveAdjustIn+`')), 0),
+			(SELECT COALESCE(SUM(s.quantity), 0) FROM stocks s WHERE s.product_id = $1 AND ($2::bigint IS NULL OR s.warehouse_id = $2))
+		FROM transactions tr
+		WHERE `+rangeCond, productID, warehouseID, from, until).Scan(&ledger.TotalEntries, &ledger.TotalIn, &ledger.TotalOut, &ledger.CurrentQuantity)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ledger.ClosingBalance = ledger.OpeningBalance + ledger.TotalIn - ledger.TotalOut
+	ledger.TotalPages = (ledger.TotalEntries + int64(ledger.PageSize) - 1) / int64(ledger.PageSize)
+
+	// Saldo berjalan dihitung atas seluruh rentang sebelum dipotong per halaman
+	rows, err := db.DB.Query(`
+		SELECT tr.id, tr.created_at, tr.warehouse_id, COALESCE(wh.code, ''), tr.move_type, tr.quantity,
+			SUM(`+stock.SignedQuantitySQL("tr")+`) OVER (ORDER BY tr.created_at, tr.id),
+			SUM(`+stock.SignedQuantitySQL("tr")+`) OVER (PARTITION BY tr.warehouse_id ORDER BY tr.created_at, tr.id),
+			eu.code, tr.entered_quantity, tr.user_id, COALESCE(u.name, ''),
+			tr.document_id, d.reference_no, tr.transfer_id, tr.assembly_order_id, ao.order_number, tr.stock_count_id,
+			kp.sku, rc.code, tr.notes
+		FROM transactions tr
+		LEFT JOIN warehouses wh ON wh.id = tr.warehouse_id
+		LEFT JOIN units eu ON eu.id = tr.entered_unit_id
+		LEFT JOIN users u ON u.id = tr.user_id
+		LEFT JOIN stock_documents d ON d.id = tr.document_id
+		LEFT JOIN assembly_orders ao ON ao.id = tr.assembly_order_id
+		LEFT JOIN products kp ON kp.id = tr.kit_product_id
+		LEFT JOIN reason_codes rc ON rc.id = tr.reason_code_id
+		WHERE `+rangeCond+`
+		ORDER BY tr.created_at, tr.id
+		LIMIT $5 OFFSET $6
+	`, productID, warehouseID, from, until, ledger.PageSize, (ledger.Page-1)*ledger.PageSize)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e ProductLedgerEntry
+		var qty int64
+		var documentID, transferID, assemblyID, countID *int64
+		var documentNo, assemblyNo *string
+		if err := rows.Scan(&e.TransactionID, &e.CreatedAt, &e.WarehouseID, &e.WarehouseCode, &e.MoveType, &qty,
+			&e.Balance, &e.WarehouseBalance, &e.EnteredUnit, &e.EnteredQuantity, &e.UserID, &e.UserName,
+			&documentID, &documentNo, &transferID, &assemblyID, &assemblyNo, &countID,
+			&e.KitSKU, &e.ReasonCode, &e.Notes); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if stock.IsInbound(e.MoveType) {
+			e.QuantityIn = qty
+		} else {
+			e.QuantityOut = qty
+		}
+		e.Balance += ledger.OpeningBalance
+		e.WarehouseBalance += opening[e.WarehouseID]
+
+		switch {
+		case documentID != nil:
+			e.ReferenceType, e.ReferenceID, e.ReferenceNo = RefDocument, documentID, documentNo
+		case transferID != nil:
+			e.ReferenceType, e.ReferenceID = RefTransfer, transferID
+		case assemblyID != nil:
+			e.ReferenceType, e.ReferenceID, e.ReferenceNo = RefAssembly, assemblyID, assemblyNo
+		case countID != nil:
+			e.ReferenceType, e.ReferenceID = RefStockCount, countID
+		default:
+			e.ReferenceType = RefManual
+		}
+
+		ledger.Entries = append(ledger.Entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, ledger, "Product ledger fetched successfully")
+}
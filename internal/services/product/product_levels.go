@@ -0,0 +1,169 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// Ambang stok product di satu warehouse
+type ProductLevelsData struct {
+	ProductID    int64 `json:"product_id" example:"1"`
+	WarehouseID  int64 `json:"warehouse_id" example:"1"`
+	ReorderPoint *int  `json:"reorder_point" example:"50"`
+	SafetyStock  *int  `json:"safety_stock" example:"20"`
+	MaxLevel     *int  `json:"max_level" example:"200"`
+}
+
+type ProductLevelsSuccessResp struct {
+	Status  string            `json:"status" example:"success"`
+	Message string            `json:"message" example:"Product levels updated successfully"`
+	Data    ProductLevelsData `json:"data"`
+}
+
+type ProductLevelsFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid stock levels"`
+}
+
+// UpdateProductLevels godoc
+// @Summary Set product levels per warehouse
+// @Description Override reorder point, safety stock dan max level product di satu warehouse. Field yang kosong kembali mengikuti nilai di product.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param warehouse_id formData int true "warehouse_id"
+// @Param reorder_point formData int false "reorder_point"
+// @Param safety_stock formData int false "safety_stock"
+// @Param max_level formData int false "max_level"
+// @Success 200 {object} services.ProductLevelsSuccessResp
+// @Failure 400 {object} services.ProductLevelsFailResp
+// @Failure 404 {object} services.ProductLevelsFailResp
+// @Failure 500 {object} services.ProductLevelsFailResp
+// @Router /stocklab-api/v1/products/levels/{id} [put]
+// @Security BearerAuth
+func UpdateProductLevels(w http.ResponseWriter, r *http.Request) {
+	// Ambil ID dari URL
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	warehouseID, err := strconv.ParseInt(r.FormValue("warehouse_id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+		return
+	}
+
+	override, err := parseLevelsForm(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Ambang product sebagai fallback untuk validasi nilai efektif
+	var product levelsForm
+	err = db.DB.QueryRow(`SELECT reorder_point, safety_stock, max_level FROM products WHERE id = $1`, productID).
+		Scan(&product.ReorderPoint, &product.SafetyStock, &product.MaxLevel)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			utils.RespondError(w, http.StatusNotFound, "product not found")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	effective := override.merge(product)
+	if err := stock.ValidateLevels(*effective.ReorderPoint, *effective.SafetyStock, effective.MaxLevel); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp := ProductLevelsData{
+		ProductID:    productID,
+		WarehouseID:  warehouseID,
+		ReorderPoint: override.ReorderPoint,
+		SafetyStock:  override.SafetyStock,
+		MaxLevel:     override.MaxLevel,
+	}
+
+	res, err := db.DB.Exec(`
+		UPDATE stocks SET reorder_point = $1, safety_stock = $2, max_level = $3, updated_at = NOW()
+		WHERE product_id = $4 AND warehouse_id = $5
+	`, override.ReorderPoint, override.SafetyStock, override.MaxLevel, productID, warehouseID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update levels: "+err.Error())
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		utils.RespondError(w, http.StatusNotFound, stock.ErrStockNotFound.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Product levels updated successfully")
+}
+
+// levelsForm menampung field ambang stok dari form, nil berarti tidak diisi
+type levelsForm struct {
+	ReorderPoint *int
+	SafetyStock  *int
+	MaxLevel     *int
+}
+
+// merge mengisi field yang kosong dengan nilai dari fallback
+func (l levelsForm) merge(fallback levelsForm) levelsForm {
+	if l.ReorderPoint == nil {
+		l.ReorderPoint = fallback.ReorderPoint
+	}
+	if l.SafetyStock == nil {
+		l.SafetyStock = fallback.SafetyStock
+	}
+	if l.MaxLevel == nil {
+		l.MaxLevel = fallback.MaxLevel
+	}
+	return l
+}
+
+func (l levelsForm) empty() bool {
+	return l.ReorderPoint == nil && l.SafetyStock == nil && l.MaxLevel == nil
+}
+
+// parseLevelsForm membaca reorder_point, safety_stock dan max_level (semua opsional)
+func parseLevelsForm(r *http.Request) (levelsForm, error) {
+	var l levelsForm
+	fields := []struct {
+		name string
+		dst  **int
+	}{
+		{"reorder_point", &l.ReorderPoint},
+		{"safety_stock", &l.SafetyStock},
+		{"max_level", &l.MaxLevel},
+	}
+
+	for _, f := range fields {
+		val := r.FormValue(f.name)
+		if val == "" {
+			continue
+		}
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return l, errors.New(f.name + " must be number")
+		}
+		*f.dst = &n
+	}
+
+	return l, nil
+}
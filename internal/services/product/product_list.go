@@ -2,22 +2,38 @@ package services
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 )
 
 // Product blueprint
 type Product struct {
-	ID       int    `json:"id" example:"1"`
-	Name     string `json:"name" example:"Mie Sedap Goreng"`
-	Category string `json:"category" example:"Mie"`
-	SKU      string `json:"sku" example:"SKU-20251214201530-042"`
-	Brand    string `json:"brand" example:"Mie Sedap"`
-	Price    int    `json:"price" example:"100000"`
-	Quantity int32  `json:"quantity" example:"150"`
-	Image    string `json:"image" form:"image" example:"base64imagestring"`
+	ID        int            `json:"id" example:"1"`
+	Name      string         `json:"name" example:"Mie Sedap Goreng"`
+	Category  string         `json:"category" example:"Mie"`
+	SKU       string         `json:"sku" example:"SKU-20251214201530-042"`
+	Brand     string         `json:"brand" example:"Mie Sedap"`
+	Price     money.Amount   `json:"price" swaggertype:"number" example:"100000.00"`
+	Currency  string         `json:"currency" example:"IDR"`
+	Quantity  int32          `json:"quantity" example:"150"` // on hand
+	Reserved  int32          `json:"reserved" example:"30"`
+	Available int32          `json:"available" example:"120"`
+	BaseUnit  string         `json:"base_unit" example:"PCS"` // satuan quantity, reserved dan available
+	InUnit    *UnitQuantity  `json:"in_unit,omitempty"`       // hanya jika query unit diisi dan dikonfigurasi untuk product
+	Stocks    []ProductStock `json:"stocks,omitempty"`
+	Image     string         `json:"image" form:"image" example:"base64imagestring"`
+
+	ParentID          *int              `json:"parent_id,omitempty" example:"10"` // hanya untuk varian
+	IsParent          bool              `json:"is_parent" example:"false"`        // stok parent adalah total stok variannya
+	VariantAttributes map[string]string `json:"variant_attributes,omitempty"`
+	Variants          []ProductVariant  `json:"variants,omitempty"`     // hanya jika group_variants=true
+	IsKit             bool              `json:"is_kit" example:"false"` // stok kit dihitung dari stok komponennya
 }
 
 type ProductSuccessResp struct {
@@ -33,21 +49,56 @@ type ProductFailResp struct {
 
 // Product godoc
 // @Summary Product list
-// @Description Menampilkan list product
+// @Description Menampilkan list product. Quantity (on hand), reserved dan available adalah total semua warehouse, atau hanya satu warehouse jika warehouse_id diisi. Stok parent product adalah total stok semua variannya; dengan group_variants=true varian tidak ditampilkan sendiri melainkan di dalam parent-nya. Stok kit adalah jumlah kit yang bisa dirakit dari stok komponen di masing-masing warehouse.
 // @Tags products
 // @Accept  json
 // @Produce  json
+// @Param warehouse_id query int false "Only count stock in this warehouse"
+// @Param per_location query bool false "Include stock breakdown per warehouse"
+// @Param unit query string false "Also show stock in this unit (e.g. CTN) for products that have it configured"
+// @Param group_variants query bool false "Nest variants under their parent product instead of listing them separately"
 // @Success 200 {object} services.ProductSuccessResp
+// @Failure 400 {object} services.ProductFailResp
 // @Failure 500 {object} services.ProductFailResp
 // @Security BearerAuth
 // @Router /stocklab-api//v1/products/ [get]
 func GetProductList(w http.ResponseWriter, r *http.Request) {
-	// Query semua category
-	rows, err := db.DB.Query(`SELECT p.id, p.name, p.category_id as category, p.sku, p.brand, COALESCE(CAST(p.price AS INT), 0) as price, COALESCE(s.quantity, 0) as quantity, p.image 
+	// Filter warehouse OPTIONAL
+	var warehouseID interface{}
+	if whVal := r.URL.Query().Get("warehouse_id"); whVal != "" {
+		val, err := strconv.ParseInt(whVal, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		warehouseID = val
+	}
+	perLocation, _ := strconv.ParseBool(r.URL.Query().Get("per_location"))
+	unit := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("unit")))
+	groupVariants, _ := strconv.ParseBool(r.URL.Query().Get("group_variants"))
+
+	// Query semua product, stok varian ikut dijumlahkan ke parent-nya dan stok kit dihitung dari komponennya
+	rows, err := db.DB.Query(`SELECT p.id, p.name, p.category_id as category, p.sku, p.brand, COALESCE(p.price, 0) as price, p.currency, COALESCE(s.quantity, 0) as quantity, COALESCE(s.reserved, 0) as reserved, COALESCE(u.code, '') as base_unit, p.image, p.parent_id, p.is_parent, p.variant_attributes, p.is_kit 
 							  FROM products p 
-							  LEFT JOIN stocks s ON s.product_id = p.id 
+							  LEFT JOIN units u ON u.id = p.base_unit_id 
+							  LEFT JOIN (
+								  SELECT x.product_id, SUM(x.quantity) AS quantity, SUM(x.reserved) AS reserved
+								  FROM (
+									  SELECT o.id AS product_id, st.quantity, st.reserved_quantity AS reserved
+									  FROM stocks st
+									  JOIN products sp ON sp.id = st.product_id
+									  JOIN products o ON o.id = sp.id OR o.id = sp.parent_id
+									  WHERE $1::bigint IS NULL OR st.warehouse_id = $1
+									  UNION ALL
+									  SELECT kw.kit_id, kw.quantity, kw.quantity - kw.available
+									  FROM (`+kitWarehouseStockSQL+`) kw
+									  WHERE $1::bigint IS NULL OR kw.warehouse_id = $1
+								  ) x
+								  GROUP BY x.product_id
+							  ) s ON s.product_id = p.id 
 							  LEFT JOIN categories c ON c.id = p.category_id 
-							  ORDER BY id DESC`)
+							  WHERE NOT $2 OR p.parent_id IS NULL 
+							  ORDER BY id DESC`, warehouseID, groupVariants)
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch products: "+err.Error())
 		return
@@ -57,12 +108,20 @@ func GetProductList(w http.ResponseWriter, r *http.Request) {
 	products := []Product{}
 
 	for rows.Next() {
-		var image []byte
+		var image, attrs []byte
 		var p Product
-		if err := rows.Scan(&p.ID, &p.Name, &p.Category, &p.SKU, &p.Brand, &p.Price, &p.Quantity, &image); err != nil {
+		if err := rows.Scan(&p.ID, &p.Name, &p.Category, &p.SKU, &p.Brand, &p.Price, &p.Currency, &p.Quantity, &p.Reserved, &p.BaseUnit, &image, &p.ParentID, &p.IsParent, &attrs, &p.IsKit); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan products: "+err.Error())
 			return
 		}
+		if attrs != nil {
+			if err := json.Unmarshal(attrs, &p.VariantAttributes); err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to read variant attributes: "+err.Error())
+				return
+			}
+		}
+
+		p.Available = p.Quantity - p.Reserved
 
 		//Condition null image
 		if image != nil {
@@ -80,5 +139,49 @@ func GetProductList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Varian ditampilkan di dalam parent-nya
+	if groupVariants {
+		variants, err := loadVariants(0)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch variants: "+err.Error())
+			return
+		}
+		for i := range products {
+			if products[i].IsParent {
+				products[i].Variants = variants[int64(products[i].ID)]
+			}
+		}
+	}
+
+	// Konversi ke satuan yang diminta
+	var units map[int64][]ProductUnit
+	if unit != "" {
+		units, err = loadProductUnits(0)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch units: "+err.Error())
+			return
+		}
+		for i := range products {
+			products[i].InUnit = inUnit(units[int64(products[i].ID)], unit, products[i].Quantity, products[i].Reserved)
+		}
+	}
+
+	// Rincian stok per warehouse
+	if perLocation {
+		stocks, err := loadProductStocks(0)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch stocks: "+err.Error())
+			return
+		}
+		for i := range products {
+			pid := int64(products[i].ID)
+			products[i].Stocks = stocks[pid]
+			for j := range products[i].Stocks {
+				ps := &products[i].Stocks[j]
+				ps.InUnit = inUnit(units[pid], unit, ps.Quantity, ps.Reserved)
+			}
+		}
+	}
+
 	utils.RespondSuccess(w, products, "Products fetched successfully")
 }
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Product di bawah reorder point
+type LowStockItem struct {
+	ProductID        int64  `json:"product_id" example:"1"`
+	ProductName      string `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU       string `json:"product_sku" example:"SKU-20251214201530-042"`
+	WarehouseID      *int64 `json:"warehouse_id" example:"1"`
+	WarehouseCode    string `json:"warehouse_code,omitempty" example:"MAIN"`
+	Quantity         int64  `json:"quantity" example:"12"`
+	ReorderPoint     int64  `json:"reorder_point" example:"50"`
+	SafetyStock      int64  `json:"safety_stock" example:"20"`
+	MaxLevel         *int64 `json:"max_level" example:"200"`
+	Shortfall        int64  `json:"shortfall" example:"38"`
+	SuggestedOrder   int64  `json:"suggested_order" example:"188"`
+	BelowSafetyStock bool   `json:"below_safety_stock" example:"true"`
+}
+
+type LowStockSuccessResp struct {
+	Status  string         `json:"status" example:"success"`
+	Message string         `json:"message" example:"Low stock products fetched successfully"`
+	Data    []LowStockItem `json:"data"`
+}
+
+type LowStockFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch low stock products"`
+}
+
+// GetLowStockList godoc
+// @Summary Low stock products
+// @Description Menampilkan product dengan stok di bawah reorder point beserta shortfall (reorder point - stok) dan saran order (sampai max level, atau sebesar shortfall jika max level kosong). Tanpa filter stok dijumlahkan semua warehouse.
+// @Tags products
+// @Produce json
+// @Param warehouse_id query int false "Only check stock in this warehouse"
+// @Param per_location query bool false "Check each warehouse separately using per-location levels"
+// @Success 200 {object} services.LowStockSuccessResp
+// @Failure 400 {object} services.LowStockFailResp
+// @Failure 500 {object} services.LowStockFailResp
+// @Router /stocklab-api/v1/products/low-stock [get]
+// @Security BearerAuth
+func GetLowStockList(w http.ResponseWriter, r *http.Request) {
+	// Filter warehouse OPTIONAL
+	var warehouseID interface{}
+	if whVal := r.URL.Query().Get("warehouse_id"); whVal != "" {
+		val, err := strconv.ParseInt(whVal, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		warehouseID = val
+	}
+	perLocation, _ := strconv.ParseBool(r.URL.Query().Get("per_location"))
+
+	query := `
+		SELECT l.product_id, p.name, p.sku, l.warehouse_id, COALESCE(w.code, ''),
+			l.quantity, l.reorder_point, l.safety_stock, l.max_level
+		FROM (` + stock.LevelsSQL(perLocation || warehouseID != nil) + `) l
+		JOIN products p ON p.id = l.product_id
+		LEFT JOIN warehouses w ON w.id = l.warehouse_id
+		WHERE l.quantity < l.reorder_point
+			AND ($1::bigint IS NULL OR l.warehouse_id = $1)
+		ORDER BY l.reorder_point - l.quantity DESC, l.product_id, l.warehouse_id
+	`
+
+	rows, err := db.DB.Query(query, warehouseID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch low stock products: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	items := []LowStockItem{}
+	for rows.Next() {
+		var it LowStockItem
+		if err := rows.Scan(
+			&it.ProductID, &it.ProductName, &it.ProductSKU, &it.WarehouseID, &it.WarehouseCode,
+			&it.Quantity, &it.ReorderPoint, &it.SafetyStock, &it.MaxLevel,
+		); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan low stock products: "+err.Error())
+			return
+		}
+
+		it.Shortfall = it.ReorderPoint - it.Quantity
+		it.SuggestedOrder = it.Shortfall
+		if it.MaxLevel != nil && *it.MaxLevel-it.Quantity > it.Shortfall {
+			it.SuggestedOrder = *it.MaxLevel - it.Quantity
+		}
+		it.BelowSafetyStock = it.Quantity < it.SafetyStock
+
+		items = append(items, it)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading low stock products: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, items, "Low stock products fetched successfully")
+}
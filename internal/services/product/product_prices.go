@@ -0,0 +1,138 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// Satu periode harga product, EffectiveTo kosong = harga yang berlaku sekarang
+type ProductPrice struct {
+	Price         money.Amount `json:"price" swaggertype:"number" example:"3500.00"`
+	Currency      string       `json:"currency" example:"IDR"`
+	EffectiveFrom time.Time    `json:"effective_from" example:"2025-12-14T20:15:30Z"`
+	EffectiveTo   *time.Time   `json:"effective_to" example:"2026-01-01T00:00:00Z"`
+	ChangedBy     string       `json:"changed_by,omitempty" example:"Admin"`
+}
+
+type ProductPriceHistorySuccessResp struct {
+	Status  string         `json:"status" example:"success"`
+	Message string         `json:"message" example:"Price history fetched successfully"`
+	Data    []ProductPrice `json:"data"`
+}
+
+type ProductPriceHistoryFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"product not found"`
+}
+
+// GetProductPriceHistory godoc
+// @Summary Product price history
+// @Description Riwayat harga product, terbaru lebih dulu. Jika at diisi hanya periode yang berlaku pada waktu tersebut yang ditampilkan.
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param at query string false "Only the price in effect at this time (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} services.ProductPriceHistorySuccessResp
+// @Failure 400 {object} services.ProductPriceHistoryFailResp
+// @Failure 404 {object} services.ProductPriceHistoryFailResp
+// @Failure 500 {object} services.ProductPriceHistoryFailResp
+// @Router /stocklab-api/v1/products/prices/{id} [get]
+// @Security BearerAuth
+func GetProductPriceHistory(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	// Filter waktu OPTIONAL
+	var at interface{}
+	if val := r.URL.Query().Get("at"); val != "" {
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			t, err = time.Parse("2006-01-02", val)
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "at must be RFC3339 or YYYY-MM-DD")
+			return
+		}
+		at = t
+	}
+
+	var exists bool
+	err = db.DB.QueryRow(`SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, productID).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !exists {
+		utils.RespondError(w, http.StatusNotFound, "product not found")
+		return
+	}
+
+	rows, err := db.DB.Query(`
+		SELECT pp.price, pp.currency, pp.effective_from, pp.effective_to, COALESCE(u.name, '')
+		FROM product_prices pp
+		LEFT JOIN users u ON u.id = pp.changed_by
+		WHERE pp.product_id = $1
+			AND ($2::timestamptz IS NULL OR (pp.effective_from <= $2 AND (pp.effective_to IS NULL OR pp.effective_to > $2)))
+		ORDER BY pp.effective_from DESC, pp.id DESC
+	`, productID, at)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer rows.Close()
+
+	prices := []ProductPrice{}
+	for rows.Next() {
+		var p ProductPrice
+		if err := rows.Scan(&p.Price, &p.Currency, &p.EffectiveFrom, &p.EffectiveTo, &p.ChangedBy); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		prices = append(prices, p)
+	}
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, prices, "Price history fetched successfully")
+}
+
+// recordPrice menutup periode harga yang sedang berlaku dan membuka periode baru.
+// Tidak melakukan apa-apa jika harga dan mata uang tidak berubah.
+func recordPrice(tx *sql.Tx, productID int64, price money.Amount, currency string, userID int64) error {
+	var current money.Amount
+	var currentCurrency string
+	err := tx.QueryRow(`
+		SELECT price, currency FROM product_prices
+		WHERE product_id = $1 AND effective_to IS NULL
+		ORDER BY effective_from DESC LIMIT 1
+	`, productID).Scan(&current, &currentCurrency)
+	if err == nil && current == price && currentCurrency == currency {
+		return nil
+	} else if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE product_prices SET effective_to = NOW()
+		WHERE product_id = $1 AND effective_to IS NULL
+	`, productID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO product_prices (product_id, price, currency, changed_by) VALUES ($1, $2, $3, $4)
+	`, productID, price, currency, userID)
+	return err
+}
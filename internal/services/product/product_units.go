@@ -0,0 +1,273 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+// Satuan dasar product baru jika base_unit tidak diisi
+const defaultBaseUnit = "PCS"
+
+// Satuan yang boleh dipakai untuk satu product. Factor = jumlah satuan dasar per 1 unit.
+type ProductUnit struct {
+	Code   string `json:"code" example:"CTN"`
+	Name   string `json:"name" example:"Karton"`
+	Factor int64  `json:"factor" example:"40"`
+	IsBase bool   `json:"is_base" example:"false"`
+}
+
+// Stok yang dikonversi ke satuan yang diminta lewat query unit
+type UnitQuantity struct {
+	Unit      string  `json:"unit" example:"CTN"`
+	Factor    int64   `json:"factor" example:"40"`
+	Quantity  float64 `json:"quantity" example:"3.75"`
+	Reserved  float64 `json:"reserved" example:"0.75"`
+	Available float64 `json:"available" example:"3"`
+}
+
+type ProductUnitRequest struct {
+	Unit   string `json:"unit" example:"CTN"`
+	Factor int64  `json:"factor" example:"40"`
+}
+
+type ProductUnitsUpdateRequest struct {
+	BaseUnit string               `json:"base_unit" example:"PCS"`
+	Units    []ProductUnitRequest `json:"units"` // menggantikan semua satuan alternatif
+}
+
+type ProductUnitsSuccessResp struct {
+	Status  string        `json:"status" example:"success"`
+	Message string        `json:"message" example:"Product units updated successfully"`
+	Data    []ProductUnit `json:"data"`
+}
+
+type ProductUnitsFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Unit CTN not found"`
+}
+
+// UpdateProductUnits godoc
+// @Summary Set product units
+// @Description Atur satuan dasar dan satuan alternatif product beserta faktor konversinya (1 unit = factor x satuan dasar). Satuan alternatif yang tidak dikirim dihapus. Satuan dasar hanya bisa diganti saat stok product nol karena stok disimpan dalam satuan dasar.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param body body services.ProductUnitsUpdateRequest true "Base unit and alternate units"
+// @Success 200 {object} services.ProductUnitsSuccessResp
+// @Failure 400 {object} services.ProductUnitsFailResp
+// @Failure 404 {object} services.ProductUnitsFailResp
+// @Failure 409 {object} services.ProductUnitsFailResp
+// @Failure 500 {object} services.ProductUnitsFailResp
+// @Router /stocklab-api/v1/products/units/{id} [put]
+// @Security BearerAuth
+func UpdateProductUnits(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req ProductUnitsUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	req.BaseUnit = strings.ToUpper(strings.TrimSpace(req.BaseUnit))
+	if req.BaseUnit == "" {
+		utils.RespondError(w, http.StatusBadRequest, "base_unit is required")
+		return
+	}
+
+	seen := map[string]bool{req.BaseUnit: true}
+	for i, u := range req.Units {
+		req.Units[i].Unit = strings.ToUpper(strings.TrimSpace(u.Unit))
+		if req.Units[i].Unit == "" || u.Factor <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid unit line "+strconv.Itoa(i+1))
+			return
+		}
+		if seen[req.Units[i].Unit] {
+			utils.RespondError(w, http.StatusBadRequest, "unit "+req.Units[i].Unit+" appears more than once")
+			return
+		}
+		seen[req.Units[i].Unit] = true
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var currentBase sql.NullInt64
+	err = tx.QueryRow(`SELECT base_unit_id FROM products WHERE id = $1 FOR UPDATE`, productID).Scan(&currentBase)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "product not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	baseID, err := findUnitID(tx, req.BaseUnit)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusBadRequest, "Unit "+req.BaseUnit+" not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Stok tersimpan dalam satuan dasar lama, jadi ganti satuan dasar hanya saat stok nol
+	if currentBase.Valid && currentBase.Int64 != baseID {
+		var onHand int64
+		err = tx.QueryRow(`SELECT COALESCE(SUM(quantity), 0) FROM stocks WHERE product_id = $1`, productID).Scan(&onHand)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if onHand != 0 {
+			utils.RespondError(w, http.StatusConflict, "Cannot change base unit while the product has stock")
+			return
+		}
+	}
+
+	// Satuan yang masih punya barcode tidak boleh dihapus
+	kept := make([]string, 0, len(seen))
+	for code := range seen {
+		kept = append(kept, code)
+	}
+	var barcodeUnit string
+	err = tx.QueryRow(`
+		SELECT u.code
+		FROM product_barcodes pb
+		JOIN units u ON u.id = pb.unit_id
+		WHERE pb.product_id = $1 AND NOT (UPPER(u.code) = ANY($2::text[]))
+		LIMIT 1
+	`, productID, pq.Array(kept)).Scan(&barcodeUnit)
+	if err == nil {
+		utils.RespondError(w, http.StatusConflict, "Unit "+barcodeUnit+" still has barcodes; remove them first")
+		return
+	} else if err != sql.ErrNoRows {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if _, err := tx.Exec(`UPDATE products SET base_unit_id = $1 WHERE id = $2`, baseID, productID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update product: "+err.Error())
+		return
+	}
+	if _, err := tx.Exec(`DELETE FROM product_units WHERE product_id = $1`, productID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update product units: "+err.Error())
+		return
+	}
+	for _, u := range req.Units {
+		unitID, err := findUnitID(tx, u.Unit)
+		if err == sql.ErrNoRows {
+			utils.RespondError(w, http.StatusBadRequest, "Unit "+u.Unit+" not found")
+			return
+		} else if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO product_units (product_id, unit_id, factor) VALUES ($1, $2, $3)`,
+			productID, unitID, u.Factor,
+		); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update product units: "+err.Error())
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	units, err := loadProductUnits(productID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, units[productID], "Product units updated successfully")
+}
+
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// findUnitID mencari unit berdasarkan code, sql.ErrNoRows jika tidak ada
+func findUnitID(q queryRower, code string) (int64, error) {
+	var id int64
+	err := q.QueryRow(`SELECT id FROM units WHERE UPPER(code) = $1`, strings.ToUpper(code)).Scan(&id)
+	return id, err
+}
+
+// loadProductUnits mengambil satuan dasar dan alternatif, dikelompokkan per product.
+// productID 0 berarti semua product.
+func loadProductUnits(productID int64) (map[int64][]ProductUnit, error) {
+	rows, err := db.DB.Query(`
+		SELECT p.id, u.code, u.name, 1, TRUE
+		FROM products p
+		JOIN units u ON u.id = p.base_unit_id
+		WHERE $1 = 0 OR p.id = $1
+		UNION ALL
+		SELECT pu.product_id, u.code, u.name, pu.factor, FALSE
+		FROM product_units pu
+		JOIN units u ON u.id = pu.unit_id
+		WHERE $1 = 0 OR pu.product_id = $1
+		ORDER BY 1, 5 DESC, 4
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int64][]ProductUnit{}
+	for rows.Next() {
+		var pid int64
+		var u ProductUnit
+		if err := rows.Scan(&pid, &u.Code, &u.Name, &u.Factor, &u.IsBase); err != nil {
+			return nil, err
+		}
+		result[pid] = append(result[pid], u)
+	}
+
+	return result, rows.Err()
+}
+
+// inUnit mengonversi stok (dalam satuan dasar) ke satuan code.
+// Nil jika code kosong atau tidak dikonfigurasi untuk product.
+func inUnit(units []ProductUnit, code string, quantity, reserved int32) *UnitQuantity {
+	if code == "" {
+		return nil
+	}
+	for _, u := range units {
+		if u.Code != code {
+			continue
+		}
+		convert := func(qty int32) float64 {
+			return math.Round(float64(qty)/float64(u.Factor)*100) / 100
+		}
+		return &UnitQuantity{
+			Unit:      u.Code,
+			Factor:    u.Factor,
+			Quantity:  convert(quantity),
+			Reserved:  convert(reserved),
+			Available: convert(quantity - reserved),
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"database/sql"
 	"encoding/base64"
 	"io"
 	"net/http"
@@ -8,19 +9,31 @@ import (
 	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/sku"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
 )
 
 // Product blueprint
 type ProductUpdateData struct {
-	ID         int64  `json:"id" example:"1"`
-	Name       string `json:"name" example:"Mie Sedap Goreng"`
-	CategoryId *int   `json:"category_id,omitempty" example:"1"`
-	SKU        string `json:"sku" example:"SKU-000001"`
-	Brand      string `json:"brand" example:"Mie Sedap"`
-	Price      int `json:"price" example:"10000"`
-	Image      string `json:"image,omitempty" example:"base64imagestring"`
+	ID           int64        `json:"id" example:"1"`
+	Name         string       `json:"name" example:"Mie Sedap Goreng"`
+	CategoryId   *int         `json:"category_id,omitempty" example:"1"`
+	SKU          string       `json:"sku" example:"SKU-000001"`
+	Brand        string       `json:"brand" example:"Mie Sedap"`
+	Price        money.Amount `json:"price" swaggertype:"number" example:"10000.00"`
+	Currency     string       `json:"currency" example:"IDR"`
+	Costing      string       `json:"costing_method" example:"FIFO"`
+	ReorderPoint int          `json:"reorder_point" example:"50"`
+	SafetyStock  int          `json:"safety_stock" example:"20"`
+	MaxLevel     *int         `json:"max_level" example:"200"`
+	IsLotTracked bool         `json:"is_lot_tracked" example:"true"`
+	IsSerialized bool         `json:"is_serialized" example:"false"`
+	Image        string       `json:"image,omitempty" example:"base64imagestring"`
 }
 
 type ProductUpdateSuccessResp struct {
@@ -36,7 +49,7 @@ type ProductUpdateFailResp struct {
 
 // UpdateProduct godoc
 // @Summary Update product
-// @Description Update product (PATCH semantics)
+// @Description Update product (PATCH semantics). Mengaktifkan is_lot_tracked memindahkan stok yang ada ke lot UNLABELLED; is_serialized hanya bisa diaktifkan saat stok product nol.
 // @Tags products
 // @Accept multipart/form-data
 // @Produce json
@@ -44,14 +57,29 @@ type ProductUpdateFailResp struct {
 // @Param name formData string false "name"
 // @Param category_id formData int false "category_id"
 // @Param brand formData string false "brand"
-// @Param price formData string false "price"
+// @Param price formData string false "price: desimal maksimal 2 digit tanpa pemisah ribuan; perubahan dicatat di riwayat harga"
+// @Param currency formData string false "currency: kode ISO 4217"
+// @Param costing_method formData string false "costing_method: FIFO atau AVG; beralih ke AVG menggabungkan layer biaya per warehouse menjadi biaya rata-rata"
+// @Param sku formData string false "sku: harus unik; SKU varian tidak ikut berubah"
+// @Param reorder_point formData int false "reorder_point"
+// @Param safety_stock formData int false "safety_stock"
+// @Param max_level formData int false "max_level"
+// @Param is_lot_tracked formData bool false "is_lot_tracked"
+// @Param is_serialized formData bool false "is_serialized"
 // @Param image formData file false "Product image"
 // @Success 200 {object} services.ProductUpdateSuccessResp
 // @Failure 400 {object} services.ProductUpdateFailResp
+// @Failure 409 {object} services.ProductUpdateFailResp
 // @Failure 500 {object} services.ProductUpdateFailResp
 // @Router /stocklab-api//v1/products/update/{id} [patch]
 // @Security BearerAuth
 func UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	// Ambil ID dari URL
 	productIDStr := chi.URLParam(r, "id")
 	productID, err := strconv.ParseInt(productIDStr, 10, 64)
@@ -68,7 +96,10 @@ func UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	name := r.FormValue("name")
 	catVal := r.FormValue("category_id")
 	brand := r.FormValue("brand")
-	price := r.FormValue("price")
+	priceVal := strings.TrimSpace(r.FormValue("price"))
+	currency := strings.ToUpper(strings.TrimSpace(r.FormValue("currency")))
+	costing := strings.ToUpper(strings.TrimSpace(r.FormValue("costing_method")))
+	newSKU := strings.TrimSpace(r.FormValue("sku"))
 
 	// category_id OPTIONAL
 	var categoryID *int
@@ -81,6 +112,85 @@ func UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		categoryID = &val
 	}
 
+	// Harga dan mata uang OPTIONAL
+	var price *money.Amount
+	if priceVal != "" {
+		parsed, err := money.Parse(priceVal)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		price = &parsed
+	}
+	if currency != "" {
+		if err := money.ValidCurrency(currency); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if costing != "" {
+		if err := stock.ValidCostingMethod(costing); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// SKU OPTIONAL
+	if newSKU != "" {
+		if err := sku.Validate(newSKU); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Ambang stok OPTIONAL, divalidasi bersama nilai yang sudah tersimpan
+	levels, err := parseLevelsForm(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !levels.empty() {
+		var current levelsForm
+		err = db.DB.QueryRow(`SELECT reorder_point, safety_stock, max_level FROM products WHERE id = $1`, productID).
+			Scan(&current.ReorderPoint, &current.SafetyStock, &current.MaxLevel)
+		if err != nil {
+			if err.Error() == "sql: no rows in result set" {
+				utils.RespondError(w, http.StatusNotFound, "product not found")
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		merged := levels.merge(current)
+		if err := stock.ValidateLevels(*merged.ReorderPoint, *merged.SafetyStock, merged.MaxLevel); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Lot tracking OPTIONAL
+	var lotTracked *bool
+	if val := r.FormValue("is_lot_tracked"); val != "" {
+		parsed, err := strconv.ParseBool(val)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "is_lot_tracked must be true or false")
+			return
+		}
+		lotTracked = &parsed
+	}
+
+	// Serial tracking OPTIONAL
+	var serialized *bool
+	if val := r.FormValue("is_serialized"); val != "" {
+		parsed, err := strconv.ParseBool(val)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "is_serialized must be true or false")
+			return
+		}
+		serialized = &parsed
+	}
+
 	// image OPTIONAL
 	var imageBytes []byte
 	file, _, err := r.FormFile("image")
@@ -113,15 +223,57 @@ func UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		argID++
 	}
 
+	if newSKU != "" {
+		setParts = append(setParts, "sku=$"+strconv.Itoa(argID))
+		args = append(args, newSKU)
+		argID++
+	}
+
 	if brand != "" {
 		setParts = append(setParts, "brand=$"+strconv.Itoa(argID))
 		args = append(args, brand)
 		argID++
 	}
 
-	if price != "" {
+	if price != nil {
 		setParts = append(setParts, "price=$"+strconv.Itoa(argID))
-		args = append(args, price)
+		args = append(args, *price)
+		argID++
+	}
+
+	if currency != "" {
+		setParts = append(setParts, "currency=$"+strconv.Itoa(argID))
+		args = append(args, currency)
+		argID++
+	}
+
+	if levels.ReorderPoint != nil {
+		setParts = append(setParts, "reorder_point=$"+strconv.Itoa(argID))
+		args = append(args, *levels.ReorderPoint)
+		argID++
+	}
+
+	if levels.SafetyStock != nil {
+		setParts = append(setParts, "safety_stock=$"+strconv.Itoa(argID))
+		args = append(args, *levels.SafetyStock)
+		argID++
+	}
+
+	if levels.MaxLevel != nil {
+		setParts = append(setParts, "max_level=$"+strconv.Itoa(argID))
+		args = append(args, *levels.MaxLevel)
+		argID++
+	}
+
+	if lotTracked != nil {
+		setParts = append(setParts, "is_lot_tracked=$"+strconv.Itoa(argID))
+		args = append(args, *lotTracked)
+		argID++
+	}
+
+	if serialized != nil {
+		setParts = append(setParts, "is_serialized=$"+strconv.Itoa(argID))
+		args = append(args, *serialized)
 		argID++
 	}
 
@@ -131,37 +283,153 @@ func UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		argID++
 	}
 
-	if len(setParts) == 0 {
+	if len(setParts) == 0 && costing == "" {
 		utils.RespondError(w, http.StatusBadRequest, "no fields to update")
 		return
 	}
+	if len(setParts) == 0 {
+		// Hanya costing_method yang diubah, lewat stock.SetCostingMethod
+		setParts = append(setParts, "updated_at=NOW()")
+	}
 
 	query := `
 		UPDATE products
 		SET ` + strings.Join(setParts, ", ") + `
 		WHERE id=$` + strconv.Itoa(argID) + `
-		RETURNING id, sku, name, category_id, brand, price, image
+		RETURNING id, sku, name, category_id, brand, COALESCE(price, 0), currency, costing_method, reorder_point, safety_stock, max_level, is_lot_tracked, is_serialized, image
 	`
 
 	args = append(args, productID)
 
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	// Harga sebelum update, supaya PATCH dengan harga yang sama tidak menambah riwayat
+	var oldPrice *money.Amount
+	var oldCurrency string
+	if price != nil || currency != "" {
+		err = tx.QueryRow(`SELECT price, currency FROM products WHERE id = $1 FOR UPDATE`, productID).Scan(&oldPrice, &oldCurrency)
+		if err == sql.ErrNoRows {
+			utils.RespondError(w, http.StatusNotFound, "product not found")
+			return
+		} else if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	if (lotTracked != nil && *lotTracked) || (serialized != nil && *serialized) {
+		var currentLot, currentSerial, isKit, isComponent bool
+		err = tx.QueryRow(`
+			SELECT is_lot_tracked, is_serialized, is_kit, EXISTS (SELECT 1 FROM kit_components WHERE component_id = products.id)
+			FROM products WHERE id = $1 FOR UPDATE
+		`, productID).Scan(&currentLot, &currentSerial, &isKit, &isComponent)
+		if err == sql.ErrNoRows {
+			utils.RespondError(w, http.StatusNotFound, "product not found")
+			return
+		} else if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// Kit tidak punya stok sendiri, komponen kit dikeluarkan tanpa nomor seri
+		if isKit {
+			utils.RespondError(w, http.StatusBadRequest, "Kits cannot be lot-tracked or serialized")
+			return
+		}
+		if serialized != nil && *serialized && isComponent {
+			utils.RespondError(w, http.StatusBadRequest, "Product is a kit component and cannot be serialized")
+			return
+		}
+
+		// Saat lot tracking baru diaktifkan, stok yang ada dibuka sebagai lot UNLABELLED
+		if lotTracked != nil && *lotTracked && !currentLot {
+			if err := stock.OpenDefaultLots(tx, productID); err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to open lots: "+err.Error())
+				return
+			}
+		}
+
+		// Serial tracking hanya bisa dimulai dari stok nol
+		if serialized != nil && *serialized && !currentSerial {
+			if err := stock.EnableSerials(tx, productID); err != nil {
+				utils.RespondError(w, stock.StatusCode(err), err.Error())
+				return
+			}
+		}
+	}
+
+	// SKU baru tidak boleh dipakai product lain
+	if newSKU != "" {
+		taken, err := sku.Exists(tx, newSKU, productID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if taken {
+			utils.RespondError(w, http.StatusConflict, "SKU "+newSKU+" already exists")
+			return
+		}
+	}
+
+	// Metode costing diganti bersama penggabungan layer biayanya
+	if costing != "" {
+		if err := stock.SetCostingMethod(tx, productID, costing); err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+	}
+
 	var resp ProductUpdateData
 	var imageDB []byte
 
-	err = db.DB.QueryRow(query, args...).Scan(
+	err = tx.QueryRow(query, args...).Scan(
 		&resp.ID,
 		&resp.SKU,
 		&resp.Name,
 		&resp.CategoryId,
 		&resp.Brand,
 		&resp.Price,
+		&resp.Currency,
+		&resp.Costing,
+		&resp.ReorderPoint,
+		&resp.SafetyStock,
+		&resp.MaxLevel,
+		&resp.IsLotTracked,
+		&resp.IsSerialized,
 		&imageDB,
 	)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			utils.RespondError(w, http.StatusNotFound, "product not found")
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "SKU "+newSKU+" already exists")
+			return
+		}
 		utils.RespondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	// Perubahan harga dicatat di riwayat harga
+	priceChanged := price != nil && (oldPrice == nil || *oldPrice != *price)
+	if priceChanged || (currency != "" && currency != oldCurrency) {
+		if err := recordPrice(tx, productID, resp.Price, resp.Currency, principal.UserID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to record price: "+err.Error())
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
 	if imageDB != nil {
 		resp.Image = base64.StdEncoding.EncodeToString(imageDB)
 	}
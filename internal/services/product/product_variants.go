@@ -0,0 +1,140 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+)
+
+// Batas jumlah varian yang dibuat dalam satu request
+const maxVariants = 100
+
+// Varian dari parent product beserta stoknya sendiri
+type ProductVariant struct {
+	ID         int64             `json:"id" example:"12"`
+	SKU        string            `json:"sku" example:"SKU-20251214201530-042-01"`
+	Name       string            `json:"name" example:"Mie Sedap - Goreng / 75g"`
+	Attributes map[string]string `json:"attributes"`
+	Price      money.Amount      `json:"price" swaggertype:"number" example:"3500.00"`
+	Currency   string            `json:"currency" example:"IDR"`
+	Quantity   int32             `json:"quantity" example:"40"` // on hand
+	Reserved   int32             `json:"reserved" example:"0"`
+	Available  int32             `json:"available" example:"40"`
+}
+
+// parseVariantMatrix membaca {"flavour": ["Goreng", "Soto"], "size": ["75g"]}
+// dan menghasilkan semua kombinasi atribut. Atribut diurutkan berdasarkan nama
+// supaya urutan varian (dan suffix SKU) selalu sama untuk input yang sama.
+func parseVariantMatrix(raw string) ([]map[string]string, []string, error) {
+	var options map[string][]string
+	if err := json.Unmarshal([]byte(raw), &options); err != nil {
+		return nil, nil, errors.New("variants must be a JSON object of attribute to values, e.g. {\"flavour\": [\"Goreng\", \"Soto\"]}")
+	}
+	if len(options) == 0 {
+		return nil, nil, errors.New("variants must have at least one attribute")
+	}
+
+	axes := make([]string, 0, len(options))
+	for attr, values := range options {
+		attr = strings.TrimSpace(attr)
+		if attr == "" || len(values) == 0 {
+			return nil, nil, errors.New("every variant attribute needs a name and at least one value")
+		}
+		axes = append(axes, attr)
+	}
+	sort.Strings(axes)
+
+	combos := []map[string]string{{}}
+	for _, attr := range axes {
+		seen := map[string]bool{}
+		next := []map[string]string{}
+		for _, value := range options[attr] {
+			value = strings.TrimSpace(value)
+			if value == "" || seen[value] {
+				continue
+			}
+			seen[value] = true
+			for _, c := range combos {
+				combo := map[string]string{attr: value}
+				for k, v := range c {
+					combo[k] = v
+				}
+				next = append(next, combo)
+			}
+		}
+		combos = next
+		if len(combos) > maxVariants {
+			return nil, nil, errors.New("too many variants, the limit is 100 per product")
+		}
+	}
+
+	// Urutkan varian mengikuti urutan atribut lalu urutan value di input
+	order := map[string]map[string]int{}
+	for _, attr := range axes {
+		order[attr] = map[string]int{}
+		for i, value := range options[attr] {
+			if _, ok := order[attr][strings.TrimSpace(value)]; !ok {
+				order[attr][strings.TrimSpace(value)] = i
+			}
+		}
+	}
+	sort.SliceStable(combos, func(i, j int) bool {
+		for _, attr := range axes {
+			a, b := order[attr][combos[i][attr]], order[attr][combos[j][attr]]
+			if a != b {
+				return a < b
+			}
+		}
+		return false
+	})
+
+	return combos, axes, nil
+}
+
+// variantName menyusun nama varian: "<parent> - <value> / <value>"
+func variantName(parent string, axes []string, attrs map[string]string) string {
+	values := make([]string, 0, len(axes))
+	for _, attr := range axes {
+		values = append(values, attrs[attr])
+	}
+	return parent + " - " + strings.Join(values, " / ")
+}
+
+// loadVariants mengambil varian beserta total stoknya, dikelompokkan per parent.
+// parentID 0 berarti semua parent.
+func loadVariants(parentID int64) (map[int64][]ProductVariant, error) {
+	rows, err := db.DB.Query(`
+		SELECT p.parent_id, p.id, p.sku, p.name, COALESCE(p.variant_attributes, '{}'), COALESCE(p.price, 0), p.currency,
+			COALESCE(SUM(s.quantity), 0), COALESCE(SUM(s.reserved_quantity), 0)
+		FROM products p
+		LEFT JOIN stocks s ON s.product_id = p.id
+		WHERE p.parent_id IS NOT NULL AND ($1 = 0 OR p.parent_id = $1)
+		GROUP BY p.id
+		ORDER BY p.parent_id, p.sku
+	`, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int64][]ProductVariant{}
+	for rows.Next() {
+		var pid int64
+		var attrs []byte
+		var v ProductVariant
+		if err := rows.Scan(&pid, &v.ID, &v.SKU, &v.Name, &attrs, &v.Price, &v.Currency, &v.Quantity, &v.Reserved); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(attrs, &v.Attributes); err != nil {
+			return nil, err
+		}
+		v.Available = v.Quantity - v.Reserved
+		result[pid] = append(result[pid], v)
+	}
+
+	return result, rows.Err()
+}
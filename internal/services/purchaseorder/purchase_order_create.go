@@ -0,0 +1,226 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/lib/pq"
+)
+
+const (
+	StatusDraft             = "DRAFT"
+	StatusSent              = "SENT"
+	StatusPartiallyReceived = "PARTIALLY_RECEIVED"
+	StatusReceived          = "RECEIVED"
+	StatusCancelled         = "CANCELLED"
+)
+
+type PurchaseOrderLineRequest struct {
+	ProductID int64        `json:"product_id" example:"1"`
+	Quantity  int64        `json:"quantity" example:"100"`
+	UnitPrice money.Amount `json:"unit_price" swaggertype:"number" example:"3000.50"`
+	Currency  string       `json:"currency" example:"IDR"` // kosong = mata uang product
+}
+
+type PurchaseOrderCreateRequest struct {
+	PONumber     string                     `json:"po_number" example:"PO-2025-0001"`
+	SupplierID   int64                      `json:"supplier_id" example:"1"`
+	WarehouseID  int64                      `json:"warehouse_id" example:"1"`
+	OrderDate    string                     `json:"order_date" example:"2025-12-14"`
+	ExpectedDate string                     `json:"expected_date" example:"2025-12-21"`
+	Notes        string                     `json:"notes" example:"Order mingguan"`
+	Lines        []PurchaseOrderLineRequest `json:"lines"`
+}
+
+type PurchaseOrderCreateSuccessResp struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Purchase order created successfully"`
+	Data    PurchaseOrderDetail `json:"data"`
+}
+
+type PurchaseOrderCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to create purchase order"`
+}
+
+// CreatePurchaseOrder godoc
+// @Summary Create purchase order
+// @Description Buat purchase order berstatus DRAFT ke satu supplier untuk diterima di satu warehouse. Satu product hanya boleh muncul sekali per order. Harga baris desimal (maks. 2 desimal); currency kosong memakai mata uang product dan semua baris harus bermata uang sama.
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param body body services.PurchaseOrderCreateRequest true "Purchase order header and lines"
+// @Success 200 {object} services.PurchaseOrderCreateSuccessResp
+// @Failure 400 {object} services.PurchaseOrderCreateFailResp
+// @Failure 401 {object} services.PurchaseOrderCreateFailResp
+// @Failure 409 {object} services.PurchaseOrderCreateFailResp
+// @Failure 500 {object} services.PurchaseOrderCreateFailResp
+// @Router /stocklab-api/v1/purchase-orders/create [post]
+// @Security BearerAuth
+func CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req PurchaseOrderCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	if req.SupplierID == 0 || req.WarehouseID == 0 || len(req.Lines) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "supplier_id, warehouse_id and lines are required")
+		return
+	}
+
+	if req.OrderDate == "" {
+		req.OrderDate = time.Now().Format("2006-01-02")
+	}
+	orderDate, err := time.Parse("2006-01-02", req.OrderDate)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "order_date must be YYYY-MM-DD")
+		return
+	}
+	var expectedDate sql.NullString
+	if req.ExpectedDate != "" {
+		t, err := time.Parse("2006-01-02", req.ExpectedDate)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "expected_date must be YYYY-MM-DD")
+			return
+		}
+		if t.Before(orderDate) {
+			utils.RespondError(w, http.StatusBadRequest, "expected_date must not be before order_date")
+			return
+		}
+		expectedDate = sql.NullString{String: req.ExpectedDate, Valid: true}
+	}
+
+	productIDs := make([]int64, 0, len(req.Lines))
+	seen := map[int64]bool{}
+	for i, line := range req.Lines {
+		if line.ProductID == 0 || line.Quantity <= 0 || line.UnitPrice < 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid order line "+strconv.Itoa(i+1))
+			return
+		}
+		req.Lines[i].Currency = strings.ToUpper(strings.TrimSpace(line.Currency))
+		if req.Lines[i].Currency != "" {
+			if err := money.ValidCurrency(req.Lines[i].Currency); err != nil {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		if seen[line.ProductID] {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" appears more than once")
+			return
+		}
+		seen[line.ProductID] = true
+		productIDs = append(productIDs, line.ProductID)
+	}
+
+	// Supplier harus aktif
+	var supplierActive bool
+	err = db.DB.QueryRow("SELECT is_active FROM suppliers WHERE id = $1", req.SupplierID).Scan(&supplierActive)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusBadRequest, "Supplier not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !supplierActive {
+		utils.RespondError(w, http.StatusBadRequest, "Supplier is inactive")
+		return
+	}
+
+	// Semua product harus punya baris stok di warehouse tujuan
+	var known int
+	err = db.DB.QueryRow(
+		"SELECT COUNT(*) FROM stocks WHERE warehouse_id = $1 AND product_id = ANY($2::bigint[])",
+		req.WarehouseID, pq.Array(productIDs),
+	).Scan(&known)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if known != len(productIDs) {
+		utils.RespondError(w, http.StatusBadRequest, "Unknown warehouse or product in order lines")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var orderID int64
+	err = tx.QueryRow(`
+		INSERT INTO purchase_orders (po_number, supplier_id, warehouse_id, status, order_date, expected_date, notes, created_by)
+		VALUES (NULLIF($1, ''), $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`, req.PONumber, req.SupplierID, req.WarehouseID, StatusDraft, req.OrderDate, expectedDate, req.Notes, principal.UserID).Scan(&orderID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "PO number "+req.PONumber+" is already used")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create purchase order: "+err.Error())
+		return
+	}
+
+	// Nomor PO otomatis PO-<id> jika tidak diisi
+	if req.PONumber == "" {
+		if _, err := tx.Exec(`UPDATE purchase_orders SET po_number = $1 WHERE id = $2`, "PO-"+strconv.FormatInt(orderID, 10), orderID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create purchase order: "+err.Error())
+			return
+		}
+	}
+
+	for _, line := range req.Lines {
+		_, err := tx.Exec(`
+			INSERT INTO purchase_order_lines (purchase_order_id, product_id, ordered_quantity, unit_price, currency)
+			SELECT $1, $2, $3, $4, COALESCE(NULLIF($5, ''), p.currency) FROM products p WHERE p.id = $2
+		`, orderID, line.ProductID, line.Quantity, line.UnitPrice, line.Currency)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create order lines: "+err.Error())
+			return
+		}
+	}
+
+	// Total order hanya bermakna jika semua baris memakai mata uang yang sama
+	var currencies int
+	if err := tx.QueryRow(`
+		SELECT COUNT(DISTINCT currency) FROM purchase_order_lines WHERE purchase_order_id = $1
+	`, orderID).Scan(&currencies); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if currencies > 1 {
+		utils.RespondError(w, http.StatusBadRequest, "All order lines must use the same currency")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	detail, err := loadPurchaseOrder(db.DB, orderID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, detail, "Purchase order created successfully")
+}
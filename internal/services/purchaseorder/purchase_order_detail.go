@@ -0,0 +1,164 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	DeliveryPending  = "PENDING"  // belum ada yang diterima
+	DeliveryUnder    = "UNDER"    // diterima kurang dari yang dipesan
+	DeliveryComplete = "COMPLETE" // diterima sesuai pesanan
+	DeliveryOver     = "OVER"     // diterima melebihi pesanan
+)
+
+// deliveryStatus membandingkan quantity diterima dengan quantity dipesan
+func deliveryStatus(ordered, received int64) string {
+	switch {
+	case received == 0:
+		return DeliveryPending
+	case received < ordered:
+		return DeliveryUnder
+	case received > ordered:
+		return DeliveryOver
+	default:
+		return DeliveryComplete
+	}
+}
+
+type PurchaseOrderLine struct {
+	ID                  int64        `json:"id" example:"1"`
+	ProductID           int64        `json:"product_id" example:"1"`
+	ProductName         string       `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU          string       `json:"product_sku" example:"SKU-20251214201530-042"`
+	OrderedQuantity     int64        `json:"ordered_quantity" example:"100"`
+	ReceivedQuantity    int64        `json:"received_quantity" example:"40"`
+	OutstandingQuantity int64        `json:"outstanding_quantity" example:"60"`
+	UnitPrice           money.Amount `json:"unit_price" swaggertype:"number" example:"3000.50"`
+	Currency            string       `json:"currency" example:"IDR"`
+	Delivery            string       `json:"delivery" example:"UNDER"` // PENDING | UNDER | COMPLETE | OVER
+}
+
+// Dokumen penerimaan yang sudah diposting untuk order ini
+type PurchaseOrderReceipt struct {
+	DocumentID  int64  `json:"document_id" example:"5"`
+	ReferenceNo string `json:"reference_no" example:"SJ-2025-0001"`
+	DocDate     string `json:"doc_date" example:"2025-12-20"`
+}
+
+type PurchaseOrderDetail struct {
+	PurchaseOrderListData
+	Lines    []PurchaseOrderLine    `json:"lines"`
+	Receipts []PurchaseOrderReceipt `json:"receipts"`
+}
+
+type PurchaseOrderDetailSuccessResp struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Purchase order fetched successfully"`
+	Data    PurchaseOrderDetail `json:"data"`
+}
+
+type PurchaseOrderDetailFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch purchase order"`
+}
+
+// GetPurchaseOrderDetail godoc
+// @Summary Purchase order detail
+// @Description Menampilkan header purchase order, quantity dipesan vs diterima per baris beserta status pengirimannya, dan dokumen penerimaan
+// @Tags purchase-orders
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} services.PurchaseOrderDetailSuccessResp
+// @Failure 400 {object} services.PurchaseOrderDetailFailResp
+// @Failure 404 {object} services.PurchaseOrderDetailFailResp
+// @Failure 500 {object} services.PurchaseOrderDetailFailResp
+// @Router /stocklab-api/v1/purchase-orders/detail/{id} [get]
+// @Security BearerAuth
+func GetPurchaseOrderDetail(w http.ResponseWriter, r *http.Request) {
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	d, err := loadPurchaseOrder(db.DB, orderID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "purchase order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, d, "Purchase order fetched successfully")
+}
+
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// loadPurchaseOrder mengambil header, baris dan dokumen penerimaan satu purchase order
+func loadPurchaseOrder(q queryer, orderID int64) (PurchaseOrderDetail, error) {
+	var d PurchaseOrderDetail
+	if err := scanPurchaseOrder(q.QueryRow(purchaseOrderSelect+" WHERE po.id = $1", orderID), &d.PurchaseOrderListData); err != nil {
+		return d, err
+	}
+
+	rows, err := q.Query(`
+		SELECT l.id, l.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), l.ordered_quantity, l.received_quantity, l.unit_price, l.currency
+		FROM purchase_order_lines l
+		LEFT JOIN products p ON p.id = l.product_id
+		WHERE l.purchase_order_id = $1
+		ORDER BY l.id
+	`, orderID)
+	if err != nil {
+		return d, err
+	}
+	defer rows.Close()
+
+	d.Lines = []PurchaseOrderLine{}
+	for rows.Next() {
+		var l PurchaseOrderLine
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.ProductName, &l.ProductSKU, &l.OrderedQuantity, &l.ReceivedQuantity, &l.UnitPrice, &l.Currency); err != nil {
+			return d, err
+		}
+		if l.ReceivedQuantity < l.OrderedQuantity {
+			l.OutstandingQuantity = l.OrderedQuantity - l.ReceivedQuantity
+		}
+		l.Delivery = deliveryStatus(l.OrderedQuantity, l.ReceivedQuantity)
+		d.Lines = append(d.Lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return d, err
+	}
+
+	docRows, err := q.Query(`
+		SELECT id, COALESCE(reference_no, ''), TO_CHAR(doc_date, 'YYYY-MM-DD')
+		FROM stock_documents
+		WHERE purchase_order_id = $1
+		ORDER BY doc_date, id
+	`, orderID)
+	if err != nil {
+		return d, err
+	}
+	defer docRows.Close()
+
+	d.Receipts = []PurchaseOrderReceipt{}
+	for docRows.Next() {
+		var rc PurchaseOrderReceipt
+		if err := docRows.Scan(&rc.DocumentID, &rc.ReferenceNo, &rc.DocDate); err != nil {
+			return d, err
+		}
+		d.Receipts = append(d.Receipts, rc)
+	}
+
+	return d, docRows.Err()
+}
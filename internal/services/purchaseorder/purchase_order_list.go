@@ -0,0 +1,148 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type PurchaseOrderListData struct {
+	ID               int64        `json:"id" example:"1"`
+	PONumber         string       `json:"po_number" example:"PO-2025-0001"`
+	SupplierID       int64        `json:"supplier_id" example:"1"`
+	Supplier         string       `json:"supplier" example:"PT Indofood"`
+	WarehouseID      int64        `json:"warehouse_id" example:"1"`
+	Warehouse        string       `json:"warehouse" example:"Main Warehouse"`
+	Status           string       `json:"status" example:"SENT"`
+	OrderDate        string       `json:"order_date" example:"2025-12-14"`
+	ExpectedDate     *string      `json:"expected_date" example:"2025-12-21"`
+	Overdue          bool         `json:"overdue" example:"false"` // lewat expected_date dan belum selesai diterima
+	Notes            string       `json:"notes" example:"Order mingguan"`
+	CreatedBy        string       `json:"created_by" example:"John Doe"`
+	TotalLines       int64        `json:"total_lines" example:"3"`
+	OrderedQuantity  int64        `json:"ordered_quantity" example:"300"`
+	ReceivedQuantity int64        `json:"received_quantity" example:"120"`
+	TotalAmount      money.Amount `json:"total_amount" swaggertype:"number" example:"900150.00"`
+	Currency         string       `json:"currency" example:"IDR"`
+	CreatedAt        time.Time    `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type PurchaseOrderListSuccessResp struct {
+	Status  string                  `json:"status" example:"success"`
+	Message string                  `json:"message" example:"Purchase orders fetched successfully"`
+	Data    []PurchaseOrderListData `json:"data"`
+}
+
+type PurchaseOrderListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch purchase orders"`
+}
+
+const purchaseOrderSelect = `
+	SELECT po.id, COALESCE(po.po_number, ''), po.supplier_id, COALESCE(s.name, ''), po.warehouse_id, COALESCE(wh.name, ''),
+		po.status, TO_CHAR(po.order_date, 'YYYY-MM-DD'), TO_CHAR(po.expected_date, 'YYYY-MM-DD'),
+		po.expected_date < CURRENT_DATE AND po.status IN ('` + StatusSent + `', '` + StatusPartiallyReceived + `'),
+		COALESCE(po.notes, ''), COALESCE(u.name, ''),
+		(SELECT COUNT(*) FROM purchase_order_lines l WHERE l.purchase_order_id = po.id),
+		(SELECT COALESCE(SUM(l.ordered_quantity), 0) FROM purchase_order_lines l WHERE l.purchase_order_id = po.id),
+		(SELECT COALESCE(SUM(l.received_quantity), 0) FROM purchase_order_lines l WHERE l.purchase_order_id = po.id),
+		(SELECT COALESCE(SUM(l.ordered_quantity * l.unit_price), 0) FROM purchase_order_lines l WHERE l.purchase_order_id = po.id),
+		(SELECT COALESCE(MIN(l.currency), '') FROM purchase_order_lines l WHERE l.purchase_order_id = po.id),
+		po.created_at
+	FROM purchase_orders po
+	LEFT JOIN suppliers s ON s.id = po.supplier_id
+	LEFT JOIN warehouses wh ON wh.id = po.warehouse_id
+	LEFT JOIN users u ON u.id = po.created_by
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPurchaseOrder(row rowScanner, po *PurchaseOrderListData) error {
+	var overdue *bool
+	err := row.Scan(&po.ID, &po.PONumber, &po.SupplierID, &po.Supplier, &po.WarehouseID, &po.Warehouse,
+		&po.Status, &po.OrderDate, &po.ExpectedDate, &overdue, &po.Notes, &po.CreatedBy,
+		&po.TotalLines, &po.OrderedQuantity, &po.ReceivedQuantity, &po.TotalAmount, &po.Currency, &po.CreatedAt)
+	po.Overdue = overdue != nil && *overdue
+	return err
+}
+
+// GetPurchaseOrderList godoc
+// @Summary List purchase orders
+// @Description List purchase order beserta total dipesan dan diterima
+// @Tags purchase-orders
+// @Produce json
+// @Param status query string false "DRAFT | SENT | PARTIALLY_RECEIVED | RECEIVED | CANCELLED"
+// @Param supplier_id query int false "Supplier ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param overdue query bool false "Only orders past their expected date"
+// @Success 200 {object} services.PurchaseOrderListSuccessResp
+// @Failure 400 {object} services.PurchaseOrderListFailResp
+// @Failure 500 {object} services.PurchaseOrderListFailResp
+// @Router /stocklab-api/v1/purchase-orders [get]
+// @Security BearerAuth
+func GetPurchaseOrderList(w http.ResponseWriter, r *http.Request) {
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+	overdue, _ := strconv.ParseBool(r.URL.Query().Get("overdue"))
+
+	var args []interface{}
+	conditions := []string{}
+
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, "po.status = $"+strconv.Itoa(len(args)))
+	}
+	for _, param := range []string{"supplier_id", "warehouse_id"} {
+		val := r.URL.Query().Get(param)
+		if val == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, param+" must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "po."+param+" = $"+strconv.Itoa(len(args)))
+	}
+	if overdue {
+		conditions = append(conditions, "po.expected_date < CURRENT_DATE AND po.status IN ('"+StatusSent+"', '"+StatusPartiallyReceived+"')")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.DB.Query(purchaseOrderSelect+where+" ORDER BY po.order_date DESC, po.id DESC", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching purchase orders: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []PurchaseOrderListData{}
+
+	for rows.Next() {
+		var po PurchaseOrderListData
+		if err := scanPurchaseOrder(rows, &po); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing purchase orders: "+err.Error())
+			return
+		}
+
+		data = append(data, po)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading purchase orders: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Purchase orders fetched successfully")
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	documentService "github.com/Arrafll/StockLab-Go/internal/services/document"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+type PurchaseOrderReceiveLineRequest struct {
+	ProductID  int64    `json:"product_id" example:"1"`
+	Quantity   int64    `json:"quantity" example:"40"`
+	LotNumber  string   `json:"lot_number" example:"LOT-2025-11A"` // wajib untuk product lot-tracked
+	ExpiryDate string   `json:"expiry_date" example:"2026-05-31"`
+	Serials    []string `json:"serial_numbers" example:"SN-0001"` // wajib untuk product serialized, satu per unit
+}
+
+type PurchaseOrderReceiveRequest struct {
+	ReferenceNo string                            `json:"reference_no" example:"SJ-2025-0001"`
+	Notes       string                            `json:"notes" example:"Pengiriman pertama"`
+	DocDate     string                            `json:"doc_date" example:"2025-12-20"`
+	Close       bool                              `json:"close" example:"false"` // tutup order walau masih ada kekurangan
+	Lines       []PurchaseOrderReceiveLineRequest `json:"lines"`
+}
+
+type PurchaseOrderReceiveLine struct {
+	TransactionID    int64  `json:"transaction_id" example:"10"`
+	ProductID        int64  `json:"product_id" example:"1"`
+	Quantity         int64  `json:"quantity" example:"40"`
+	OrderedQuantity  int64  `json:"ordered_quantity" example:"100"`
+	ReceivedQuantity int64  `json:"received_quantity" example:"40"` // total diterima termasuk penerimaan ini
+	Delivery         string `json:"delivery" example:"UNDER"`
+	LotNumber        string `json:"lot_number,omitempty" example:"LOT-2025-11A"`
+	ExpiryDate       string `json:"expiry_date,omitempty" example:"2026-05-31"`
+}
+
+type PurchaseOrderReceiveData struct {
+	PurchaseOrderID int64                      `json:"purchase_order_id" example:"1"`
+	Status          string                     `json:"status" example:"PARTIALLY_RECEIVED"`
+	DocumentID      int64                      `json:"document_id" example:"5"`
+	ReferenceNo     string                     `json:"reference_no" example:"SJ-2025-0001"`
+	OverDelivery    bool                       `json:"over_delivery" example:"false"` // ada baris yang diterima melebihi pesanan
+	UnderDelivery   bool                       `json:"under_delivery" example:"true"` // masih ada baris yang kurang
+	Lines           []PurchaseOrderReceiveLine `json:"lines"`
+}
+
+type PurchaseOrderReceiveSuccessResp struct {
+	Status  string                   `json:"status" example:"success"`
+	Message string                   `json:"message" example:"Goods received successfully"`
+	Data    PurchaseOrderReceiveData `json:"data"`
+}
+
+type PurchaseOrderReceiveFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to receive goods"`
+}
+
+// orderLine adalah baris purchase order yang sudah di-lock
+type orderLine struct {
+	id        int64
+	ordered   int64
+	received  int64
+	unitPrice money.Amount
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary Receive goods against purchase order
+// @Description Posting penerimaan barang untuk purchase order SENT atau PARTIALLY_RECEIVED. Stok masuk lewat dokumen RECEIPT ke warehouse tujuan order, quantity diterima per baris ditambah, dan kelebihan/kekurangan pengiriman ditandai. Order menjadi RECEIVED jika semua baris terpenuhi atau close=true. Product lot-tracked wajib lot_number; satu product boleh diterima dalam beberapa lot. Product serialized wajib serial_numbers sebanyak quantity.
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param body body services.PurchaseOrderReceiveRequest true "Received quantities"
+// @Param Idempotency-Key header string false "Unique key per movement; retries with the same key return the original response"
+// @Success 200 {object} services.PurchaseOrderReceiveSuccessResp
+// @Failure 400 {object} services.PurchaseOrderReceiveFailResp
+// @Failure 401 {object} services.PurchaseOrderReceiveFailResp
+// @Failure 404 {object} services.PurchaseOrderReceiveFailResp
+// @Failure 409 {object} services.PurchaseOrderReceiveFailResp
+// @Failure 422 {object} services.PurchaseOrderReceiveFailResp
+// @Failure 500 {object} services.PurchaseOrderReceiveFailResp
+// @Router /stocklab-api/v1/purchase-orders/receive/{id} [post]
+// @Security BearerAuth
+func ReceivePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	var req PurchaseOrderReceiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	req.ReferenceNo = strings.TrimSpace(req.ReferenceNo)
+
+	if len(req.Lines) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "lines are required")
+		return
+	}
+
+	if req.DocDate == "" {
+		req.DocDate = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", req.DocDate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "doc_date must be YYYY-MM-DD")
+		return
+	}
+
+	// Satu product boleh muncul lebih dari sekali asalkan lot-nya berbeda
+	productIDs := make([]int64, 0, len(req.Lines))
+	seen := map[int64]bool{}
+	seenLots := map[string]bool{}
+	for i, line := range req.Lines {
+		if line.ProductID == 0 || line.Quantity <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid receipt line "+strconv.Itoa(i+1))
+			return
+		}
+		req.Lines[i].LotNumber = strings.TrimSpace(line.LotNumber)
+		lotKey := strconv.FormatInt(line.ProductID, 10) + "/" + req.Lines[i].LotNumber
+		if seenLots[lotKey] {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" appears more than once")
+			return
+		}
+		seenLots[lotKey] = true
+		if !seen[line.ProductID] {
+			seen[line.ProductID] = true
+			productIDs = append(productIDs, line.ProductID)
+		}
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	status, warehouseID, err := lockPurchaseOrder(tx, orderID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "purchase order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status != StatusSent && status != StatusPartiallyReceived {
+		utils.RespondError(w, http.StatusConflict, "Cannot receive goods for a "+status+" purchase order")
+		return
+	}
+
+	lines, err := lockOrderLines(tx, orderID, productIDs)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for _, pid := range productIDs {
+		if _, ok := lines[pid]; !ok {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(pid, 10)+" is not part of this purchase order")
+			return
+		}
+	}
+
+	keys := make([]stock.Key, 0, len(productIDs))
+	for _, pid := range productIDs {
+		keys = append(keys, stock.Key{ProductID: pid, WarehouseID: warehouseID})
+	}
+
+	// Lock baris stok lewat jalur yang sama dengan CreateTransaction
+	balances, err := stock.Lock(tx, keys)
+	if err != nil {
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
+		return
+	}
+
+	resp := PurchaseOrderReceiveData{
+		PurchaseOrderID: orderID,
+		ReferenceNo:     req.ReferenceNo,
+		Lines:           []PurchaseOrderReceiveLine{},
+	}
+
+	// Dokumen penerimaan dengan supplier sebagai partner
+	err = tx.QueryRow(`
+		INSERT INTO stock_documents (doc_type, reference_no, partner, notes, doc_date, warehouse_id, user_id, purchase_order_id)
+		SELECT $1, NULLIF($2, ''), s.name, $3, $4, $5, $6, po.id
+		FROM purchase_orders po
+		LEFT JOIN suppliers s ON s.id = po.supplier_id
+		WHERE po.id = $7
+		RETURNING id
+	`, documentService.DocTypeReceipt, req.ReferenceNo, req.Notes, req.DocDate, warehouseID, principal.UserID, orderID).Scan(&resp.DocumentID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "Reference number "+req.ReferenceNo+" is already posted")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create document: "+err.Error())
+		return
+	}
+
+	// Nomor referensi otomatis GR-<id> jika tidak diisi
+	if req.ReferenceNo == "" {
+		resp.ReferenceNo = "GR-" + strconv.FormatInt(resp.DocumentID, 10)
+		if _, err := tx.Exec(`UPDATE stock_documents SET reference_no = $1 WHERE id = $2`, resp.ReferenceNo, resp.DocumentID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create document: "+err.Error())
+			return
+		}
+	}
+
+	for _, line := range req.Lines {
+		// Biaya perolehan dari harga beli di purchase order
+		cost := lines[line.ProductID].unitPrice.Mul(line.Quantity)
+
+		txID, err := stock.Apply(tx, balances, stock.Movement{
+			Key:        stock.Key{ProductID: line.ProductID, WarehouseID: warehouseID},
+			UserID:     principal.UserID,
+			Quantity:   line.Quantity,
+			MoveType:   stock.MoveIn,
+			DocumentID: resp.DocumentID,
+			LotNumber:  line.LotNumber,
+			ExpiryDate: strings.TrimSpace(line.ExpiryDate),
+			Serials:    line.Serials,
+			CostValue:  &cost,
+		})
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+
+		ol := lines[line.ProductID]
+		ol.received += line.Quantity
+		if _, err := tx.Exec(
+			"UPDATE purchase_order_lines SET received_quantity = $1, updated_at = NOW() WHERE id = $2",
+			ol.received, ol.id,
+		); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update order line: "+err.Error())
+			return
+		}
+
+		resp.Lines = append(resp.Lines, PurchaseOrderReceiveLine{
+			TransactionID:    txID,
+			ProductID:        line.ProductID,
+			Quantity:         line.Quantity,
+			OrderedQuantity:  ol.ordered,
+			ReceivedQuantity: ol.received,
+			Delivery:         deliveryStatus(ol.ordered, ol.received),
+			LotNumber:        line.LotNumber,
+			ExpiryDate:       strings.TrimSpace(line.ExpiryDate),
+		})
+	}
+
+	// Status order dihitung dari semua baris, bukan hanya yang diterima sekarang
+	err = tx.QueryRow(`
+		SELECT
+			COALESCE(BOOL_OR(received_quantity > ordered_quantity), FALSE),
+			COALESCE(BOOL_OR(received_quantity < ordered_quantity), FALSE)
+		FROM purchase_order_lines
+		WHERE purchase_order_id = $1
+	`, orderID).Scan(&resp.OverDelivery, &resp.UnderDelivery)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp.Status = StatusPartiallyReceived
+	if !resp.UnderDelivery || req.Close {
+		resp.Status = StatusReceived
+	}
+
+	if _, err := tx.Exec("UPDATE purchase_orders SET status = $1, updated_at = NOW() WHERE id = $2", resp.Status, orderID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update purchase order: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Goods received successfully")
+}
+
+// lockOrderLines mengunci baris purchase order untuk product yang diterima
+func lockOrderLines(tx *sql.Tx, orderID int64, productIDs []int64) (map[int64]*orderLine, error) {
+	rows, err := tx.Query(`
+		SELECT id, product_id, ordered_quantity, received_quantity, unit_price
+		FROM purchase_order_lines
+		WHERE purchase_order_id = $1 AND product_id = ANY($2::bigint[])
+		ORDER BY id
+		FOR UPDATE
+	`, orderID, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := map[int64]*orderLine{}
+	for rows.Next() {
+		var pid int64
+		l := &orderLine{}
+		if err := rows.Scan(&l.id, &pid, &l.ordered, &l.received, &l.unitPrice); err != nil {
+			return nil, err
+		}
+		lines[pid] = l
+	}
+
+	return lines, rows.Err()
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type PurchaseOrderStatusSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Purchase order sent successfully"`
+}
+
+type PurchaseOrderStatusFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Purchase order is already RECEIVED"`
+}
+
+// lockPurchaseOrder mengunci header purchase order untuk perubahan status
+func lockPurchaseOrder(tx *sql.Tx, orderID int64) (status string, warehouseID int64, err error) {
+	err = tx.QueryRow(
+		"SELECT status, warehouse_id FROM purchase_orders WHERE id = $1 FOR UPDATE",
+		orderID,
+	).Scan(&status, &warehouseID)
+	return status, warehouseID, err
+}
+
+// SendPurchaseOrder godoc
+// @Summary Send purchase order
+// @Description Tandai purchase order DRAFT sudah dikirim ke supplier sehingga bisa diterima
+// @Tags purchase-orders
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} services.PurchaseOrderStatusSuccessResp
+// @Failure 400 {object} services.PurchaseOrderStatusFailResp
+// @Failure 404 {object} services.PurchaseOrderStatusFailResp
+// @Failure 409 {object} services.PurchaseOrderStatusFailResp
+// @Failure 500 {object} services.PurchaseOrderStatusFailResp
+// @Router /stocklab-api/v1/purchase-orders/send/{id} [post]
+// @Security BearerAuth
+func SendPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	changePurchaseOrderStatus(w, r, StatusSent, []string{StatusDraft}, "Purchase order sent successfully")
+}
+
+// CancelPurchaseOrder godoc
+// @Summary Cancel purchase order
+// @Description Batalkan purchase order yang belum menerima barang (DRAFT atau SENT)
+// @Tags purchase-orders
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} services.PurchaseOrderStatusSuccessResp
+// @Failure 400 {object} services.PurchaseOrderStatusFailResp
+// @Failure 403 {object} services.PurchaseOrderStatusFailResp
+// @Failure 404 {object} services.PurchaseOrderStatusFailResp
+// @Failure 409 {object} services.PurchaseOrderStatusFailResp
+// @Failure 500 {object} services.PurchaseOrderStatusFailResp
+// @Router /stocklab-api/v1/purchase-orders/cancel/{id} [post]
+// @Security BearerAuth
+func CancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	changePurchaseOrderStatus(w, r, StatusCancelled, []string{StatusDraft, StatusSent}, "Purchase order cancelled successfully")
+}
+
+func changePurchaseOrderStatus(w http.ResponseWriter, r *http.Request, to string, from []string, message string) {
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid purchase order id")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	status, _, err := lockPurchaseOrder(tx, orderID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "purchase order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	allowed := false
+	for _, s := range from {
+		if status == s {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		utils.RespondError(w, http.StatusConflict, "Purchase order is already "+status)
+		return
+	}
+
+	if _, err := tx.Exec("UPDATE purchase_orders SET status = $1, updated_at = NOW() WHERE id = $2", to, orderID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update purchase order: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, nil, message)
+}
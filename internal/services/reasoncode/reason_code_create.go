@@ -0,0 +1,89 @@
+package services
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type ReasonCodeCreateSuccessResp struct {
+	Status  string     `json:"status" example:"success"`
+	Message string     `json:"message" example:"Reason code created successfully"`
+	Data    ReasonCode `json:"data"`
+}
+
+type ReasonCodeCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid parameter"`
+}
+
+// CreateReasonCode godoc
+// @Summary Create reason code
+// @Description Create a reason code for stock adjustments
+// @Tags reason-codes
+// @Accept multipart/form-data
+// @Produce json
+// @Param code formData string true "code"
+// @Param name formData string true "name"
+// @Param direction formData string false "IN | OUT | BOTH (default BOTH)"
+// @Success 200 {object} services.ReasonCodeCreateSuccessResp
+// @Failure 400 {object} services.ReasonCodeCreateFailResp
+// @Failure 403 {object} services.ReasonCodeCreateFailResp
+// @Failure 409 {object} services.ReasonCodeCreateFailResp
+// @Failure 500 {object} services.ReasonCodeCreateFailResp
+// @Router /stocklab-api/v1/reason-codes/create [post]
+// @Security BearerAuth
+func CreateReasonCode(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(r.FormValue("code")))
+	name := strings.TrimSpace(r.FormValue("name"))
+	direction := strings.ToUpper(strings.TrimSpace(r.FormValue("direction")))
+
+	if code == "" || name == "" {
+		utils.RespondError(w, http.StatusBadRequest, "code and name are required")
+		return
+	}
+	if direction == "" {
+		direction = stock.ReasonDirectionBoth
+	}
+	if !validDirection(direction) {
+		utils.RespondError(w, http.StatusBadRequest, "direction must be IN, OUT or BOTH")
+		return
+	}
+
+	// Cek apakah code sudah ada
+	var exists bool
+	err := db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM reason_codes WHERE UPPER(code) = $1)", code).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if exists {
+		utils.RespondError(w, http.StatusConflict, code+" is already registered")
+		return
+	}
+
+	resp := ReasonCode{Code: code, Name: name, Direction: direction, IsActive: true}
+	err = db.DB.QueryRow(
+		`INSERT INTO reason_codes (code, name, direction) VALUES ($1, $2, $3) RETURNING id`,
+		code, name, direction,
+	).Scan(&resp.ID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create reason code: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Reason code created successfully")
+}
+
+func validDirection(direction string) bool {
+	return direction == stock.ReasonDirectionIn || direction == stock.ReasonDirectionOut || direction == stock.ReasonDirectionBoth
+}
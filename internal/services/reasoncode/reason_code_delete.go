@@ -0,0 +1,77 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type ReasonCodeDeleteSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Reason code deleted successfully"`
+}
+
+type ReasonCodeDeleteFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to delete reason code"`
+}
+
+// DeleteReasonCode godoc
+// @Summary Delete reason code
+// @Description Delete a reason code that has never been used. Used codes should be deactivated instead.
+// @Tags reason-codes
+// @Produce json
+// @Param id path int true "Reason code ID"
+// @Success 200 {object} services.ReasonCodeDeleteSuccessResp
+// @Failure 400 {object} services.ReasonCodeDeleteFailResp
+// @Failure 403 {object} services.ReasonCodeDeleteFailResp
+// @Failure 404 {object} services.ReasonCodeDeleteFailResp
+// @Failure 409 {object} services.ReasonCodeDeleteFailResp
+// @Failure 500 {object} services.ReasonCodeDeleteFailResp
+// @Router /stocklab-api/v1/reason-codes/delete/{id} [delete]
+// @Security BearerAuth
+func DeleteReasonCode(w http.ResponseWriter, r *http.Request) {
+	reasonID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Reason code Id must be a number")
+		return
+	}
+
+	// Cek apakah reason code ada
+	var exists bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM reason_codes WHERE id=$1)", reasonID).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !exists {
+		utils.RespondError(w, http.StatusNotFound, "Reason code not found")
+		return
+	}
+
+	// Cek apakah sudah dipakai di transaksi
+	var used bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM transactions WHERE reason_code_id=$1)", reasonID).Scan(&used)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if used {
+		utils.RespondError(w, http.StatusConflict, "Cannot delete reason code that is used by transactions, deactivate it instead")
+		return
+	}
+
+	if _, err = db.DB.Exec("DELETE FROM reason_codes WHERE id=$1", reasonID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete reason code: "+err.Error())
+		return
+	}
+
+	// Response sukses
+	response := map[string]interface{}{
+		"id": reasonID,
+	}
+	utils.RespondSuccess(w, response, "Reason code deleted successfully")
+}
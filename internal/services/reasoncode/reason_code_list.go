@@ -0,0 +1,67 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Reason code blueprint
+type ReasonCode struct {
+	ID        int64  `json:"id" example:"1"`
+	Code      string `json:"code" example:"DAMAGE"`
+	Name      string `json:"name" example:"Barang rusak"`
+	Direction string `json:"direction" example:"OUT"` // IN | OUT | BOTH
+	IsActive  bool   `json:"is_active" example:"true"`
+}
+
+type ReasonCodeSuccessResp struct {
+	Status  string       `json:"status" example:"success"`
+	Message string       `json:"message" example:"Reason codes fetched successfully"`
+	Data    []ReasonCode `json:"data"`
+}
+
+type ReasonCodeFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch reason codes"`
+}
+
+// GetReasonCodeList godoc
+// @Summary Get list of reason codes
+// @Description Get all reason codes used for stock adjustments
+// @Tags reason-codes
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} services.ReasonCodeSuccessResp
+// @Failure 500 {object} services.ReasonCodeFailResp
+// @Router /stocklab-api/v1/reason-codes [get]
+// @Security BearerAuth
+func GetReasonCodeList(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.DB.Query("SELECT id, code, name, direction, is_active FROM reason_codes ORDER BY code ASC")
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch reason codes: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	reasons := []ReasonCode{}
+
+	for rows.Next() {
+		var rc ReasonCode
+		if err := rows.Scan(&rc.ID, &rc.Code, &rc.Name, &rc.Direction, &rc.IsActive); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan reason codes: "+err.Error())
+			return
+		}
+
+		reasons = append(reasons, rc)
+	}
+
+	// Cek apakah ada error saat iterasi rows
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading reason codes: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, reasons, "Reason codes fetched successfully")
+}
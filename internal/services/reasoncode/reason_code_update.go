@@ -0,0 +1,110 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type ReasonCodeUpdateSuccessResp struct {
+	Status  string     `json:"status" example:"success"`
+	Message string     `json:"message" example:"Reason code updated successfully"`
+	Data    ReasonCode `json:"data"`
+}
+
+type ReasonCodeUpdateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid parameter"`
+}
+
+// UpdateReasonCode godoc
+// @Summary Update reason code
+// @Description Update name, direction or active flag of a reason code. The code itself cannot change because it is referenced by history.
+// @Tags reason-codes
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Reason code ID"
+// @Param name formData string false "name"
+// @Param direction formData string false "IN | OUT | BOTH"
+// @Param is_active formData bool false "is_active"
+// @Success 200 {object} services.ReasonCodeUpdateSuccessResp
+// @Failure 400 {object} services.ReasonCodeUpdateFailResp
+// @Failure 403 {object} services.ReasonCodeUpdateFailResp
+// @Failure 404 {object} services.ReasonCodeUpdateFailResp
+// @Failure 500 {object} services.ReasonCodeUpdateFailResp
+// @Router /stocklab-api/v1/reason-codes/update/{id} [put]
+// @Security BearerAuth
+func UpdateReasonCode(w http.ResponseWriter, r *http.Request) {
+	reasonID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Reason code Id must be a number")
+		return
+	}
+
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	direction := strings.ToUpper(strings.TrimSpace(r.FormValue("direction")))
+	isActiveVal := r.FormValue("is_active")
+
+	if direction != "" && !validDirection(direction) {
+		utils.RespondError(w, http.StatusBadRequest, "direction must be IN, OUT or BOTH")
+		return
+	}
+
+	// Build query dinamis
+	setParts := []string{}
+	args := []interface{}{}
+	argID := 1
+
+	if name != "" {
+		setParts = append(setParts, "name=$"+strconv.Itoa(argID))
+		args = append(args, name)
+		argID++
+	}
+	if direction != "" {
+		setParts = append(setParts, "direction=$"+strconv.Itoa(argID))
+		args = append(args, direction)
+		argID++
+	}
+	if isActiveVal != "" {
+		isActive, err := strconv.ParseBool(isActiveVal)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "is_active must be boolean")
+			return
+		}
+		setParts = append(setParts, "is_active=$"+strconv.Itoa(argID))
+		args = append(args, isActive)
+		argID++
+	}
+
+	if len(setParts) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "No fields to update")
+		return
+	}
+
+	setParts = append(setParts, "updated_at=NOW()")
+	query := "UPDATE reason_codes SET " + strings.Join(setParts, ", ") + " WHERE id=$" + strconv.Itoa(argID) + " RETURNING id, code, name, direction, is_active"
+	args = append(args, reasonID)
+
+	var updated ReasonCode
+	err = db.DB.QueryRow(query, args...).Scan(&updated.ID, &updated.Code, &updated.Name, &updated.Direction, &updated.IsActive)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			utils.RespondError(w, http.StatusNotFound, "Reason code not found")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update reason code: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, updated, "Reason code updated successfully")
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/reconcile"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type ReconciliationCorrectRequest struct {
+	DriftIDs []int64 `json:"drift_ids"`              // kosong = semua drift terbuka di run ini
+	Confirm  bool    `json:"confirm" example:"true"` // false = hanya preview
+}
+
+// Adjustment koreksi untuk satu drift
+type ReconciliationCorrection struct {
+	DriftID       int64  `json:"drift_id" example:"1"`
+	ProductID     int64  `json:"product_id" example:"1"`
+	WarehouseID   int64  `json:"warehouse_id" example:"1"`
+	MoveType      string `json:"move_type" example:"ADJ_OUT"` // kosong jika ledger sudah sama dengan stocks
+	Quantity      int64  `json:"quantity" example:"2"`
+	TransactionID *int64 `json:"transaction_id" example:"501"` // null untuk preview
+}
+
+type ReconciliationCorrectData struct {
+	RunID       int64                      `json:"run_id" example:"1"`
+	Confirmed   bool                       `json:"confirmed" example:"true"`
+	Corrections []ReconciliationCorrection `json:"corrections"`
+}
+
+type ReconciliationCorrectSuccessResp struct {
+	Status  string                    `json:"status" example:"success"`
+	Message string                    `json:"message" example:"Correcting adjustments posted successfully"`
+	Data    ReconciliationCorrectData `json:"data"`
+}
+
+// CorrectReconciliation godoc
+// @Summary Correct reconciliation drifts
+// @Description Posting adjustment ADJ_IN/ADJ_OUT dengan reason RECONCILE supaya ledger kembali sama dengan stocks.quantity. stocks tidak diubah: stok fisik dianggap benar, lakukan stock count jika stocks yang diragukan. Tanpa confirm=true hanya preview yang dikembalikan. Selisih dihitung ulang saat posting.
+// @Tags reconciliations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reconciliation run ID"
+// @Param body body services.ReconciliationCorrectRequest false "Drifts to correct"
+// @Success 200 {object} services.ReconciliationCorrectSuccessResp
+// @Failure 400 {object} services.ReconciliationFailResp
+// @Failure 401 {object} services.ReconciliationFailResp
+// @Failure 404 {object} services.ReconciliationFailResp
+// @Failure 409 {object} services.ReconciliationFailResp
+// @Failure 500 {object} services.ReconciliationFailResp
+// @Router /stocklab-api/v1/reconciliations/correct/{id} [post]
+// @Security BearerAuth
+func CorrectReconciliation(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	runID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Reconciliation run Id must be a number")
+		return
+	}
+
+	var req ReconciliationCorrectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	drifts, err := reconcile.OpenDrifts(tx, runID, req.DriftIDs)
+	if err != nil {
+		utils.RespondError(w, reconcile.StatusCode(err), err.Error())
+		return
+	}
+
+	resp := ReconciliationCorrectData{RunID: runID, Confirmed: req.Confirm, Corrections: []ReconciliationCorrection{}}
+
+	// Preview memakai selisih yang tercatat di report
+	if !req.Confirm {
+		for _, d := range drifts {
+			resp.Corrections = append(resp.Corrections, correction(d, d.Difference, nil))
+		}
+		utils.RespondSuccess(w, resp, "Preview only, send confirm=true to post these adjustments")
+		return
+	}
+
+	for _, d := range drifts {
+		txID, diff, err := reconcile.Correct(tx, d, principal.UserID)
+		if err != nil {
+			utils.RespondError(w, reconcile.StatusCode(err), "Failed to correct drift "+strconv.FormatInt(d.ID, 10)+": "+err.Error())
+			return
+		}
+
+		var posted *int64
+		if txID != 0 {
+			posted = &txID
+		}
+		resp.Corrections = append(resp.Corrections, correction(d, diff, posted))
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Correcting adjustments posted successfully")
+}
+
+func correction(d reconcile.Drift, diff int64, txID *int64) ReconciliationCorrection {
+	c := ReconciliationCorrection{DriftID: d.ID, ProductID: d.ProductID, WarehouseID: d.WarehouseID, TransactionID: txID}
+	switch {
+	case diff > 0:
+		c.MoveType, c.Quantity = stock.MoveAdjustIn, diff
+	case diff < 0:
+		c.MoveType, c.Quantity = stock.MoveAdjustOut, -diff
+	}
+	return c
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// Satu baris stocks yang tidak sama dengan ledger
+type ReconciliationDrift struct {
+	ID             int64      `json:"id" example:"1"`
+	ProductID      int64      `json:"product_id" example:"1"`
+	SKU            string     `json:"sku" example:"SKU-000042"` // kosong jika product sudah dihapus
+	ProductName    string     `json:"product_name" example:"Mie Sedap Goreng"`
+	WarehouseID    int64      `json:"warehouse_id" example:"1"`
+	WarehouseCode  string     `json:"warehouse_code" example:"MAIN"`
+	LedgerQuantity int64      `json:"ledger_quantity" example:"140"`
+	StockQuantity  *int64     `json:"stock_quantity" example:"138"` // null jika baris stocks tidak ada
+	Difference     int64      `json:"difference" example:"-2"`      // stock - ledger
+	Status         string     `json:"status" example:"OPEN"`        // OPEN | CORRECTED
+	TransactionID  *int64     `json:"transaction_id" example:"501"` // adjustment koreksi
+	CorrectedBy    string     `json:"corrected_by" example:"Admin"`
+	CorrectedAt    *time.Time `json:"corrected_at" example:"2026-01-02T09:00:00Z"`
+}
+
+type ReconciliationDetail struct {
+	ReconciliationRunData
+	Drifts []ReconciliationDrift `json:"drifts"`
+}
+
+type ReconciliationDetailSuccessResp struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Reconciliation run fetched successfully"`
+	Data    ReconciliationDetail `json:"data"`
+}
+
+// GetReconciliationDetail godoc
+// @Summary Reconciliation drift report
+// @Description Detail run rekonsiliasi beserta semua drift: saldo ledger, stocks.quantity, selisih dan status koreksinya
+// @Tags reconciliations
+// @Produce json
+// @Param id path int true "Reconciliation run ID"
+// @Success 200 {object} services.ReconciliationDetailSuccessResp
+// @Failure 400 {object} services.ReconciliationFailResp
+// @Failure 404 {object} services.ReconciliationFailResp
+// @Failure 500 {object} services.ReconciliationFailResp
+// @Router /stocklab-api/v1/reconciliations/detail/{id} [get]
+// @Security BearerAuth
+func GetReconciliationDetail(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Reconciliation run Id must be a number")
+		return
+	}
+
+	var detail ReconciliationDetail
+	err = scanReconciliationRun(db.DB.QueryRow(reconciliationRunSelect+` WHERE rr.id = $1`, id), &detail.ReconciliationRunData)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			utils.RespondError(w, http.StatusNotFound, "Reconciliation run not found")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch reconciliation run: "+err.Error())
+		return
+	}
+
+	rows, err := db.DB.Query(`
+		SELECT d.id, d.product_id, COALESCE(p.sku, ''), COALESCE(p.name, ''), d.warehouse_id, COALESCE(wh.code, ''),
+			d.ledger_quantity, d.stock_quantity, d.difference, d.status, d.transaction_id, COALESCE(u.name, ''), d.corrected_at
+		FROM reconciliation_drifts d
+		LEFT JOIN products p ON p.id = d.product_id
+		LEFT JOIN warehouses wh ON wh.id = d.warehouse_id
+		LEFT JOIN users u ON u.id = d.corrected_by
+		WHERE d.run_id = $1
+		ORDER BY ABS(d.difference) DESC, d.product_id, d.warehouse_id
+	`, id)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch drifts: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	detail.Drifts = []ReconciliationDrift{}
+	for rows.Next() {
+		var d ReconciliationDrift
+		if err := rows.Scan(&d.ID, &d.ProductID, &d.SKU, &d.ProductName, &d.WarehouseID, &d.WarehouseCode,
+			&d.LedgerQuantity, &d.StockQuantity, &d.Difference, &d.Status, &d.TransactionID, &d.CorrectedBy, &d.CorrectedAt); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan drifts: "+err.Error())
+			return
+		}
+		detail.Drifts = append(detail.Drifts, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading drifts: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, detail, "Reconciliation run fetched successfully")
+}
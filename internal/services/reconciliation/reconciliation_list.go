@@ -0,0 +1,82 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type ReconciliationRunData struct {
+	ID          int64      `json:"id" example:"1"`
+	Source      string     `json:"source" example:"SCHEDULED"` // MANUAL | SCHEDULED | COMMAND
+	CheckedRows int64      `json:"checked_rows" example:"1200"`
+	DriftCount  int64      `json:"drift_count" example:"3"`
+	OpenDrifts  int64      `json:"open_drifts" example:"1"` // drift yang belum dikoreksi
+	CreatedBy   string     `json:"created_by" example:"Admin"`
+	StartedAt   time.Time  `json:"started_at" example:"2026-01-01T02:00:00Z"`
+	FinishedAt  *time.Time `json:"finished_at" example:"2026-01-01T02:00:04Z"`
+}
+
+type ReconciliationListSuccessResp struct {
+	Status  string                  `json:"status" example:"success"`
+	Message string                  `json:"message" example:"Reconciliation runs fetched successfully"`
+	Data    []ReconciliationRunData `json:"data"`
+}
+
+type ReconciliationFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Reconciliation run not found"`
+}
+
+const reconciliationRunSelect = `
+	SELECT rr.id, rr.source, rr.checked_rows, rr.drift_count,
+		(SELECT COUNT(*) FROM reconciliation_drifts d WHERE d.run_id = rr.id AND d.status = 'OPEN'),
+		COALESCE(u.name, ''), rr.started_at, rr.finished_at
+	FROM reconciliation_runs rr
+	LEFT JOIN users u ON u.id = rr.created_by
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReconciliationRun(row rowScanner, rr *ReconciliationRunData) error {
+	return row.Scan(&rr.ID, &rr.Source, &rr.CheckedRows, &rr.DriftCount, &rr.OpenDrifts, &rr.CreatedBy, &rr.StartedAt, &rr.FinishedAt)
+}
+
+// GetReconciliationList godoc
+// @Summary List reconciliation runs
+// @Description List run rekonsiliasi stocks terhadap ledger transactions, terbaru lebih dulu
+// @Tags reconciliations
+// @Produce json
+// @Success 200 {object} services.ReconciliationListSuccessResp
+// @Failure 500 {object} services.ReconciliationFailResp
+// @Router /stocklab-api/v1/reconciliations [get]
+// @Security BearerAuth
+func GetReconciliationList(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.DB.Query(reconciliationRunSelect + ` ORDER BY rr.started_at DESC, rr.id DESC`)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch reconciliation runs: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	runs := []ReconciliationRunData{}
+	for rows.Next() {
+		var rr ReconciliationRunData
+		if err := scanReconciliationRun(rows, &rr); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan reconciliation runs: "+err.Error())
+			return
+		}
+		runs = append(runs, rr)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading reconciliation runs: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, runs, "Reconciliation runs fetched successfully")
+}
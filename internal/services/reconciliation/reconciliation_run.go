@@ -0,0 +1,48 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/reconcile"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type ReconciliationRunSuccessResp struct {
+	Status  string                `json:"status" example:"success"`
+	Message string                `json:"message" example:"Reconciliation finished"`
+	Data    ReconciliationRunData `json:"data"`
+}
+
+// RunReconciliation godoc
+// @Summary Run stock reconciliation
+// @Description Bandingkan stocks.quantity setiap product di setiap warehouse dengan jumlah IN - OUT di transactions dan simpan selisihnya sebagai drift report. Tidak ada yang dikoreksi; gunakan endpoint correct.
+// @Tags reconciliations
+// @Produce json
+// @Success 200 {object} services.ReconciliationRunSuccessResp
+// @Failure 401 {object} services.ReconciliationFailResp
+// @Failure 500 {object} services.ReconciliationFailResp
+// @Router /stocklab-api/v1/reconciliations/run [post]
+// @Security BearerAuth
+func RunReconciliation(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	res, err := reconcile.Run(db.DB, reconcile.SourceManual, principal.UserID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to run reconciliation: "+err.Error())
+		return
+	}
+
+	var run ReconciliationRunData
+	if err := scanReconciliationRun(db.DB.QueryRow(reconciliationRunSelect+` WHERE rr.id = $1`, res.RunID), &run); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch reconciliation run: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, run, "Reconciliation finished")
+}
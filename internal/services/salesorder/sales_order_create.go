@@ -0,0 +1,219 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/lib/pq"
+)
+
+const (
+	StatusDraft              = "DRAFT"
+	StatusConfirmed          = "CONFIRMED"
+	StatusPartiallyFulfilled = "PARTIALLY_FULFILLED"
+	StatusFulfilled          = "FULFILLED"
+	StatusCancelled          = "CANCELLED"
+)
+
+type SalesOrderLineRequest struct {
+	ProductID int64        `json:"product_id" example:"1"`
+	Quantity  int64        `json:"quantity" example:"20"`
+	UnitPrice money.Amount `json:"unit_price" swaggertype:"number" example:"3500.00"`
+	Currency  string       `json:"currency" example:"IDR"` // kosong = mata uang product
+}
+
+type SalesOrderCreateRequest struct {
+	SONumber    string                  `json:"so_number" example:"SO-2025-0001"`
+	CustomerID  int64                   `json:"customer_id" example:"1"`
+	WarehouseID int64                   `json:"warehouse_id" example:"1"`
+	OrderDate   string                  `json:"order_date" example:"2025-12-14"`
+	Notes       string                  `json:"notes" example:"Kirim sebelum jam 10"`
+	Confirm     bool                    `json:"confirm" example:"true"` // langsung reserve stok
+	Lines       []SalesOrderLineRequest `json:"lines"`
+}
+
+type SalesOrderCreateSuccessResp struct {
+	Status  string           `json:"status" example:"success"`
+	Message string           `json:"message" example:"Sales order created successfully"`
+	Data    SalesOrderDetail `json:"data"`
+}
+
+type SalesOrderCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to create sales order"`
+}
+
+// CreateSalesOrder godoc
+// @Summary Create sales order
+// @Description Buat sales order untuk satu customer yang dikirim dari satu warehouse. Order berstatus DRAFT, atau langsung CONFIRMED dengan stok ter-reserve jika confirm=true. Satu product hanya boleh muncul sekali per order. Harga baris desimal (maks. 2 desimal); currency kosong memakai mata uang product dan semua baris harus bermata uang sama.
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param body body services.SalesOrderCreateRequest true "Sales order header and lines"
+// @Success 200 {object} services.SalesOrderCreateSuccessResp
+// @Failure 400 {object} services.SalesOrderCreateFailResp
+// @Failure 401 {object} services.SalesOrderCreateFailResp
+// @Failure 409 {object} services.SalesOrderCreateFailResp
+// @Failure 500 {object} services.SalesOrderCreateFailResp
+// @Router /stocklab-api/v1/sales-orders/create [post]
+// @Security BearerAuth
+func CreateSalesOrder(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req SalesOrderCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	if req.CustomerID == 0 || req.WarehouseID == 0 || len(req.Lines) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "customer_id, warehouse_id and lines are required")
+		return
+	}
+
+	if req.OrderDate == "" {
+		req.OrderDate = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", req.OrderDate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "order_date must be YYYY-MM-DD")
+		return
+	}
+
+	productIDs := make([]int64, 0, len(req.Lines))
+	seen := map[int64]bool{}
+	for i, line := range req.Lines {
+		if line.ProductID == 0 || line.Quantity <= 0 || line.UnitPrice < 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid order line "+strconv.Itoa(i+1))
+			return
+		}
+		req.Lines[i].Currency = strings.ToUpper(strings.TrimSpace(line.Currency))
+		if req.Lines[i].Currency != "" {
+			if err := money.ValidCurrency(req.Lines[i].Currency); err != nil {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		if seen[line.ProductID] {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" appears more than once")
+			return
+		}
+		seen[line.ProductID] = true
+		productIDs = append(productIDs, line.ProductID)
+	}
+
+	// Customer harus aktif
+	var customerActive bool
+	err := db.DB.QueryRow("SELECT is_active FROM customers WHERE id = $1", req.CustomerID).Scan(&customerActive)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusBadRequest, "Customer not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !customerActive {
+		utils.RespondError(w, http.StatusBadRequest, "Customer is inactive")
+		return
+	}
+
+	// Semua product harus punya baris stok di warehouse asal
+	var known int
+	err = db.DB.QueryRow(
+		"SELECT COUNT(*) FROM stocks WHERE warehouse_id = $1 AND product_id = ANY($2::bigint[])",
+		req.WarehouseID, pq.Array(productIDs),
+	).Scan(&known)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if known != len(productIDs) {
+		utils.RespondError(w, http.StatusBadRequest, "Unknown warehouse or product in order lines")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var orderID int64
+	err = tx.QueryRow(`
+		INSERT INTO sales_orders (so_number, customer_id, warehouse_id, status, order_date, notes, created_by)
+		VALUES (NULLIF($1, ''), $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, req.SONumber, req.CustomerID, req.WarehouseID, StatusDraft, req.OrderDate, req.Notes, principal.UserID).Scan(&orderID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "SO number "+req.SONumber+" is already used")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create sales order: "+err.Error())
+		return
+	}
+
+	// Nomor SO otomatis SO-<id> jika tidak diisi
+	if req.SONumber == "" {
+		if _, err := tx.Exec(`UPDATE sales_orders SET so_number = $1 WHERE id = $2`, "SO-"+strconv.FormatInt(orderID, 10), orderID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create sales order: "+err.Error())
+			return
+		}
+	}
+
+	for _, line := range req.Lines {
+		_, err := tx.Exec(`
+			INSERT INTO sales_order_lines (sales_order_id, product_id, ordered_quantity, unit_price, currency)
+			SELECT $1, $2, $3, $4, COALESCE(NULLIF($5, ''), p.currency) FROM products p WHERE p.id = $2
+		`, orderID, line.ProductID, line.Quantity, line.UnitPrice, line.Currency)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create order lines: "+err.Error())
+			return
+		}
+	}
+
+	// Total order hanya bermakna jika semua baris memakai mata uang yang sama
+	var currencies int
+	if err := tx.QueryRow(`
+		SELECT COUNT(DISTINCT currency) FROM sales_order_lines WHERE sales_order_id = $1
+	`, orderID).Scan(&currencies); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if currencies > 1 {
+		utils.RespondError(w, http.StatusBadRequest, "All order lines must use the same currency")
+		return
+	}
+
+	if req.Confirm {
+		if err := confirmSalesOrder(tx, orderID, req.WarehouseID); err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	detail, err := loadSalesOrder(db.DB, orderID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, detail, "Sales order created successfully")
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type SalesOrderLine struct {
+	ID                  int64        `json:"id" example:"1"`
+	ProductID           int64        `json:"product_id" example:"1"`
+	ProductName         string       `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU          string       `json:"product_sku" example:"SKU-20251214201530-042"`
+	OrderedQuantity     int64        `json:"ordered_quantity" example:"20"`
+	FulfilledQuantity   int64        `json:"fulfilled_quantity" example:"5"`
+	OutstandingQuantity int64        `json:"outstanding_quantity" example:"15"`
+	ReservedQuantity    int64        `json:"reserved_quantity" example:"15"` // outstanding yang sedang di-reserve
+	UnitPrice           money.Amount `json:"unit_price" swaggertype:"number" example:"3500.00"`
+	Currency            string       `json:"currency" example:"IDR"`
+}
+
+// Dokumen pengeluaran yang sudah diposting untuk order ini
+type SalesOrderShipment struct {
+	DocumentID  int64  `json:"document_id" example:"7"`
+	ReferenceNo string `json:"reference_no" example:"GI-7"`
+	DocDate     string `json:"doc_date" example:"2025-12-15"`
+}
+
+type SalesOrderDetail struct {
+	SalesOrderListData
+	Lines     []SalesOrderLine     `json:"lines"`
+	Shipments []SalesOrderShipment `json:"shipments"`
+}
+
+type SalesOrderDetailSuccessResp struct {
+	Status  string           `json:"status" example:"success"`
+	Message string           `json:"message" example:"Sales order fetched successfully"`
+	Data    SalesOrderDetail `json:"data"`
+}
+
+type SalesOrderDetailFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch sales order"`
+}
+
+// GetSalesOrderDetail godoc
+// @Summary Sales order detail
+// @Description Menampilkan header sales order, quantity dipesan, dikirim dan yang masih di-reserve per baris, beserta dokumen pengeluaran
+// @Tags sales-orders
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Success 200 {object} services.SalesOrderDetailSuccessResp
+// @Failure 400 {object} services.SalesOrderDetailFailResp
+// @Failure 404 {object} services.SalesOrderDetailFailResp
+// @Failure 500 {object} services.SalesOrderDetailFailResp
+// @Router /stocklab-api/v1/sales-orders/detail/{id} [get]
+// @Security BearerAuth
+func GetSalesOrderDetail(w http.ResponseWriter, r *http.Request) {
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid sales order id")
+		return
+	}
+
+	d, err := loadSalesOrder(db.DB, orderID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "sales order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, d, "Sales order fetched successfully")
+}
+
+// holdsReservation bernilai true jika sisa order sedang memegang reservasi stok
+func holdsReservation(status string) bool {
+	return status == StatusConfirmed || status == StatusPartiallyFulfilled
+}
+
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// loadSalesOrder mengambil header, baris dan dokumen pengeluaran satu sales order
+func loadSalesOrder(q queryer, orderID int64) (SalesOrderDetail, error) {
+	var d SalesOrderDetail
+	if err := scanSalesOrder(q.QueryRow(salesOrderSelect+" WHERE so.id = $1", orderID), &d.SalesOrderListData); err != nil {
+		return d, err
+	}
+
+	rows, err := q.Query(`
+		SELECT l.id, l.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), l.ordered_quantity, l.fulfilled_quantity, l.unit_price, l.currency
+		FROM sales_order_lines l
+		LEFT JOIN products p ON p.id = l.product_id
+		WHERE l.sales_order_id = $1
+		ORDER BY l.id
+	`, orderID)
+	if err != nil {
+		return d, err
+	}
+	defer rows.Close()
+
+	d.Lines = []SalesOrderLine{}
+	for rows.Next() {
+		var l SalesOrderLine
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.ProductName, &l.ProductSKU, &l.OrderedQuantity, &l.FulfilledQuantity, &l.UnitPrice, &l.Currency); err != nil {
+			return d, err
+		}
+		l.OutstandingQuantity = l.OrderedQuantity - l.FulfilledQuantity
+		if holdsReservation(d.Status) {
+			l.ReservedQuantity = l.OutstandingQuantity
+		}
+		d.Lines = append(d.Lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return d, err
+	}
+
+	docRows, err := q.Query(`
+		SELECT id, COALESCE(reference_no, ''), TO_CHAR(doc_date, 'YYYY-MM-DD')
+		FROM stock_documents
+		WHERE sales_order_id = $1
+		ORDER BY doc_date, id
+	`, orderID)
+	if err != nil {
+		return d, err
+	}
+	defer docRows.Close()
+
+	d.Shipments = []SalesOrderShipment{}
+	for docRows.Next() {
+		var sh SalesOrderShipment
+		if err := docRows.Scan(&sh.DocumentID, &sh.ReferenceNo, &sh.DocDate); err != nil {
+			return d, err
+		}
+		d.Shipments = append(d.Shipments, sh)
+	}
+
+	return d, docRows.Err()
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	documentService "github.com/Arrafll/StockLab-Go/internal/services/document"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+type SalesOrderFulfilLineRequest struct {
+	ProductID int64    `json:"product_id" example:"1"`
+	Quantity  int64    `json:"quantity" example:"5"`
+	LotNumber string   `json:"lot_number" example:"LOT-2025-11A"` // product lot-tracked: kosong = FEFO
+	Serials   []string `json:"serial_numbers" example:"SN-0001"`  // wajib untuk product serialized, satu per unit
+}
+
+type SalesOrderFulfilRequest struct {
+	ReferenceNo string                        `json:"reference_no" example:"SJ-OUT-2025-0001"`
+	Notes       string                        `json:"notes" example:"Pengiriman pertama"`
+	DocDate     string                        `json:"doc_date" example:"2025-12-15"`
+	Lines       []SalesOrderFulfilLineRequest `json:"lines"` // kosong = kirim semua sisa order
+}
+
+type SalesOrderFulfilLine struct {
+	TransactionID     int64                 `json:"transaction_id" example:"12"`
+	ProductID         int64                 `json:"product_id" example:"1"`
+	Quantity          int64                 `json:"quantity" example:"5"`
+	OrderedQuantity   int64                 `json:"ordered_quantity" example:"20"`
+	FulfilledQuantity int64                 `json:"fulfilled_quantity" example:"5"` // total dikirim termasuk pengiriman ini
+	Lots              []SalesOrderFulfilLot `json:"lots,omitempty"`                 // lot yang dikirim, hanya product lot-tracked
+	Serials           []string              `json:"serial_numbers,omitempty" example:"SN-0001"`
+}
+
+type SalesOrderFulfilLot struct {
+	LotNumber  string `json:"lot_number" example:"LOT-2025-11A"`
+	ExpiryDate string `json:"expiry_date,omitempty" example:"2026-05-31"`
+	Quantity   int64  `json:"quantity" example:"5"`
+}
+
+type SalesOrderFulfilData struct {
+	SalesOrderID int64                  `json:"sales_order_id" example:"1"`
+	Status       string                 `json:"status" example:"PARTIALLY_FULFILLED"`
+	DocumentID   int64                  `json:"document_id" example:"7"`
+	ReferenceNo  string                 `json:"reference_no" example:"GI-7"`
+	Lines        []SalesOrderFulfilLine `json:"lines"`
+}
+
+type SalesOrderFulfilSuccessResp struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Sales order fulfilled successfully"`
+	Data    SalesOrderFulfilData `json:"data"`
+}
+
+type SalesOrderFulfilFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fulfil sales order"`
+}
+
+// FulfilSalesOrder godoc
+// @Summary Fulfil sales order
+// @Description Kirim barang untuk sales order CONFIRMED atau PARTIALLY_FULFILLED. Reservasi dilepas dan OUT diposting lewat dokumen ISSUE dari warehouse order. Tanpa lines semua sisa order dikirim. Product lot-tracked dikirim dari lot yang disebut atau FEFO; product serialized wajib menyebut serial_numbers yang dikirim.
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Param body body services.SalesOrderFulfilRequest true "Shipped quantities"
+// @Param Idempotency-Key header string false "Unique key per movement; retries with the same key return the original response"
+// @Success 200 {object} services.SalesOrderFulfilSuccessResp
+// @Failure 400 {object} services.SalesOrderFulfilFailResp
+// @Failure 401 {object} services.SalesOrderFulfilFailResp
+// @Failure 404 {object} services.SalesOrderFulfilFailResp
+// @Failure 409 {object} services.SalesOrderFulfilFailResp
+// @Failure 422 {object} services.SalesOrderFulfilFailResp
+// @Failure 500 {object} services.SalesOrderFulfilFailResp
+// @Router /stocklab-api/v1/sales-orders/fulfil/{id} [post]
+// @Security BearerAuth
+func FulfilSalesOrder(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid sales order id")
+		return
+	}
+
+	var req SalesOrderFulfilRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	req.ReferenceNo = strings.TrimSpace(req.ReferenceNo)
+
+	if req.DocDate == "" {
+		req.DocDate = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", req.DocDate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "doc_date must be YYYY-MM-DD")
+		return
+	}
+
+	seen := map[int64]bool{}
+	for i, line := range req.Lines {
+		if line.ProductID == 0 || line.Quantity <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid fulfilment line "+strconv.Itoa(i+1))
+			return
+		}
+		if seen[line.ProductID] {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" appears more than once")
+			return
+		}
+		seen[line.ProductID] = true
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	status, warehouseID, err := lockSalesOrder(tx, orderID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "sales order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !holdsReservation(status) {
+		utils.RespondError(w, http.StatusConflict, "Cannot fulfil a "+status+" sales order")
+		return
+	}
+
+	outstanding, balances, err := lockOutstanding(tx, orderID, warehouseID)
+	if err != nil {
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
+		return
+	}
+	remaining := map[int64]int64{}
+	for _, l := range outstanding {
+		remaining[l.productID] = l.outstanding
+	}
+
+	// Tanpa lines berarti kirim semua sisa order
+	if len(req.Lines) == 0 {
+		for _, l := range outstanding {
+			req.Lines = append(req.Lines, SalesOrderFulfilLineRequest{ProductID: l.productID, Quantity: l.outstanding})
+		}
+	}
+	if len(req.Lines) == 0 {
+		utils.RespondError(w, http.StatusConflict, "Sales order has nothing left to fulfil")
+		return
+	}
+	for _, line := range req.Lines {
+		rest, ok := remaining[line.ProductID]
+		if !ok {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" has nothing left to fulfil on this sales order")
+			return
+		}
+		if line.Quantity > rest {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" only has "+strconv.FormatInt(rest, 10)+" left to fulfil")
+			return
+		}
+	}
+
+	resp := SalesOrderFulfilData{
+		SalesOrderID: orderID,
+		ReferenceNo:  req.ReferenceNo,
+		Lines:        []SalesOrderFulfilLine{},
+	}
+
+	// Dokumen pengeluaran dengan customer sebagai partner
+	err = tx.QueryRow(`
+		INSERT INTO stock_documents (doc_type, reference_no, partner, notes, doc_date, warehouse_id, user_id, sales_order_id)
+		SELECT $1, NULLIF($2, ''), c.name, $3, $4, $5, $6, so.id
+		FROM sales_orders so
+		LEFT JOIN customers c ON c.id = so.customer_id
+		WHERE so.id = $7
+		RETURNING id
+	`, documentService.DocTypeIssue, req.ReferenceNo, req.Notes, req.DocDate, warehouseID, principal.UserID, orderID).Scan(&resp.DocumentID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			utils.RespondError(w, http.StatusConflict, "Reference number "+req.ReferenceNo+" is already posted")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create document: "+err.Error())
+		return
+	}
+
+	// Nomor referensi otomatis GI-<id> jika tidak diisi
+	if req.ReferenceNo == "" {
+		resp.ReferenceNo = "GI-" + strconv.FormatInt(resp.DocumentID, 10)
+		if _, err := tx.Exec(`UPDATE stock_documents SET reference_no = $1 WHERE id = $2`, resp.ReferenceNo, resp.DocumentID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to create document: "+err.Error())
+			return
+		}
+	}
+
+	for _, line := range req.Lines {
+		key := stock.Key{ProductID: line.ProductID, WarehouseID: warehouseID}
+
+		// Lepas reservasi dulu supaya OUT boleh memakai stok yang dialokasikan ke order ini
+		if err := stock.Release(tx, balances, key, line.Quantity); err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+
+		txID, err := stock.Apply(tx, balances, stock.Movement{
+			Key:        key,
+			UserID:     principal.UserID,
+			Quantity:   line.Quantity,
+			MoveType:   stock.MoveOut,
+			DocumentID: resp.DocumentID,
+			LotNumber:  strings.TrimSpace(line.LotNumber),
+			Serials:    line.Serials,
+		})
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+
+		lots, err := stock.TransactionLots(tx, txID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		fl := SalesOrderFulfilLine{TransactionID: txID, ProductID: line.ProductID, Quantity: line.Quantity, Lots: []SalesOrderFulfilLot{}, Serials: line.Serials}
+		for _, l := range lots {
+			fl.Lots = append(fl.Lots, SalesOrderFulfilLot{LotNumber: l.LotNumber, ExpiryDate: l.ExpiryDate, Quantity: l.Quantity})
+		}
+		err = tx.QueryRow(`
+			UPDATE sales_order_lines SET fulfilled_quantity = fulfilled_quantity + $1, updated_at = NOW()
+			WHERE sales_order_id = $2 AND product_id = $3
+			RETURNING ordered_quantity, fulfilled_quantity
+		`, line.Quantity, orderID, line.ProductID).Scan(&fl.OrderedQuantity, &fl.FulfilledQuantity)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to update order line: "+err.Error())
+			return
+		}
+
+		remaining[line.ProductID] -= line.Quantity
+		resp.Lines = append(resp.Lines, fl)
+	}
+
+	resp.Status = StatusFulfilled
+	for _, rest := range remaining {
+		if rest > 0 {
+			resp.Status = StatusPartiallyFulfilled
+			break
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE sales_orders SET status = $1, updated_at = NOW() WHERE id = $2", resp.Status, orderID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update sales order: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Sales order fulfilled successfully")
+}
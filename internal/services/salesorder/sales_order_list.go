@@ -0,0 +1,136 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type SalesOrderListData struct {
+	ID                int64        `json:"id" example:"1"`
+	SONumber          string       `json:"so_number" example:"SO-2025-0001"`
+	CustomerID        int64        `json:"customer_id" example:"1"`
+	Customer          string       `json:"customer" example:"PT Sumber Alfaria"`
+	WarehouseID       int64        `json:"warehouse_id" example:"1"`
+	Warehouse         string       `json:"warehouse" example:"Main Warehouse"`
+	Status            string       `json:"status" example:"CONFIRMED"`
+	OrderDate         string       `json:"order_date" example:"2025-12-14"`
+	Notes             string       `json:"notes" example:"Kirim sebelum jam 10"`
+	CreatedBy         string       `json:"created_by" example:"John Doe"`
+	TotalLines        int64        `json:"total_lines" example:"3"`
+	OrderedQuantity   int64        `json:"ordered_quantity" example:"60"`
+	FulfilledQuantity int64        `json:"fulfilled_quantity" example:"20"`
+	TotalAmount       money.Amount `json:"total_amount" swaggertype:"number" example:"210000.00"`
+	Currency          string       `json:"currency" example:"IDR"`
+	CreatedAt         time.Time    `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type SalesOrderListSuccessResp struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Sales orders fetched successfully"`
+	Data    []SalesOrderListData `json:"data"`
+}
+
+type SalesOrderListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch sales orders"`
+}
+
+const salesOrderSelect = `
+	SELECT so.id, COALESCE(so.so_number, ''), so.customer_id, COALESCE(c.name, ''), so.warehouse_id, COALESCE(wh.name, ''),
+		so.status, TO_CHAR(so.order_date, 'YYYY-MM-DD'), COALESCE(so.notes, ''), COALESCE(u.name, ''),
+		(SELECT COUNT(*) FROM sales_order_lines l WHERE l.sales_order_id = so.id),
+		(SELECT COALESCE(SUM(l.ordered_quantity), 0) FROM sales_order_lines l WHERE l.sales_order_id = so.id),
+		(SELECT COALESCE(SUM(l.fulfilled_quantity), 0) FROM sales_order_lines l WHERE l.sales_order_id = so.id),
+		(SELECT COALESCE(SUM(l.ordered_quantity * l.unit_price), 0) FROM sales_order_lines l WHERE l.sales_order_id = so.id),
+		(SELECT COALESCE(MIN(l.currency), '') FROM sales_order_lines l WHERE l.sales_order_id = so.id),
+		so.created_at
+	FROM sales_orders so
+	LEFT JOIN customers c ON c.id = so.customer_id
+	LEFT JOIN warehouses wh ON wh.id = so.warehouse_id
+	LEFT JOIN users u ON u.id = so.created_by
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSalesOrder(row rowScanner, so *SalesOrderListData) error {
+	return row.Scan(&so.ID, &so.SONumber, &so.CustomerID, &so.Customer, &so.WarehouseID, &so.Warehouse,
+		&so.Status, &so.OrderDate, &so.Notes, &so.CreatedBy,
+		&so.TotalLines, &so.OrderedQuantity, &so.FulfilledQuantity, &so.TotalAmount, &so.Currency, &so.CreatedAt)
+}
+
+// GetSalesOrderList godoc
+// @Summary List sales orders
+// @Description List sales order beserta total dipesan dan sudah dikirim
+// @Tags sales-orders
+// @Produce json
+// @Param status query string false "DRAFT | CONFIRMED | PARTIALLY_FULFILLED | FULFILLED | CANCELLED"
+// @Param customer_id query int false "Customer ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Success 200 {object} services.SalesOrderListSuccessResp
+// @Failure 400 {object} services.SalesOrderListFailResp
+// @Failure 500 {object} services.SalesOrderListFailResp
+// @Router /stocklab-api/v1/sales-orders [get]
+// @Security BearerAuth
+func GetSalesOrderList(w http.ResponseWriter, r *http.Request) {
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+
+	var args []interface{}
+	conditions := []string{}
+
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, "so.status = $"+strconv.Itoa(len(args)))
+	}
+	for _, param := range []string{"customer_id", "warehouse_id"} {
+		val := r.URL.Query().Get(param)
+		if val == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, param+" must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "so."+param+" = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.DB.Query(salesOrderSelect+where+" ORDER BY so.order_date DESC, so.id DESC", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching sales orders: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []SalesOrderListData{}
+
+	for rows.Next() {
+		var so SalesOrderListData
+		if err := scanSalesOrder(rows, &so); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing sales orders: "+err.Error())
+			return
+		}
+
+		data = append(data, so)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading sales orders: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Sales orders fetched successfully")
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type SalesOrderStatusSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Sales order confirmed successfully"`
+}
+
+type SalesOrderStatusFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Insufficient stock for product 1 at warehouse 1: 4 available"`
+}
+
+// lockSalesOrder mengunci header sales order untuk perubahan status
+func lockSalesOrder(tx *sql.Tx, orderID int64) (status string, warehouseID int64, err error) {
+	err = tx.QueryRow(
+		"SELECT status, warehouse_id FROM sales_orders WHERE id = $1 FOR UPDATE",
+		orderID,
+	).Scan(&status, &warehouseID)
+	return status, warehouseID, err
+}
+
+// outstandingLine adalah sisa baris sales order yang belum dikirim
+type outstandingLine struct {
+	productID   int64
+	outstanding int64
+}
+
+func loadOutstanding(tx *sql.Tx, orderID int64) ([]outstandingLine, error) {
+	rows, err := tx.Query(`
+		SELECT product_id, ordered_quantity - fulfilled_quantity
+		FROM sales_order_lines
+		WHERE sales_order_id = $1 AND ordered_quantity > fulfilled_quantity
+		ORDER BY product_id
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []outstandingLine{}
+	for rows.Next() {
+		var l outstandingLine
+		if err := rows.Scan(&l.productID, &l.outstanding); err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
+
+// lockOutstanding mengunci baris stocks untuk semua sisa baris order
+func lockOutstanding(tx *sql.Tx, orderID, warehouseID int64) ([]outstandingLine, stock.Balances, error) {
+	lines, err := loadOutstanding(tx, orderID)
+	if err != nil || len(lines) == 0 {
+		return lines, stock.Balances{}, err
+	}
+
+	keys := make([]stock.Key, 0, len(lines))
+	for _, l := range lines {
+		keys = append(keys, stock.Key{ProductID: l.productID, WarehouseID: warehouseID})
+	}
+	balances, err := stock.Lock(tx, keys)
+	return lines, balances, err
+}
+
+// confirmSalesOrder me-reserve semua baris order dan mengubah status menjadi CONFIRMED.
+// Gagal satu baris berarti gagal semua.
+func confirmSalesOrder(tx *sql.Tx, orderID, warehouseID int64) error {
+	lines, balances, err := lockOutstanding(tx, orderID, warehouseID)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		if err := stock.Reserve(tx, balances, stock.Key{ProductID: l.productID, WarehouseID: warehouseID}, l.outstanding); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("UPDATE sales_orders SET status = $1, updated_at = NOW() WHERE id = $2", StatusConfirmed, orderID)
+	return err
+}
+
+// ConfirmSalesOrder godoc
+// @Summary Confirm sales order
+// @Description Reserve stok untuk semua baris sales order DRAFT. Stok yang di-reserve tidak bisa dipakai OUT lain sampai order dikirim atau dibatalkan.
+// @Tags sales-orders
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Success 200 {object} services.SalesOrderStatusSuccessResp
+// @Failure 400 {object} services.SalesOrderStatusFailResp
+// @Failure 404 {object} services.SalesOrderStatusFailResp
+// @Failure 409 {object} services.SalesOrderStatusFailResp
+// @Failure 500 {object} services.SalesOrderStatusFailResp
+// @Router /stocklab-api/v1/sales-orders/confirm/{id} [post]
+// @Security BearerAuth
+func ConfirmSalesOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid sales order id")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	status, warehouseID, err := lockSalesOrder(tx, orderID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "sales order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status != StatusDraft {
+		utils.RespondError(w, http.StatusConflict, "Sales order is already "+status)
+		return
+	}
+
+	if err := confirmSalesOrder(tx, orderID, warehouseID); err != nil {
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, nil, "Sales order confirmed successfully")
+}
+
+// CancelSalesOrder godoc
+// @Summary Cancel sales order
+// @Description Batalkan sales order yang belum selesai dikirim. Reservasi untuk sisa baris dilepas; barang yang sudah dikirim tidak dikembalikan.
+// @Tags sales-orders
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Success 200 {object} services.SalesOrderStatusSuccessResp
+// @Failure 400 {object} services.SalesOrderStatusFailResp
+// @Failure 403 {object} services.SalesOrderStatusFailResp
+// @Failure 404 {object} services.SalesOrderStatusFailResp
+// @Failure 409 {object} services.SalesOrderStatusFailResp
+// @Failure 500 {object} services.SalesOrderStatusFailResp
+// @Router /stocklab-api/v1/sales-orders/cancel/{id} [post]
+// @Security BearerAuth
+func CancelSalesOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid sales order id")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	status, warehouseID, err := lockSalesOrder(tx, orderID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "sales order not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status != StatusDraft && !holdsReservation(status) {
+		utils.RespondError(w, http.StatusConflict, "Sales order is already "+status)
+		return
+	}
+
+	if holdsReservation(status) {
+		lines, balances, err := lockOutstanding(tx, orderID, warehouseID)
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+		for _, l := range lines {
+			if err := stock.Release(tx, balances, stock.Key{ProductID: l.productID, WarehouseID: warehouseID}, l.outstanding); err != nil {
+				utils.RespondError(w, stock.StatusCode(err), err.Error())
+				return
+			}
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE sales_orders SET status = $1, updated_at = NOW() WHERE id = $2", StatusCancelled, orderID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to cancel sales order: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, nil, "Sales order cancelled successfully")
+}
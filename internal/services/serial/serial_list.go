@@ -0,0 +1,118 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type SerialListData struct {
+	ID           int64     `json:"id" example:"1"`
+	SerialNumber string    `json:"serial_number" example:"SN-0001"`
+	ProductID    int64     `json:"product_id" example:"3"`
+	ProductName  string    `json:"product_name" example:"Barcode Scanner Zebra DS2208"`
+	ProductSKU   string    `json:"product_sku" example:"SKU-20251214201530-042"`
+	WarehouseID  int64     `json:"warehouse_id" example:"1"` // lokasi terakhir
+	Warehouse    string    `json:"warehouse" example:"Main Warehouse"`
+	Status       string    `json:"status" example:"IN_STOCK"` // IN_STOCK | OUT
+	UpdatedAt    time.Time `json:"updated_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type SerialListSuccessResp struct {
+	Status  string           `json:"status" example:"success"`
+	Message string           `json:"message" example:"Serial numbers fetched successfully"`
+	Data    []SerialListData `json:"data"`
+}
+
+type SerialListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch serial numbers"`
+}
+
+const serialSelect = `
+	SELECT sn.id, sn.serial_number, sn.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''),
+		sn.warehouse_id, COALESCE(wh.name, ''), sn.status, sn.updated_at
+	FROM serial_numbers sn
+	LEFT JOIN products p ON p.id = sn.product_id
+	LEFT JOIN warehouses wh ON wh.id = sn.warehouse_id
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSerial(row rowScanner, s *SerialListData) error {
+	return row.Scan(&s.ID, &s.SerialNumber, &s.ProductID, &s.ProductName, &s.ProductSKU,
+		&s.WarehouseID, &s.Warehouse, &s.Status, &s.UpdatedAt)
+}
+
+// GetSerialList godoc
+// @Summary List serial numbers
+// @Description List nomor seri product serialized beserta lokasi terakhir dan statusnya
+// @Tags serials
+// @Produce json
+// @Param product_id query int false "Product ID"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param status query string false "IN_STOCK | OUT"
+// @Success 200 {object} services.SerialListSuccessResp
+// @Failure 400 {object} services.SerialListFailResp
+// @Failure 500 {object} services.SerialListFailResp
+// @Router /stocklab-api/v1/serials [get]
+// @Security BearerAuth
+func GetSerialList(w http.ResponseWriter, r *http.Request) {
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+
+	var args []interface{}
+	conditions := []string{}
+
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, "sn.status = $"+strconv.Itoa(len(args)))
+	}
+	for _, param := range []string{"product_id", "warehouse_id"} {
+		val := r.URL.Query().Get(param)
+		if val == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, param+" must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "sn."+param+" = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.DB.Query(serialSelect+where+" ORDER BY sn.product_id, sn.serial_number", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching serial numbers: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []SerialListData{}
+	for rows.Next() {
+		var s SerialListData
+		if err := scanSerial(rows, &s); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing serial numbers: "+err.Error())
+			return
+		}
+		data = append(data, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading serial numbers: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Serial numbers fetched successfully")
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// Satu baris transactions yang memindahkan unit ini
+type SerialMovement struct {
+	TransactionID int64     `json:"transaction_id" example:"12"`
+	MoveType      string    `json:"move_type" example:"OUT"`
+	WarehouseID   int64     `json:"warehouse_id" example:"1"`
+	Warehouse     string    `json:"warehouse" example:"Main Warehouse"`
+	TransferID    *int64    `json:"transfer_id" example:"1"`
+	DocumentID    *int64    `json:"document_id" example:"7"`
+	DocumentRef   *string   `json:"document_ref" example:"GI-7"`
+	ReasonCode    *string   `json:"reason_code" example:"DAMAGE"`
+	Notes         *string   `json:"notes" example:"Dikirim ke customer"`
+	PICName       string    `json:"pic_name" example:"John Doe"`
+	CreatedAt     time.Time `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type SerialDetail struct {
+	SerialListData
+	History []SerialMovement `json:"history"`
+}
+
+type SerialLookupSuccessResp struct {
+	Status  string         `json:"status" example:"success"`
+	Message string         `json:"message" example:"Serial number fetched successfully"`
+	Data    []SerialDetail `json:"data"`
+}
+
+type SerialLookupFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"serial number not found"`
+}
+
+// GetSerialLookup godoc
+// @Summary Serial number lookup
+// @Description Cari satu unit berdasarkan nomor seri dan tampilkan seluruh riwayat pergerakannya dari tabel transactions. Nomor seri unik per product, jadi tanpa product_id hasilnya bisa lebih dari satu unit.
+// @Tags serials
+// @Produce json
+// @Param serial_number path string true "Serial number"
+// @Param product_id query int false "Product ID"
+// @Success 200 {object} services.SerialLookupSuccessResp
+// @Failure 400 {object} services.SerialLookupFailResp
+// @Failure 404 {object} services.SerialLookupFailResp
+// @Failure 500 {object} services.SerialLookupFailResp
+// @Router /stocklab-api/v1/serials/lookup/{serial_number} [get]
+// @Security BearerAuth
+func GetSerialLookup(w http.ResponseWriter, r *http.Request) {
+	serial := strings.TrimSpace(chi.URLParam(r, "serial_number"))
+	if serial == "" {
+		utils.RespondError(w, http.StatusBadRequest, "serial_number is required")
+		return
+	}
+
+	args := []interface{}{serial}
+	where := " WHERE sn.serial_number = $1"
+	if val := r.URL.Query().Get("product_id"); val != "" {
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "product_id must be number")
+			return
+		}
+		args = append(args, id)
+		where += " AND sn.product_id = $2"
+	}
+
+	rows, err := db.DB.Query(serialSelect+where+" ORDER BY sn.product_id", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching serial number: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []SerialDetail{}
+	for rows.Next() {
+		var d SerialDetail
+		if err := scanSerial(rows, &d.SerialListData); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing serial number: "+err.Error())
+			return
+		}
+		data = append(data, d)
+	}
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading serial number: "+err.Error())
+		return
+	}
+	rows.Close()
+
+	if len(data) == 0 {
+		utils.RespondError(w, http.StatusNotFound, "serial number not found")
+		return
+	}
+
+	for i := range data {
+		data[i].History, err = loadSerialHistory(data[i].ID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed fetching serial history: "+err.Error())
+			return
+		}
+	}
+
+	utils.RespondSuccess(w, data, "Serial number fetched successfully")
+}
+
+// loadSerialHistory mengambil semua transaksi yang menyentuh satu unit, urut kronologis
+func loadSerialHistory(serialID int64) ([]SerialMovement, error) {
+	rows, err := db.DB.Query(`
+		SELECT tr.id, tr.move_type, tr.warehouse_id, COALESCE(wh.name, ''), tr.transfer_id, tr.document_id,
+			d.reference_no, rc.code, tr.notes, COALESCE(u.name, ''), tr.created_at
+		FROM transaction_serials ts
+		JOIN transactions tr ON tr.id = ts.transaction_id
+		LEFT JOIN warehouses wh ON wh.id = tr.warehouse_id
+		LEFT JOIN stock_documents d ON d.id = tr.document_id
+		LEFT JOIN reason_codes rc ON rc.id = tr.reason_code_id
+		LEFT JOIN users u ON u.id = tr.user_id
+		WHERE ts.serial_id = $1
+		ORDER BY tr.created_at, tr.id
+	`, serialID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []SerialMovement{}
+	for rows.Next() {
+		var m SerialMovement
+		if err := rows.Scan(&m.TransactionID, &m.MoveType, &m.WarehouseID, &m.Warehouse, &m.TransferID, &m.DocumentID,
+			&m.DocumentRef, &m.ReasonCode, &m.Notes, &m.PICName, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, m)
+	}
+
+	return history, rows.Err()
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type SKUPatternDeleteSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"SKU pattern deleted successfully"`
+}
+
+// DeleteSKUPattern godoc
+// @Summary Delete SKU pattern
+// @Description Hapus pola SKU category; product baru di category tersebut kembali memakai pola default. Pola default tidak bisa dihapus, hanya diganti.
+// @Tags sku-patterns
+// @Produce json
+// @Param id path int true "SKU pattern ID"
+// @Success 200 {object} services.SKUPatternDeleteSuccessResp
+// @Failure 400 {object} services.SKUPatternFailResp
+// @Failure 404 {object} services.SKUPatternFailResp
+// @Failure 500 {object} services.SKUPatternFailResp
+// @Router /stocklab-api/v1/sku-patterns/delete/{id} [delete]
+// @Security BearerAuth
+func DeleteSKUPattern(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "SKU pattern Id must be a number")
+		return
+	}
+
+	var isDefault bool
+	err = db.DB.QueryRow("SELECT category_id IS NULL FROM sku_patterns WHERE id=$1", id).Scan(&isDefault)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			utils.RespondError(w, http.StatusNotFound, "SKU pattern not found")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if isDefault {
+		utils.RespondError(w, http.StatusBadRequest, "The default SKU pattern cannot be deleted")
+		return
+	}
+
+	if _, err := db.DB.Exec("DELETE FROM sku_patterns WHERE id=$1", id); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete SKU pattern: "+err.Error())
+		return
+	}
+
+	// Response sukses
+	response := map[string]interface{}{
+		"id": id,
+	}
+	utils.RespondSuccess(w, response, "SKU pattern deleted successfully")
+}
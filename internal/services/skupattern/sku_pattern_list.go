@@ -0,0 +1,78 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/sku"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Pola penomoran SKU, category_id kosong = pola default
+type SKUPattern struct {
+	ID           int64  `json:"id" example:"1"`
+	CategoryID   *int64 `json:"category_id" example:"3"`
+	CategoryName string `json:"category_name,omitempty" example:"Mie"`
+	Pattern      string `json:"pattern" example:"{CATEGORY}-{BRAND}-{SEQ:5}"`
+	Example      string `json:"example" example:"MIE-MIE-00001"` // hasil pola dengan nomor urut 1
+}
+
+type SKUPatternSuccessResp struct {
+	Status  string       `json:"status" example:"success"`
+	Message string       `json:"message" example:"SKU patterns fetched successfully"`
+	Data    []SKUPattern `json:"data"`
+}
+
+type SKUPatternFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch SKU patterns"`
+}
+
+// GetSKUPatternList godoc
+// @Summary Get list of SKU patterns
+// @Description Pola penomoran SKU product baru. Token: {CATEGORY} (sku_code category atau 3 huruf pertama namanya), {BRAND} (3 huruf pertama brand), {SEQ} atau {SEQ:n} (nomor urut database, zero-padded n digit). Pola category dipakai jika ada, selain itu pola default.
+// @Tags sku-patterns
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} services.SKUPatternSuccessResp
+// @Failure 500 {object} services.SKUPatternFailResp
+// @Router /stocklab-api/v1/sku-patterns [get]
+// @Security BearerAuth
+func GetSKUPatternList(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.DB.Query(`
+		SELECT sp.id, sp.category_id, COALESCE(c.name, ''), COALESCE(c.sku_code, ''), sp.pattern
+		FROM sku_patterns sp
+		LEFT JOIN categories c ON c.id = sp.category_id
+		ORDER BY sp.category_id NULLS FIRST
+	`)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch SKU patterns: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	patterns := []SKUPattern{}
+
+	for rows.Next() {
+		var p SKUPattern
+		var code string
+		if err := rows.Scan(&p.ID, &p.CategoryID, &p.CategoryName, &code, &p.Pattern); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan SKU patterns: "+err.Error())
+			return
+		}
+		if code == "" {
+			code = sku.Code(p.CategoryName)
+		}
+		p.Example = sku.Render(p.Pattern, code, sku.Code("Brand"), 1)
+
+		patterns = append(patterns, p)
+	}
+
+	// Cek apakah ada error saat iterasi rows
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading SKU patterns: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, patterns, "SKU patterns fetched successfully")
+}
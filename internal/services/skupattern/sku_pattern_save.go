@@ -0,0 +1,91 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/sku"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type SKUPatternSaveSuccessResp struct {
+	Status  string     `json:"status" example:"success"`
+	Message string     `json:"message" example:"SKU pattern saved successfully"`
+	Data    SKUPattern `json:"data"`
+}
+
+// SaveSKUPattern godoc
+// @Summary Save SKU pattern
+// @Description Buat atau ganti pola SKU untuk satu category, atau pola default jika category_id kosong. Pola harus berisi tepat satu token {SEQ} supaya SKU selalu unik. Hanya berlaku untuk product baru.
+// @Tags sku-patterns
+// @Accept multipart/form-data
+// @Produce json
+// @Param category_id formData int false "category_id, kosong = pola default"
+// @Param pattern formData string true "pattern, mis. {CATEGORY}-{BRAND}-{SEQ:5}"
+// @Success 200 {object} services.SKUPatternSaveSuccessResp
+// @Failure 400 {object} services.SKUPatternFailResp
+// @Failure 404 {object} services.SKUPatternFailResp
+// @Failure 500 {object} services.SKUPatternFailResp
+// @Router /stocklab-api/v1/sku-patterns/save [put]
+// @Security BearerAuth
+func SaveSKUPattern(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	pattern := strings.TrimSpace(r.FormValue("pattern"))
+	if err := sku.ValidatePattern(pattern); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// category_id OPTIONAL
+	var categoryID *int64
+	if val := strings.TrimSpace(r.FormValue("category_id")); val != "" {
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "category_id must be number")
+			return
+		}
+
+		var exists bool
+		err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE id=$1)", id).Scan(&exists)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+			return
+		}
+		if !exists {
+			utils.RespondError(w, http.StatusNotFound, "Category not found")
+			return
+		}
+		categoryID = &id
+	}
+
+	// Satu pola per category (dan satu pola default)
+	var p SKUPattern
+	var code string
+	err := db.DB.QueryRow(`
+		WITH saved AS (
+			INSERT INTO sku_patterns (category_id, pattern) VALUES ($1, $2)
+			ON CONFLICT ((COALESCE(category_id, 0))) DO UPDATE SET pattern = EXCLUDED.pattern, updated_at = NOW()
+			RETURNING id, category_id, pattern
+		)
+		SELECT saved.id, saved.category_id, COALESCE(c.name, ''), COALESCE(c.sku_code, ''), saved.pattern
+		FROM saved
+		LEFT JOIN categories c ON c.id = saved.category_id
+	`, categoryID, pattern).Scan(&p.ID, &p.CategoryID, &p.CategoryName, &code, &p.Pattern)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to save SKU pattern: "+err.Error())
+		return
+	}
+	if code == "" {
+		code = sku.Code(p.CategoryName)
+	}
+	p.Example = sku.Render(p.Pattern, code, sku.Code("Brand"), 1)
+
+	utils.RespondSuccess(w, p, "SKU pattern saved successfully")
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+type StockCountAdjustment struct {
+	ProductID     int64    `json:"product_id" example:"1"`
+	Variance      int64    `json:"variance" example:"-2"`
+	MoveType      string   `json:"move_type" example:"ADJ_OUT"`
+	TransactionID int64    `json:"transaction_id" example:"10"`
+	Serials       []string `json:"serial_numbers,omitempty"` // hanya product serialized
+}
+
+type StockCountApproveData struct {
+	ID          int64                  `json:"id" example:"1"`
+	Status      string                 `json:"status" example:"APPROVED"`
+	ApprovedAt  time.Time              `json:"approved_at" example:"2024-12-15T08:00:00Z"`
+	Adjustments []StockCountAdjustment `json:"adjustments"`
+}
+
+type StockCountApproveSuccessResp struct {
+	Status  string                `json:"status" example:"success"`
+	Message string                `json:"message" example:"Stock count approved successfully"`
+	Data    StockCountApproveData `json:"data"`
+}
+
+type StockCountApproveFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to approve stock count"`
+}
+
+type countedLine struct {
+	productID  int64
+	variance   int64
+	serialized bool
+}
+
+// ApproveStockCount godoc
+// @Summary Approve stock count
+// @Description Posting selisih (counted - expected snapshot) sebagai ADJ_IN / ADJ_OUT dengan reason COUNT. Semua baris harus sudah dihitung. Pergerakan stok setelah snapshot tetap dipertahankan karena yang diposting hanya selisihnya. Untuk product serialized yang diposting per nomor seri: expected yang tidak ditemukan dan masih IN_STOCK keluar (ADJ_OUT), yang ditemukan tapi tidak tercatat di warehouse ini masuk (ADJ_IN).
+// @Tags stock-counts
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Success 200 {object} services.StockCountApproveSuccessResp
+// @Failure 400 {object} services.StockCountApproveFailResp
+// @Failure 401 {object} services.StockCountApproveFailResp
+// @Failure 403 {object} services.StockCountApproveFailResp
+// @Failure 404 {object} services.StockCountApproveFailResp
+// @Failure 409 {object} services.StockCountApproveFailResp
+// @Failure 500 {object} services.StockCountApproveFailResp
+// @Router /stocklab-api/v1/stock-counts/approve/{id} [post]
+// @Security BearerAuth
+func ApproveStockCount(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	countID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid stock count id")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	status, warehouseID, err := lockStockCount(tx, countID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "stock count not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status != StatusOpen {
+		utils.RespondError(w, http.StatusConflict, "Stock count is already "+status)
+		return
+	}
+
+	var uncounted int64
+	err = tx.QueryRow(
+		"SELECT COUNT(*) FROM stock_count_lines WHERE stock_count_id = $1 AND counted_quantity IS NULL",
+		countID,
+	).Scan(&uncounted)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if uncounted > 0 {
+		utils.RespondError(w, http.StatusBadRequest, strconv.FormatInt(uncounted, 10)+" line(s) have not been counted yet")
+		return
+	}
+
+	// Product serialized selalu diperiksa karena nomor seri bisa tertukar walaupun jumlahnya sama
+	rows, err := tx.Query(`
+		SELECT l.product_id, l.counted_quantity - l.expected_quantity, COALESCE(p.is_serialized, FALSE)
+		FROM stock_count_lines l
+		LEFT JOIN products p ON p.id = l.product_id
+		WHERE l.stock_count_id = $1 AND (l.counted_quantity <> l.expected_quantity OR p.is_serialized)
+		ORDER BY l.product_id
+	`, countID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	lines := []countedLine{}
+	for rows.Next() {
+		var l countedLine
+		if err := rows.Scan(&l.productID, &l.variance, &l.serialized); err != nil {
+			rows.Close()
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		lines = append(lines, l)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := StockCountApproveData{ID: countID, Status: StatusApproved, Adjustments: []StockCountAdjustment{}}
+
+	if len(lines) > 0 {
+		keys := make([]stock.Key, 0, len(lines))
+		for _, l := range lines {
+			keys = append(keys, stock.Key{ProductID: l.productID, WarehouseID: warehouseID})
+		}
+		balances, err := stock.Lock(tx, keys)
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+
+		notes := "Stock count #" + strconv.FormatInt(countID, 10)
+		post := func(productID, variance int64, serials []string) error {
+			adj := StockCountAdjustment{ProductID: productID, Variance: variance, MoveType: stock.MoveAdjustIn, Serials: serials}
+			qty := variance
+			if variance < 0 {
+				adj.MoveType = stock.MoveAdjustOut
+				qty = -variance
+			}
+
+			reasonID, err := stock.FindReason(tx, stock.ReasonCount, adj.MoveType)
+			if err != nil {
+				return err
+			}
+
+			adj.TransactionID, err = stock.Apply(tx, balances, stock.Movement{
+				Key:      stock.Key{ProductID: productID, WarehouseID: warehouseID},
+				UserID:   principal.UserID,
+				Quantity: qty,
+				MoveType: adj.MoveType,
+				ReasonID: reasonID,
+				CountID:  countID,
+				Notes:    notes,
+				Serials:  serials,
+			})
+			if err != nil {
+				return err
+			}
+
+			// Product serialized bisa punya dua adjustment (keluar dan masuk), jadi tautannya
+			// disimpan per nomor seri dan transaction_id baris dibiarkan NULL
+			if len(serials) > 0 {
+				_, err = tx.Exec(
+					"UPDATE stock_count_serials SET transaction_id = $1 WHERE stock_count_id = $2 AND product_id = $3 AND serial_number = ANY($4)",
+					adj.TransactionID, countID, productID, pq.Array(serials),
+				)
+			} else {
+				_, err = tx.Exec(
+					"UPDATE stock_count_lines SET transaction_id = $1, updated_at = NOW() WHERE stock_count_id = $2 AND product_id = $3",
+					adj.TransactionID, countID, productID,
+				)
+			}
+			if err != nil {
+				return err
+			}
+
+			resp.Adjustments = append(resp.Adjustments, adj)
+			return nil
+		}
+
+		for _, l := range lines {
+			if !l.serialized {
+				if err := post(l.productID, l.variance, nil); err != nil {
+					utils.RespondError(w, stock.StatusCode(err), err.Error())
+					return
+				}
+				continue
+			}
+
+			// Serialized: nomor seri yang hilang keluar, nomor seri yang ditemukan masuk
+			missing, found, err := serialVariance(tx, countID, l.productID, warehouseID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			if len(missing) > 0 {
+				if err := post(l.productID, -int64(len(missing)), missing); err != nil {
+					utils.RespondError(w, stock.StatusCode(err), err.Error())
+					return
+				}
+			}
+			if len(found) > 0 {
+				if err := post(l.productID, int64(len(found)), found); err != nil {
+					utils.RespondError(w, stock.StatusCode(err), err.Error())
+					return
+				}
+			}
+		}
+	}
+
+	err = tx.QueryRow(`
+		UPDATE stock_counts SET status = $1, approved_by = $2, approved_at = NOW(), updated_at = NOW()
+		WHERE id = $3
+		RETURNING approved_at
+	`, StatusApproved, principal.UserID, countID).Scan(&resp.ApprovedAt)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to approve stock count: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Stock count approved successfully")
+}
+
+type StockCountCancelSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Stock count cancelled successfully"`
+}
+
+// CancelStockCount godoc
+// @Summary Cancel stock count
+// @Description Batalkan sesi stock opname yang masih OPEN tanpa posting adjustment
+// @Tags stock-counts
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Success 200 {object} services.StockCountCancelSuccessResp
+// @Failure 400 {object} services.StockCountApproveFailResp
+// @Failure 403 {object} services.StockCountApproveFailResp
+// @Failure 404 {object} services.StockCountApproveFailResp
+// @Failure 409 {object} services.StockCountApproveFailResp
+// @Failure 500 {object} services.StockCountApproveFailResp
+// @Router /stocklab-api/v1/stock-counts/cancel/{id} [post]
+// @Security BearerAuth
+func CancelStockCount(w http.ResponseWriter, r *http.Request) {
+	countID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid stock count id")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	status, _, err := lockStockCount(tx, countID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "stock count not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status != StatusOpen {
+		utils.RespondError(w, http.StatusConflict, "Stock count is already "+status)
+		return
+	}
+
+	if _, err := tx.Exec("UPDATE stock_counts SET status = $1, updated_at = NOW() WHERE id = $2", StatusCancelled, countID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to cancel stock count: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, nil, "Stock count cancelled successfully")
+}
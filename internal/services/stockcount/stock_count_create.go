@@ -0,0 +1,150 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+const (
+	StatusOpen      = "OPEN"
+	StatusApproved  = "APPROVED"
+	StatusCancelled = "CANCELLED"
+)
+
+type StockCountCreateRequest struct {
+	WarehouseID int64  `json:"warehouse_id" example:"1"`
+	CategoryID  int64  `json:"category_id" example:"0"` // 0 = semua category
+	Notes       string `json:"notes" example:"Stock opname akhir bulan"`
+}
+
+type StockCountCreateData struct {
+	ID          int64     `json:"id" example:"1"`
+	WarehouseID int64     `json:"warehouse_id" example:"1"`
+	CategoryID  *int64    `json:"category_id" example:"2"`
+	Status      string    `json:"status" example:"OPEN"`
+	Notes       string    `json:"notes" example:"Stock opname akhir bulan"`
+	TotalLines  int64     `json:"total_lines" example:"25"`
+	CreatedAt   time.Time `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type StockCountCreateSuccessResp struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Stock count created successfully"`
+	Data    StockCountCreateData `json:"data"`
+}
+
+type StockCountCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to create stock count"`
+}
+
+// CreateStockCount godoc
+// @Summary Create stock count
+// @Description Buka sesi stock opname untuk satu warehouse (opsional dibatasi satu category). Expected quantity dibekukan dari stocks saat sesi dibuat, untuk product serialized juga nomor seri yang IN_STOCK.
+// @Tags stock-counts
+// @Accept json
+// @Produce json
+// @Param body body services.StockCountCreateRequest true "Stock count scope"
+// @Success 200 {object} services.StockCountCreateSuccessResp
+// @Failure 400 {object} services.StockCountCreateFailResp
+// @Failure 401 {object} services.StockCountCreateFailResp
+// @Failure 500 {object} services.StockCountCreateFailResp
+// @Router /stocklab-api/v1/stock-counts/create [post]
+// @Security BearerAuth
+func CreateStockCount(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req StockCountCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	if req.WarehouseID == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "warehouse_id is required")
+		return
+	}
+
+	var categoryID sql.NullInt64
+	if req.CategoryID != 0 {
+		categoryID = sql.NullInt64{Int64: req.CategoryID, Valid: true}
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM warehouses WHERE id = $1)", req.WarehouseID).Scan(&exists); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !exists {
+		utils.RespondError(w, http.StatusBadRequest, "warehouse not found")
+		return
+	}
+
+	resp := StockCountCreateData{
+		WarehouseID: req.WarehouseID,
+		Status:      StatusOpen,
+		Notes:       req.Notes,
+	}
+	if categoryID.Valid {
+		resp.CategoryID = &categoryID.Int64
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO stock_counts (warehouse_id, category_id, status, notes, created_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id, created_at
+	`, req.WarehouseID, categoryID, StatusOpen, req.Notes, principal.UserID).Scan(&resp.ID, &resp.CreatedAt)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create stock count: "+err.Error())
+		return
+	}
+
+	// Snapshot expected quantity dari stocks saat ini
+	res, err := tx.Exec(`
+		INSERT INTO stock_count_lines (stock_count_id, product_id, expected_quantity)
+		SELECT $1, s.product_id, s.quantity
+		FROM stocks s
+		JOIN products p ON p.id = s.product_id
+		WHERE s.warehouse_id = $2 AND ($3::bigint IS NULL OR p.category_id = $3)
+	`, resp.ID, req.WarehouseID, categoryID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to snapshot stocks: "+err.Error())
+		return
+	}
+
+	resp.TotalLines, _ = res.RowsAffected()
+	if resp.TotalLines == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "No products to count for this scope")
+		return
+	}
+
+	// Product serialized juga dibekukan per nomor seri
+	if err := snapshotSerials(tx, resp.ID, req.WarehouseID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to snapshot serial numbers: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Stock count created successfully")
+}
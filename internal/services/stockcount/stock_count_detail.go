@@ -0,0 +1,115 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+)
+
+type StockCountLine struct {
+	ProductID        int64   `json:"product_id" example:"1"`
+	ProductName      string  `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU       string  `json:"product_sku" example:"SKU-20251214201530-042"`
+	ExpectedQuantity int64   `json:"expected_quantity" example:"40"`
+	CountedQuantity  *int64  `json:"counted_quantity" example:"38"` // null = belum dihitung
+	Variance         *int64  `json:"variance" example:"-2"`
+	CountedBy        string  `json:"counted_by" example:"John Doe"`
+	TransactionID    *int64  `json:"transaction_id" example:"10"` // adjustment hasil approve, NULL untuk product serialized
+	TransactionIDs   []int64 `json:"transaction_ids,omitempty"`   // product serialized: adjustment keluar dan / atau masuk per nomor seri
+}
+
+type StockCountDetail struct {
+	StockCountListData
+	Lines []StockCountLine `json:"lines"`
+}
+
+type StockCountDetailSuccessResp struct {
+	Status  string           `json:"status" example:"success"`
+	Message string           `json:"message" example:"Stock count fetched successfully"`
+	Data    StockCountDetail `json:"data"`
+}
+
+type StockCountDetailFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch stock count"`
+}
+
+// GetStockCountDetail godoc
+// @Summary Stock count detail
+// @Description Menampilkan header stock opname beserta expected, counted dan selisih per product
+// @Tags stock-counts
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Success 200 {object} services.StockCountDetailSuccessResp
+// @Failure 400 {object} services.StockCountDetailFailResp
+// @Failure 404 {object} services.StockCountDetailFailResp
+// @Failure 500 {object} services.StockCountDetailFailResp
+// @Router /stocklab-api/v1/stock-counts/detail/{id} [get]
+// @Security BearerAuth
+func GetStockCountDetail(w http.ResponseWriter, r *http.Request) {
+	countID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid stock count id")
+		return
+	}
+
+	var d StockCountDetail
+	err = scanStockCount(db.DB.QueryRow(stockCountSelect+" WHERE sc.id = $1", countID), &d.StockCountListData)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "stock count not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	rows, err := db.DB.Query(`
+		SELECT l.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), l.expected_quantity, l.counted_quantity,
+			COALESCE(u.name, ''), l.transaction_id,
+			ARRAY(
+				SELECT DISTINCT cs.transaction_id FROM stock_count_serials cs
+				WHERE cs.stock_count_id = l.stock_count_id AND cs.product_id = l.product_id AND cs.transaction_id IS NOT NULL
+				ORDER BY cs.transaction_id
+			)
+		FROM stock_count_lines l
+		LEFT JOIN products p ON p.id = l.product_id
+		LEFT JOIN users u ON u.id = l.counted_by
+		WHERE l.stock_count_id = $1
+		ORDER BY p.name, l.product_id
+	`, countID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer rows.Close()
+
+	d.Lines = []StockCountLine{}
+	for rows.Next() {
+		var l StockCountLine
+		var serialTxIDs pq.Int64Array
+		if err := rows.Scan(&l.ProductID, &l.ProductName, &l.ProductSKU, &l.ExpectedQuantity, &l.CountedQuantity, &l.CountedBy, &l.TransactionID, &serialTxIDs); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if l.CountedQuantity != nil {
+			variance := *l.CountedQuantity - l.ExpectedQuantity
+			l.Variance = &variance
+		}
+		if len(serialTxIDs) > 0 {
+			l.TransactionIDs = serialTxIDs
+		}
+		d.Lines = append(d.Lines, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, d, "Stock count fetched successfully")
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type StockCountListData struct {
+	ID           int64      `json:"id" example:"1"`
+	WarehouseID  int64      `json:"warehouse_id" example:"1"`
+	Warehouse    string     `json:"warehouse" example:"Main Warehouse"`
+	Category     string     `json:"category" example:"Mie"` // kosong = semua category
+	Status       string     `json:"status" example:"OPEN"`
+	Notes        string     `json:"notes" example:"Stock opname akhir bulan"`
+	CreatedBy    string     `json:"created_by" example:"John Doe"`
+	ApprovedBy   string     `json:"approved_by" example:"Admin"`
+	ApprovedAt   *time.Time `json:"approved_at" example:"2024-12-15T08:00:00Z"`
+	TotalLines   int64      `json:"total_lines" example:"25"`
+	CountedLines int64      `json:"counted_lines" example:"20"`
+	CreatedAt    time.Time  `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type StockCountListSuccessResp struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Stock counts fetched successfully"`
+	Data    []StockCountListData `json:"data"`
+}
+
+type StockCountListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch stock counts"`
+}
+
+const stockCountSelect = `
+	SELECT sc.id, sc.warehouse_id, COALESCE(wh.name, ''), COALESCE(c.name, ''), sc.status, COALESCE(sc.notes, ''),
+		COALESCE(uc.name, ''), COALESCE(ua.name, ''), sc.approved_at,
+		(SELECT COUNT(*) FROM stock_count_lines l WHERE l.stock_count_id = sc.id),
+		(SELECT COUNT(*) FROM stock_count_lines l WHERE l.stock_count_id = sc.id AND l.counted_quantity IS NOT NULL),
+		sc.created_at
+	FROM stock_counts sc
+	LEFT JOIN warehouses wh ON wh.id = sc.warehouse_id
+	LEFT JOIN categories c ON c.id = sc.category_id
+	LEFT JOIN users uc ON uc.id = sc.created_by
+	LEFT JOIN users ua ON ua.id = sc.approved_by
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStockCount(row rowScanner, sc *StockCountListData) error {
+	return row.Scan(&sc.ID, &sc.WarehouseID, &sc.Warehouse, &sc.Category, &sc.Status, &sc.Notes,
+		&sc.CreatedBy, &sc.ApprovedBy, &sc.ApprovedAt, &sc.TotalLines, &sc.CountedLines, &sc.CreatedAt)
+}
+
+// GetStockCountList godoc
+// @Summary List stock counts
+// @Description List sesi stock opname
+// @Tags stock-counts
+// @Produce json
+// @Param status query string false "OPEN | APPROVED | CANCELLED"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Success 200 {object} services.StockCountListSuccessResp
+// @Failure 400 {object} services.StockCountListFailResp
+// @Failure 500 {object} services.StockCountListFailResp
+// @Router /stocklab-api/v1/stock-counts [get]
+// @Security BearerAuth
+func GetStockCountList(w http.ResponseWriter, r *http.Request) {
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+	warehouseID := r.URL.Query().Get("warehouse_id")
+
+	var args []interface{}
+	conditions := []string{}
+
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, "sc.status = $"+strconv.Itoa(len(args)))
+	}
+	if warehouseID != "" {
+		id, err := strconv.ParseInt(warehouseID, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "sc.warehouse_id = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.DB.Query(stockCountSelect+where+" ORDER BY sc.created_at DESC", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching stock counts: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []StockCountListData{}
+
+	for rows.Next() {
+		var sc StockCountListData
+		if err := scanStockCount(rows, &sc); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing stock counts: "+err.Error())
+			return
+		}
+
+		data = append(data, sc)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading stock counts: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Stock counts fetched successfully")
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/lib/pq"
+)
+
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// uniqueSerials membuang nomor seri kosong dan duplikat
+func uniqueSerials(serials []string) []string {
+	result := make([]string, 0, len(serials))
+	seen := map[string]bool{}
+	for _, s := range serials {
+		s = strings.TrimSpace(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		result = append(result, s)
+	}
+	return result
+}
+
+// snapshotSerials membekukan nomor seri IN_STOCK di warehouse untuk product serialized di sesi
+func snapshotSerials(tx *sql.Tx, countID, warehouseID int64) error {
+	_, err := tx.Exec(`
+		INSERT INTO stock_count_serials (stock_count_id, product_id, serial_number, expected)
+		SELECT l.stock_count_id, sn.product_id, sn.serial_number, TRUE
+		FROM stock_count_lines l
+		JOIN serial_numbers sn ON sn.product_id = l.product_id
+		WHERE l.stock_count_id = $1 AND sn.warehouse_id = $2 AND sn.status = $3
+	`, countID, warehouseID, stock.SerialInStock)
+	return err
+}
+
+// saveCountedSerials mengganti daftar nomor seri yang ditemukan saat hitung untuk satu product
+func saveCountedSerials(tx *sql.Tx, countID, productID int64, serials []string) error {
+	if _, err := tx.Exec(`
+		UPDATE stock_count_serials SET counted = FALSE WHERE stock_count_id = $1 AND product_id = $2
+	`, countID, productID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`
+		DELETE FROM stock_count_serials WHERE stock_count_id = $1 AND product_id = $2 AND NOT expected
+	`, countID, productID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO stock_count_serials (stock_count_id, product_id, serial_number, counted)
+		SELECT $1, $2, s, TRUE FROM UNNEST($3::text[]) AS s
+		ON CONFLICT (stock_count_id, product_id, serial_number) DO UPDATE SET counted = TRUE
+	`, countID, productID, pq.Array(serials))
+	return err
+}
+
+// serialVariance membandingkan hasil hitung dengan nomor seri yang sekarang IN_STOCK di warehouse.
+// missing: expected saat snapshot, tidak ditemukan dan masih tercatat di stok (unit yang sudah
+// keluar setelah snapshot diabaikan). found: ditemukan tapi tidak tercatat di stok warehouse ini.
+func serialVariance(q querier, countID, productID, warehouseID int64) (missing, found []string, err error) {
+	rows, err := q.Query(`
+		SELECT cs.serial_number, cs.counted
+		FROM stock_count_serials cs
+		LEFT JOIN serial_numbers sn ON sn.product_id = cs.product_id AND sn.serial_number = cs.serial_number
+			AND sn.warehouse_id = $3 AND sn.status = $4
+		WHERE cs.stock_count_id = $1 AND cs.product_id = $2
+			AND ((cs.expected AND NOT cs.counted AND sn.id IS NOT NULL) OR (cs.counted AND sn.id IS NULL))
+		ORDER BY cs.serial_number
+	`, countID, productID, warehouseID, stock.SerialInStock)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	missing, found = []string{}, []string{}
+	for rows.Next() {
+		var serial string
+		var counted bool
+		if err := rows.Scan(&serial, &counted); err != nil {
+			return nil, nil, err
+		}
+		if counted {
+			found = append(found, serial)
+		} else {
+			missing = append(missing, serial)
+		}
+	}
+	return missing, found, rows.Err()
+}
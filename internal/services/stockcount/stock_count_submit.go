@@ -0,0 +1,157 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type StockCountSubmitLine struct {
+	ProductID       int64    `json:"product_id" example:"1"`
+	CountedQuantity int64    `json:"counted_quantity" example:"38"`
+	SerialNumbers   []string `json:"serial_numbers"` // wajib untuk product serialized, jumlahnya = counted_quantity
+}
+
+type StockCountSubmitRequest struct {
+	Lines []StockCountSubmitLine `json:"lines"`
+}
+
+type StockCountSubmitSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Counts submitted successfully"`
+}
+
+type StockCountSubmitFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to submit counts"`
+}
+
+// lockStockCount mengunci header stock opname supaya submit dan approve tidak saling balapan
+func lockStockCount(tx *sql.Tx, countID int64) (status string, warehouseID int64, err error) {
+	err = tx.QueryRow(
+		"SELECT status, warehouse_id FROM stock_counts WHERE id = $1 FOR UPDATE",
+		countID,
+	).Scan(&status, &warehouseID)
+	return status, warehouseID, err
+}
+
+// SubmitStockCount godoc
+// @Summary Submit counted quantities
+// @Description Simpan hasil hitung fisik. Untuk product serialized kirim nomor seri yang ditemukan di serial_numbers. Boleh dikirim berkali-kali selama sesi masih OPEN; nilai terakhir yang dipakai.
+// @Tags stock-counts
+// @Accept json
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Param body body services.StockCountSubmitRequest true "Counted quantities"
+// @Success 200 {object} services.StockCountSubmitSuccessResp
+// @Failure 400 {object} services.StockCountSubmitFailResp
+// @Failure 401 {object} services.StockCountSubmitFailResp
+// @Failure 404 {object} services.StockCountSubmitFailResp
+// @Failure 409 {object} services.StockCountSubmitFailResp
+// @Failure 500 {object} services.StockCountSubmitFailResp
+// @Router /stocklab-api/v1/stock-counts/submit/{id} [post]
+// @Security BearerAuth
+func SubmitStockCount(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	countID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid stock count id")
+		return
+	}
+
+	var req StockCountSubmitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	if len(req.Lines) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "lines are required")
+		return
+	}
+	for _, line := range req.Lines {
+		if line.ProductID == 0 || line.CountedQuantity < 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid count line")
+			return
+		}
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	status, _, err := lockStockCount(tx, countID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "stock count not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status != StatusOpen {
+		utils.RespondError(w, http.StatusConflict, "Stock count is already "+status)
+		return
+	}
+
+	for _, line := range req.Lines {
+		var serialized bool
+		err := tx.QueryRow(`SELECT COALESCE(is_serialized, FALSE) FROM products WHERE id = $1`, line.ProductID).Scan(&serialized)
+		if err != nil && err != sql.ErrNoRows {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		serials := uniqueSerials(line.SerialNumbers)
+		if !serialized && len(serials) > 0 {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" is not serialized")
+			return
+		}
+		if serialized && int64(len(serials)) != line.CountedQuantity {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+": "+
+				strconv.Itoa(len(serials))+" unique serial number(s) given for counted quantity "+strconv.FormatInt(line.CountedQuantity, 10))
+			return
+		}
+
+		res, err := tx.Exec(`
+			UPDATE stock_count_lines
+			SET counted_quantity = $1, counted_by = $2, counted_at = NOW(), updated_at = NOW()
+			WHERE stock_count_id = $3 AND product_id = $4
+		`, line.CountedQuantity, principal.UserID, countID, line.ProductID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to submit counts: "+err.Error())
+			return
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			utils.RespondError(w, http.StatusBadRequest, "product "+strconv.FormatInt(line.ProductID, 10)+" is not part of this stock count")
+			return
+		}
+
+		if serialized {
+			if err := saveCountedSerials(tx, countID, line.ProductID, serials); err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Failed to submit serial numbers: "+err.Error())
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, nil, "Counts submitted successfully")
+}
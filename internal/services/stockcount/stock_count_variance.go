@@ -0,0 +1,149 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type StockCountVarianceLine struct {
+	ProductID        int64        `json:"product_id" example:"1"`
+	ProductName      string       `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU       string       `json:"product_sku" example:"SKU-20251214201530-042"`
+	ExpectedQuantity int64        `json:"expected_quantity" example:"40"`
+	CountedQuantity  int64        `json:"counted_quantity" example:"38"`
+	Variance         int64        `json:"variance" example:"-2"`
+	UnitCost         money.Amount `json:"unit_cost" swaggertype:"number" example:"3000.00"` // OPEN: biaya satuan saat ini dari cost layer; selain itu: dari adjustment yang diposting
+	ValueImpact      money.Amount `json:"value_impact" swaggertype:"number" example:"-6000.00"`
+	MissingSerials   []string     `json:"missing_serial_numbers,omitempty"` // hanya product serialized, akan keluar saat approve
+	FoundSerials     []string     `json:"found_serial_numbers,omitempty"`   // hanya product serialized, akan masuk saat approve
+}
+
+type StockCountVarianceReport struct {
+	StockCountID  int64                    `json:"stock_count_id" example:"1"`
+	Status        string                   `json:"status" example:"OPEN"`
+	CountedLines  int64                    `json:"counted_lines" example:"20"`
+	VarianceLines int64                    `json:"variance_lines" example:"3"`
+	GainValue     money.Amount             `json:"gain_value" swaggertype:"number" example:"3000.00"`
+	LossValue     money.Amount             `json:"loss_value" swaggertype:"number" example:"-6000.00"`
+	NetValue      money.Amount             `json:"net_value" swaggertype:"number" example:"-3000.00"`
+	Lines         []StockCountVarianceLine `json:"lines"`
+}
+
+type StockCountVarianceSuccessResp struct {
+	Status  string                   `json:"status" example:"success"`
+	Message string                   `json:"message" example:"Variance report fetched successfully"`
+	Data    StockCountVarianceReport `json:"data"`
+}
+
+type StockCountVarianceFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch variance report"`
+}
+
+// GetStockCountVariance godoc
+// @Summary Stock count variance report
+// @Description Selisih per product yang sudah dihitung beserta dampak nilainya. Sesi OPEN dinilai dengan variance x biaya satuan saat ini dari cost layer product di warehouse stock opname; sesi yang sudah di-approve dinilai dari cost_value adjustment yang diposting
+// @Tags stock-counts
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Success 200 {object} services.StockCountVarianceSuccessResp
+// @Failure 400 {object} services.StockCountVarianceFailResp
+// @Failure 404 {object} services.StockCountVarianceFailResp
+// @Failure 500 {object} services.StockCountVarianceFailResp
+// @Router /stocklab-api/v1/stock-counts/variance/{id} [get]
+// @Security BearerAuth
+func GetStockCountVariance(w http.ResponseWriter, r *http.Request) {
+	countID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid stock count id")
+		return
+	}
+
+	report := StockCountVarianceReport{StockCountID: countID, Lines: []StockCountVarianceLine{}}
+	var warehouseID int64
+	err = db.DB.QueryRow("SELECT status, warehouse_id FROM stock_counts WHERE id = $1", countID).Scan(&report.Status, &warehouseID)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "stock count not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	rows, err := db.DB.Query(`
+		SELECT l.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), l.expected_quantity, l.counted_quantity,
+			`+stock.UnitCostSQL("l.product_id", "$2")+`, COALESCE(p.is_serialized, FALSE),
+			COALESCE((
+				SELECT SUM(`+stock.SignedCostSQL("tr")+`) FROM transactions tr
+				WHERE tr.stock_count_id = l.stock_count_id AND tr.product_id = l.product_id
+			), 0)
+		FROM stock_count_lines l
+		LEFT JOIN products p ON p.id = l.product_id
+		WHERE l.stock_count_id = $1 AND l.counted_quantity IS NOT NULL
+		ORDER BY p.name, l.product_id
+	`, countID, warehouseID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l StockCountVarianceLine
+		var serialized bool
+		var posted money.Amount
+		if err := rows.Scan(&l.ProductID, &l.ProductName, &l.ProductSKU, &l.ExpectedQuantity, &l.CountedQuantity, &l.UnitCost, &serialized, &posted); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		report.CountedLines++
+
+		l.Variance = l.CountedQuantity - l.ExpectedQuantity
+		if serialized && report.Status == StatusOpen {
+			l.MissingSerials, l.FoundSerials, err = serialVariance(db.DB, countID, l.ProductID, warehouseID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		// Sesi OPEN memakai estimasi biaya saat ini; setelah approve nilai diambil dari
+		// cost_value adjustment yang benar-benar diposting supaya laporan tidak berubah lagi
+		if report.Status == StatusOpen {
+			l.ValueImpact = l.UnitCost.Mul(l.Variance)
+		} else {
+			l.ValueImpact = posted
+			l.UnitCost = 0
+			if l.Variance != 0 {
+				l.UnitCost = posted.MulDiv(1, l.Variance)
+			}
+		}
+
+		// Nomor seri yang tertukar tetap ditampilkan walaupun jumlahnya sama
+		if l.Variance == 0 && len(l.MissingSerials) == 0 && len(l.FoundSerials) == 0 && l.ValueImpact == 0 {
+			continue
+		}
+
+		report.VarianceLines++
+		if l.ValueImpact > 0 {
+			report.GainValue += l.ValueImpact
+		} else {
+			report.LossValue += l.ValueImpact
+		}
+		report.NetValue += l.ValueImpact
+		report.Lines = append(report.Lines, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, report, "Variance report fetched successfully")
+}
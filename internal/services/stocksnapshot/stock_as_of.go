@@ -0,0 +1,175 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Stok product di satu warehouse pada waktu as_of, dengan cross-check ke stocks
+type StockAsOfWarehouse struct {
+	WarehouseID    int64  `json:"warehouse_id" example:"1"`
+	WarehouseCode  string `json:"warehouse_code" example:"MAIN"`
+	WarehouseName  string `json:"warehouse_name" example:"Main Warehouse"`
+	Quantity       int64  `json:"quantity" example:"50"`        // saldo pada as_of
+	LedgerQuantity int64  `json:"ledger_quantity" example:"42"` // saldo sekarang menurut snapshot + ledger
+	LiveQuantity   int64  `json:"live_quantity" example:"42"`   // stocks.quantity sekarang
+	InSync         bool   `json:"in_sync" example:"true"`
+}
+
+type StockAsOfProduct struct {
+	ProductID  int64                `json:"product_id" example:"1"`
+	SKU        string               `json:"sku" example:"SKU-000042"`
+	Name       string               `json:"name" example:"Mie Sedap Goreng"`
+	Quantity   int64                `json:"quantity" example:"150"` // total semua warehouse, satuan dasar
+	Warehouses []StockAsOfWarehouse `json:"warehouses"`
+}
+
+type StockAsOfReport struct {
+	AsOf          time.Time          `json:"as_of" example:"2025-12-31T23:59:59Z"`
+	SnapshotID    *int64             `json:"snapshot_id" example:"3"` // snapshot dasar, null = dihitung dari seluruh ledger
+	SnapshotAt    *time.Time         `json:"snapshot_at" example:"2025-11-30T23:59:59Z"`
+	TotalQuantity int64              `json:"total_quantity" example:"1500"`
+	InSync        bool               `json:"in_sync" example:"true"` // semua baris cocok dengan stocks
+	Mismatches    int                `json:"mismatches" example:"0"`
+	Products      []StockAsOfProduct `json:"products"`
+}
+
+type StockAsOfSuccessResp struct {
+	Status  string          `json:"status" example:"success"`
+	Message string          `json:"message" example:"Stock as of fetched successfully"`
+	Data    StockAsOfReport `json:"data"`
+}
+
+// GetStockAsOf godoc
+// @Summary Stock as of date
+// @Description Saldo stok per product dan warehouse pada waktu as_of, direkonstruksi dari snapshot terakhir sebelum as_of ditambah transaksi sesudahnya. Setiap baris di-cross-check: saldo sekarang menurut snapshot + ledger dibandingkan dengan stocks.quantity; baris yang tidak cocok selalu ditampilkan walaupun saldonya nol.
+// @Tags stock-snapshots
+// @Produce json
+// @Param as_of query string false "RFC3339 or YYYY-MM-DD (end of that day); default now"
+// @Param product_id query int false "Only this product"
+// @Param warehouse_id query int false "Only this warehouse"
+// @Param category_id query int false "Only products in this category"
+// @Success 200 {object} services.StockAsOfSuccessResp
+// @Failure 400 {object} services.StockSnapshotFailResp
+// @Failure 500 {object} services.StockSnapshotFailResp
+// @Router /stocklab-api/v1/stock-snapshots/as-of [get]
+// @Security BearerAuth
+func GetStockAsOf(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	// Waktu OPTIONAL, tanggal saja berarti akhir hari tersebut
+	asOf := time.Now().UTC()
+	if val := q.Get("as_of"); val != "" {
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			var day time.Time
+			day, err = time.Parse("2006-01-02", val)
+			t = day.Add(24*time.Hour - time.Second)
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "as_of must be RFC3339 or YYYY-MM-DD")
+			return
+		}
+		asOf = t
+	}
+
+	// Filter OPTIONAL
+	filters := map[string]interface{}{"product_id": nil, "warehouse_id": nil, "category_id": nil}
+	for name := range filters {
+		if val := q.Get(name); val != "" {
+			id, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				utils.RespondError(w, http.StatusBadRequest, name+" must be number")
+				return
+			}
+			filters[name] = id
+		}
+	}
+
+	report := StockAsOfReport{AsOf: asOf, InSync: true, Products: []StockAsOfProduct{}}
+
+	var snapshotID int64
+	var snapshotAt time.Time
+	err := db.DB.QueryRow(`SELECT id, snapshot_at FROM stock_snapshots WHERE snapshot_at <= $1 ORDER BY snapshot_at DESC LIMIT 1`, asOf).
+		Scan(&snapshotID, &snapshotAt)
+	if err == nil {
+		report.SnapshotID, report.SnapshotAt = &snapshotID, &snapshotAt
+	} else if err != sql.ErrNoRows {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch stock snapshot: "+err.Error())
+		return
+	}
+
+	// Saldo as_of, saldo sekarang menurut ledger dan stocks dibaca dalam satu statement
+	// supaya cross-check memakai data yang sama
+	rows, err := db.DB.Query(`
+		WITH h AS (
+			SELECT b.* FROM (`+stock.BalanceAsOfSQL("$1::timestamptz")+`) b
+			WHERE ($2::bigint IS NULL OR b.product_id = $2) AND ($3::bigint IS NULL OR b.warehouse_id = $3)
+		), c AS (
+			SELECT b.* FROM (`+stock.BalanceAsOfSQL("NOW()")+`) b
+			WHERE ($2::bigint IS NULL OR b.product_id = $2) AND ($3::bigint IS NULL OR b.warehouse_id = $3)
+		), s AS (
+			SELECT product_id, warehouse_id, quantity FROM stocks
+			WHERE ($2::bigint IS NULL OR product_id = $2) AND ($3::bigint IS NULL OR warehouse_id = $3)
+		), k AS (
+			SELECT product_id, warehouse_id FROM h
+			UNION SELECT product_id, warehouse_id FROM c
+			UNION SELECT product_id, warehouse_id FROM s
+		)
+		SELECT k.product_id, COALESCE(p.sku, ''), COALESCE(p.name, ''), k.warehouse_id, COALESCE(wh.code, ''), COALESCE(wh.name, ''),
+			COALESCE(h.quantity, 0), COALESCE(c.quantity, 0), COALESCE(s.quantity, 0)
+		FROM k
+		LEFT JOIN h ON h.product_id = k.product_id AND h.warehouse_id = k.warehouse_id
+		LEFT JOIN c ON c.product_id = k.product_id AND c.warehouse_id = k.warehouse_id
+		LEFT JOIN s ON s.product_id = k.product_id AND s.warehouse_id = k.warehouse_id
+		LEFT JOIN products p ON p.id = k.product_id
+		LEFT JOIN warehouses wh ON wh.id = k.warehouse_id
+		WHERE ($4::bigint IS NULL OR p.category_id = $4)
+			AND (COALESCE(h.quantity, 0) <> 0 OR COALESCE(c.quantity, 0) <> COALESCE(s.quantity, 0))
+		ORDER BY 3, 1, 4
+	`, asOf, filters["product_id"], filters["warehouse_id"], filters["category_id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch stock as of: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p StockAsOfProduct
+		var wh StockAsOfWarehouse
+		if err := rows.Scan(&p.ProductID, &p.SKU, &p.Name, &wh.WarehouseID, &wh.WarehouseCode, &wh.WarehouseName,
+			&wh.Quantity, &wh.LedgerQuantity, &wh.LiveQuantity); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan stock as of: "+err.Error())
+			return
+		}
+		wh.InSync = wh.LedgerQuantity == wh.LiveQuantity
+		if !wh.InSync {
+			report.InSync = false
+			report.Mismatches++
+		}
+
+		// Baris sudah urut per product
+		n := len(report.Products)
+		if n == 0 || report.Products[n-1].ProductID != p.ProductID {
+			p.Warehouses = []StockAsOfWarehouse{}
+			report.Products = append(report.Products, p)
+			n++
+		}
+		report.Products[n-1].Quantity += wh.Quantity
+		report.Products[n-1].Warehouses = append(report.Products[n-1].Warehouses, wh)
+		report.TotalQuantity += wh.Quantity
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading stock as of: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, report, "Stock as of fetched successfully")
+}
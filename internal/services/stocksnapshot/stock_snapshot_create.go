@@ -0,0 +1,99 @@
+package services
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/snapshot"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type StockSnapshotCreateRequest struct {
+	SnapshotAt string `json:"snapshot_at" example:"2025-12-31"` // RFC3339 atau YYYY-MM-DD (akhir hari tersebut), default akhir hari kemarin
+	Notes      string `json:"notes" example:"Tutup buku Desember"`
+}
+
+type StockSnapshotCreateSuccessResp struct {
+	Status  string            `json:"status" example:"success"`
+	Message string            `json:"message" example:"Stock snapshot created successfully"`
+	Data    StockSnapshotData `json:"data"`
+}
+
+type StockSnapshotFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"A snapshot already exists at this time"`
+}
+
+// CreateStockSnapshot godoc
+// @Summary Create stock snapshot
+// @Description Simpan saldo stok per product per warehouse pada waktu snapshot_at, dihitung dari snapshot sebelumnya ditambah transaksi sesudahnya. Snapshot mempercepat laporan stok per tanggal; snapshot juga dibuat otomatis setiap SNAPSHOT_INTERVAL, endpoint ini untuk titik tertentu seperti tutup buku akhir bulan. snapshot_at harus minimal 5 menit sebelum sekarang.
+// @Tags stock-snapshots
+// @Accept json
+// @Produce json
+// @Param body body services.StockSnapshotCreateRequest false "Snapshot time"
+// @Success 200 {object} services.StockSnapshotCreateSuccessResp
+// @Failure 400 {object} services.StockSnapshotFailResp
+// @Failure 401 {object} services.StockSnapshotFailResp
+// @Failure 409 {object} services.StockSnapshotFailResp
+// @Failure 500 {object} services.StockSnapshotFailResp
+// @Router /stocklab-api/v1/stock-snapshots/create [post]
+// @Security BearerAuth
+func CreateStockSnapshot(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Body OPTIONAL, kosong berarti snapshot akhir hari kemarin
+	var req StockSnapshotCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	snapshotAt := time.Now().UTC().Truncate(24 * time.Hour).Add(-time.Second)
+	if req.SnapshotAt != "" {
+		t, err := time.Parse(time.RFC3339, req.SnapshotAt)
+		if err != nil {
+			var day time.Time
+			day, err = time.Parse("2006-01-02", req.SnapshotAt)
+			t = day.Add(24*time.Hour - time.Second)
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "snapshot_at must be RFC3339 or YYYY-MM-DD")
+			return
+		}
+		snapshotAt = t
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	snapshotID, err := snapshot.Create(tx, snapshotAt, req.Notes, principal.UserID)
+	if err != nil {
+		utils.RespondError(w, snapshot.StatusCode(err), err.Error())
+		return
+	}
+
+	var data StockSnapshotData
+	if err := scanStockSnapshot(tx.QueryRow(stockSnapshotSelect+` WHERE ss.id = $1`, snapshotID), &data); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch stock snapshot: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Stock snapshot created successfully")
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type StockSnapshotDeleteSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Stock snapshot deleted successfully"`
+}
+
+// DeleteStockSnapshot godoc
+// @Summary Delete stock snapshot
+// @Description Hapus snapshot saldo stok, mis. jika cross-check menunjukkan snapshot tidak cocok dengan ledger. Laporan stok per tanggal tetap benar karena memakai snapshot sebelumnya, hanya lebih lambat.
+// @Tags stock-snapshots
+// @Produce json
+// @Param id path int true "Stock snapshot ID"
+// @Success 200 {object} services.StockSnapshotDeleteSuccessResp
+// @Failure 400 {object} services.StockSnapshotFailResp
+// @Failure 404 {object} services.StockSnapshotFailResp
+// @Failure 500 {object} services.StockSnapshotFailResp
+// @Router /stocklab-api/v1/stock-snapshots/delete/{id} [delete]
+// @Security BearerAuth
+func DeleteStockSnapshot(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Stock snapshot Id must be a number")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("DELETE FROM stock_snapshots WHERE id=$1", id)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete stock snapshot: "+err.Error())
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		utils.RespondError(w, http.StatusNotFound, "Stock snapshot not found")
+		return
+	}
+
+	if _, err := tx.Exec("DELETE FROM stock_snapshot_lines WHERE snapshot_id=$1", id); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete stock snapshot lines: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	// Response sukses
+	response := map[string]interface{}{
+		"id": id,
+	}
+	utils.RespondSuccess(w, response, "Stock snapshot deleted successfully")
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type StockSnapshotData struct {
+	ID            int64     `json:"id" example:"1"`
+	SnapshotAt    time.Time `json:"snapshot_at" example:"2025-12-31T23:59:59Z"`
+	Notes         string    `json:"notes" example:"Tutup buku Desember"`
+	TotalLines    int64     `json:"total_lines" example:"120"`      // baris product per warehouse dengan saldo tidak nol
+	TotalQuantity int64     `json:"total_quantity" example:"15000"` // satuan dasar
+	CreatedBy     string    `json:"created_by" example:"Admin"`
+	CreatedAt     time.Time `json:"created_at" example:"2026-01-01T08:00:00Z"`
+}
+
+type StockSnapshotListSuccessResp struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Stock snapshots fetched successfully"`
+	Data    []StockSnapshotData `json:"data"`
+}
+
+const stockSnapshotSelect = `
+	SELECT ss.id, ss.snapshot_at, COALESCE(ss.notes, ''),
+		(SELECT COUNT(*) FROM stock_snapshot_lines l WHERE l.snapshot_id = ss.id),
+		(SELECT COALESCE(SUM(l.quantity), 0) FROM stock_snapshot_lines l WHERE l.snapshot_id = ss.id),
+		COALESCE(u.name, ''), ss.created_at
+	FROM stock_snapshots ss
+	LEFT JOIN users u ON u.id = ss.created_by
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStockSnapshot(row rowScanner, s *StockSnapshotData) error {
+	return row.Scan(&s.ID, &s.SnapshotAt, &s.Notes, &s.TotalLines, &s.TotalQuantity, &s.CreatedBy, &s.CreatedAt)
+}
+
+// GetStockSnapshotList godoc
+// @Summary List stock snapshots
+// @Description List snapshot saldo stok, terbaru lebih dulu
+// @Tags stock-snapshots
+// @Produce json
+// @Success 200 {object} services.StockSnapshotListSuccessResp
+// @Failure 500 {object} services.StockSnapshotFailResp
+// @Router /stocklab-api/v1/stock-snapshots [get]
+// @Security BearerAuth
+func GetStockSnapshotList(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.DB.Query(stockSnapshotSelect + ` ORDER BY ss.snapshot_at DESC`)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch stock snapshots: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	snapshots := []StockSnapshotData{}
+	for rows.Next() {
+		var s StockSnapshotData
+		if err := scanStockSnapshot(rows, &s); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan stock snapshots: "+err.Error())
+			return
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading stock snapshots: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, snapshots, "Stock snapshots fetched successfully")
+}
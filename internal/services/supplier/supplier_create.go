@@ -0,0 +1,86 @@
+package services
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type SupplierCreateSuccessResp struct {
+	Status  string   `json:"status" example:"success"`
+	Message string   `json:"message" example:"Supplier created successfully"`
+	Data    Supplier `json:"data"`
+}
+
+type SupplierCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid parameter"`
+}
+
+// CreateSupplier godoc
+// @Summary Create supplier
+// @Description Create a supplier that purchase orders can be placed with
+// @Tags suppliers
+// @Accept multipart/form-data
+// @Produce json
+// @Param code formData string true "code"
+// @Param name formData string true "name"
+// @Param contact_name formData string false "contact_name"
+// @Param phone formData string false "phone"
+// @Param email formData string false "email"
+// @Param address formData string false "address"
+// @Success 200 {object} services.SupplierCreateSuccessResp
+// @Failure 400 {object} services.SupplierCreateFailResp
+// @Failure 403 {object} services.SupplierCreateFailResp
+// @Failure 409 {object} services.SupplierCreateFailResp
+// @Failure 500 {object} services.SupplierCreateFailResp
+// @Router /stocklab-api/v1/suppliers/create [post]
+// @Security BearerAuth
+func CreateSupplier(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	s := Supplier{
+		Code:        strings.ToUpper(strings.TrimSpace(r.FormValue("code"))),
+		Name:        strings.TrimSpace(r.FormValue("name")),
+		ContactName: strings.TrimSpace(r.FormValue("contact_name")),
+		Phone:       strings.TrimSpace(r.FormValue("phone")),
+		Email:       strings.TrimSpace(r.FormValue("email")),
+		Address:     strings.TrimSpace(r.FormValue("address")),
+		IsActive:    true,
+	}
+
+	if s.Code == "" || s.Name == "" {
+		utils.RespondError(w, http.StatusBadRequest, "code and name are required")
+		return
+	}
+
+	// Cek apakah code sudah ada
+	var exists bool
+	err := db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM suppliers WHERE UPPER(code) = $1)", s.Code).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if exists {
+		utils.RespondError(w, http.StatusConflict, s.Code+" is already registered")
+		return
+	}
+
+	err = db.DB.QueryRow(`
+		INSERT INTO suppliers (code, name, contact_name, phone, email, address)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, s.Code, s.Name, s.ContactName, s.Phone, s.Email, s.Address).Scan(&s.ID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create supplier: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, s, "Supplier created successfully")
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type SupplierDeleteSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Supplier deleted successfully"`
+}
+
+type SupplierDeleteFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to delete supplier"`
+}
+
+// DeleteSupplier godoc
+// @Summary Delete supplier
+// @Description Delete a supplier without purchase orders. Suppliers with order history should be deactivated instead.
+// @Tags suppliers
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Success 200 {object} services.SupplierDeleteSuccessResp
+// @Failure 400 {object} services.SupplierDeleteFailResp
+// @Failure 403 {object} services.SupplierDeleteFailResp
+// @Failure 404 {object} services.SupplierDeleteFailResp
+// @Failure 409 {object} services.SupplierDeleteFailResp
+// @Failure 500 {object} services.SupplierDeleteFailResp
+// @Router /stocklab-api/v1/suppliers/delete/{id} [delete]
+// @Security BearerAuth
+func DeleteSupplier(w http.ResponseWriter, r *http.Request) {
+	supplierID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Supplier Id must be a number")
+		return
+	}
+
+	// Cek apakah supplier ada
+	var exists bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM suppliers WHERE id=$1)", supplierID).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !exists {
+		utils.RespondError(w, http.StatusNotFound, "Supplier not found")
+		return
+	}
+
+	// Cek apakah sudah punya purchase order
+	var used bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM purchase_orders WHERE supplier_id=$1)", supplierID).Scan(&used)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if used {
+		utils.RespondError(w, http.StatusConflict, "Cannot delete supplier that has purchase orders, deactivate it instead")
+		return
+	}
+
+	if _, err = db.DB.Exec("DELETE FROM suppliers WHERE id=$1", supplierID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete supplier: "+err.Error())
+		return
+	}
+
+	// Response sukses
+	response := map[string]interface{}{
+		"id": supplierID,
+	}
+	utils.RespondSuccess(w, response, "Supplier deleted successfully")
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Supplier blueprint
+type Supplier struct {
+	ID          int64  `json:"id" example:"1"`
+	Code        string `json:"code" example:"SUP-INDOFOOD"`
+	Name        string `json:"name" example:"PT Indofood"`
+	ContactName string `json:"contact_name" example:"Budi"`
+	Phone       string `json:"phone" example:"08123456789"`
+	Email       string `json:"email" example:"sales@indofood.co.id"`
+	Address     string `json:"address" example:"Jl. Sudirman No. 1"`
+	IsActive    bool   `json:"is_active" example:"true"`
+}
+
+type SupplierSuccessResp struct {
+	Status  string     `json:"status" example:"success"`
+	Message string     `json:"message" example:"Suppliers fetched successfully"`
+	Data    []Supplier `json:"data"`
+}
+
+type SupplierFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch suppliers"`
+}
+
+const supplierColumns = `id, code, name, COALESCE(contact_name, ''), COALESCE(phone, ''), COALESCE(email, ''), COALESCE(address, ''), is_active`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSupplier(row rowScanner, s *Supplier) error {
+	return row.Scan(&s.ID, &s.Code, &s.Name, &s.ContactName, &s.Phone, &s.Email, &s.Address, &s.IsActive)
+}
+
+// GetSupplierList godoc
+// @Summary Get list of suppliers
+// @Description Get all suppliers
+// @Tags suppliers
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} services.SupplierSuccessResp
+// @Failure 500 {object} services.SupplierFailResp
+// @Router /stocklab-api/v1/suppliers [get]
+// @Security BearerAuth
+func GetSupplierList(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.DB.Query("SELECT " + supplierColumns + " FROM suppliers ORDER BY name ASC")
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch suppliers: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	suppliers := []Supplier{}
+
+	for rows.Next() {
+		var s Supplier
+		if err := scanSupplier(rows, &s); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan suppliers: "+err.Error())
+			return
+		}
+
+		suppliers = append(suppliers, s)
+	}
+
+	// Cek apakah ada error saat iterasi rows
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading suppliers: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, suppliers, "Suppliers fetched successfully")
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type SupplierUpdateSuccessResp struct {
+	Status  string   `json:"status" example:"success"`
+	Message string   `json:"message" example:"Supplier updated successfully"`
+	Data    Supplier `json:"data"`
+}
+
+type SupplierUpdateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid parameter"`
+}
+
+// UpdateSupplier godoc
+// @Summary Update supplier
+// @Description Update an existing supplier. Inactive suppliers cannot receive new purchase orders.
+// @Tags suppliers
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Param code formData string false "code"
+// @Param name formData string false "name"
+// @Param contact_name formData string false "contact_name"
+// @Param phone formData string false "phone"
+// @Param email formData string false "email"
+// @Param address formData string false "address"
+// @Param is_active formData bool false "is_active"
+// @Success 200 {object} services.SupplierUpdateSuccessResp
+// @Failure 400 {object} services.SupplierUpdateFailResp
+// @Failure 403 {object} services.SupplierUpdateFailResp
+// @Failure 404 {object} services.SupplierUpdateFailResp
+// @Failure 409 {object} services.SupplierUpdateFailResp
+// @Failure 500 {object} services.SupplierUpdateFailResp
+// @Router /stocklab-api/v1/suppliers/update/{id} [put]
+// @Security BearerAuth
+func UpdateSupplier(w http.ResponseWriter, r *http.Request) {
+	supplierID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Supplier Id must be a number")
+		return
+	}
+
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(r.FormValue("code")))
+	isActiveVal := r.FormValue("is_active")
+
+	// Cek code unik (hanya jika code diupdate)
+	if code != "" {
+		var codeExists bool
+		err = db.DB.QueryRow(
+			"SELECT EXISTS(SELECT 1 FROM suppliers WHERE UPPER(code) = $1 AND id <> $2)",
+			code, supplierID,
+		).Scan(&codeExists)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+			return
+		}
+		if codeExists {
+			utils.RespondError(w, http.StatusConflict, code+" is already registered")
+			return
+		}
+	}
+
+	// Build query dinamis
+	setParts := []string{}
+	args := []interface{}{}
+	argID := 1
+
+	fields := []struct {
+		column string
+		value  string
+	}{
+		{"code", code},
+		{"name", strings.TrimSpace(r.FormValue("name"))},
+		{"contact_name", strings.TrimSpace(r.FormValue("contact_name"))},
+		{"phone", strings.TrimSpace(r.FormValue("phone"))},
+		{"email", strings.TrimSpace(r.FormValue("email"))},
+		{"address", strings.TrimSpace(r.FormValue("address"))},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		setParts = append(setParts, f.column+"=$"+strconv.Itoa(argID))
+		args = append(args, f.value)
+		argID++
+	}
+	if isActiveVal != "" {
+		isActive, err := strconv.ParseBool(isActiveVal)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "is_active must be boolean")
+			return
+		}
+		setParts = append(setParts, "is_active=$"+strconv.Itoa(argID))
+		args = append(args, isActive)
+		argID++
+	}
+
+	if len(setParts) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "No fields to update")
+		return
+	}
+
+	setParts = append(setParts, "updated_at=NOW()")
+	query := "UPDATE suppliers SET " + strings.Join(setParts, ", ") + " WHERE id=$" + strconv.Itoa(argID) + " RETURNING " + supplierColumns
+	args = append(args, supplierID)
+
+	var updated Supplier
+	err = scanSupplier(db.DB.QueryRow(query, args...), &updated)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			utils.RespondError(w, http.StatusNotFound, "Supplier not found")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update supplier: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, updated, "Supplier updated successfully")
+}
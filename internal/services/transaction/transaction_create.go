@@ -4,18 +4,46 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 )
 
 type TransactionCreateData struct {
-	ID        int64  `json:"id" example:"1"`
-	ProductID int64  `json:"product_id" example:"1"`
-	UserID    int64  `json:"user_id"  example:"1"`
-	Quantity  int64  `json:"quantity" example:"100"`
-	MoveType  string `json:"move_type" example:"in"` // IN | OUT
+	ID          int64            `json:"id" example:"1"`
+	ProductID   int64            `json:"product_id" example:"1"`
+	WarehouseID int64            `json:"warehouse_id" example:"1"`
+	UserID      int64            `json:"user_id"  example:"1"`
+	Quantity    int64            `json:"quantity" example:"80"` // dalam satuan dasar product
+	Unit        string           `json:"unit,omitempty" example:"CTN"`
+	UnitQty     int64            `json:"unit_quantity,omitempty" example:"2"` // quantity seperti yang diinput dalam unit
+	MoveType    string           `json:"move_type" example:"in"`              // IN | OUT | ADJ_IN | ADJ_OUT
+	ReasonCode  string           `json:"reason_code,omitempty" example:"DAMAGE"`
+	Notes       string           `json:"notes,omitempty" example:"Kardus basah"`
+	Lots        []TransactionLot `json:"lots,omitempty"` // hanya untuk product lot-tracked
+	Serials     []string         `json:"serial_numbers,omitempty" example:"SN-0001,SN-0002"`
+	UnitCost    money.Amount     `json:"unit_cost" swaggertype:"number" example:"3000.00"`    // biaya per satuan dasar
+	CostValue   money.Amount     `json:"cost_value" swaggertype:"number" example:"240000.00"` // biaya perolehan (masuk) atau COGS (keluar)
+
+	Components []TransactionKitComponent `json:"components,omitempty"` // hanya untuk kit
+}
+
+// Pergerakan satu komponen saat kit dikeluarkan
+type TransactionKitComponent struct {
+	TransactionID int64            `json:"transaction_id" example:"11"`
+	ProductID     int64            `json:"product_id" example:"3"`
+	Quantity      int64            `json:"quantity" example:"4"` // quantity kit x quantity komponen per kit
+	Lots          []TransactionLot `json:"lots,omitempty"`
+}
+
+// Lot yang ditambah atau dikurangi oleh transaksi
+type TransactionLot struct {
+	LotNumber  string `json:"lot_number" example:"LOT-2025-11A"`
+	ExpiryDate string `json:"expiry_date,omitempty" example:"2026-05-31"`
+	Quantity   int64  `json:"quantity" example:"40"`
 }
 
 type TransactionCreateSuccessResp struct {
@@ -30,35 +58,92 @@ type TransactionCreateFailResp struct {
 
 // CreateTransaction godoc
 // @Summary Create transaction stocks
-// @Description Create a transaction for stock movements
+// @Description Create a transaction for stock movements. Adjustments (ADJ_IN / ADJ_OUT) require a reason_code. For lot-tracked products IN requires lot_number (and expiry_date for a new lot); OUT takes the named lot or the first-expiring unexpired lots (FEFO). Serialized products need one serial number per unit. quantity may be given in any unit configured for the product; it is stored in the product's base unit. OUT / ADJ_OUT of a kit decrements every component by its bill of materials in the same DB transaction. Inbound movements open a cost layer at unit_cost (default: the current average cost); outbound movements record cost of goods from the product's cost layers (FIFO or moving average).
 // @Tags transactions
 // @Accept multipart/form-data
 // @Produce json
-// @Param product_id formData int true "product_id"
-// @Param user_id formData int true "user_id"
+// @Param product_id formData int false "product_id, required unless barcode is given"
+// @Param barcode formData string false "barcode: scanned GS1 barcode used instead of product_id; unit defaults to the unit the barcode belongs to"
+// @Param warehouse_id formData int true "warehouse_id"
 // @Param quantity formData int true "quantity"
-// @Param move_type formData string true "move_type"
+// @Param unit formData string false "unit code of quantity (e.g. CTN); default is the product's base unit"
+// @Param move_type formData string true "move_type (IN | OUT | ADJ_IN | ADJ_OUT)"
+// @Param reason_code formData string false "reason_code, required for ADJ_IN / ADJ_OUT"
+// @Param notes formData string false "notes"
+// @Param lot_number formData string false "lot_number, required for IN of lot-tracked products; optional for OUT"
+// @Param expiry_date formData string false "expiry_date (YYYY-MM-DD) of the incoming lot"
+// @Param serial_numbers formData string false "serial_numbers, comma separated; required for serialized products, count must equal quantity"
+// @Param unit_cost formData string false "unit_cost: purchase cost per 1 unit of quantity (in unit if given, else base unit); only for IN / ADJ_IN"
+// @Param Idempotency-Key header string false "Unique key per movement; retries with the same key return the original response"
 // @Success 200 {object} services.TransactionCreateData
 // @Failure 400 {object} services.TransactionCreateFailResp
+// @Failure 401 {object} services.TransactionCreateFailResp
+// @Failure 409 {object} services.TransactionCreateFailResp
+// @Failure 422 {object} services.TransactionCreateFailResp
 // @Failure 500 {object} services.TransactionCreateFailResp
 // @Router /stocklab-api/v1/transactions/create [post]
 // @Security BearerAuth
 func CreateTransaction(w http.ResponseWriter, r *http.Request) {
+	// Actor transaksi selalu user yang login, bukan dari form
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := principal.UserID
+
 	if err := r.ParseMultipartForm(10 << 20); err != nil {
 		utils.RespondError(w, http.StatusBadRequest, "Invalid data: "+err.Error())
 		return
 	}
 
 	productID, _ := strconv.ParseInt(r.FormValue("product_id"), 10, 64)
-	userID, _ := strconv.ParseInt(r.FormValue("user_id"), 10, 64)
+	warehouseID, _ := strconv.ParseInt(r.FormValue("warehouse_id"), 10, 64)
 	qty, _ := strconv.ParseInt(r.FormValue("quantity"), 10, 64)
 	moveType := strings.ToUpper(r.FormValue("move_type"))
+	reasonCode := strings.ToUpper(strings.TrimSpace(r.FormValue("reason_code")))
+	notes := strings.TrimSpace(r.FormValue("notes"))
+	unit := strings.ToUpper(strings.TrimSpace(r.FormValue("unit")))
+	lotNumber := strings.TrimSpace(r.FormValue("lot_number"))
+	expiryDate := strings.TrimSpace(r.FormValue("expiry_date"))
+	barcode := strings.TrimSpace(r.FormValue("barcode"))
+	unitCostVal := strings.TrimSpace(r.FormValue("unit_cost"))
+
+	// serial_numbers boleh dikirim berulang atau dipisah koma
+	var serials []string
+	for _, val := range r.MultipartForm.Value["serial_numbers"] {
+		for _, s := range strings.Split(val, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				serials = append(serials, s)
+			}
+		}
+	}
 
-	if productID == 0 || qty <= 0 || (moveType != "IN" && moveType != "OUT") {
+	if (productID == 0 && barcode == "") || warehouseID == 0 || qty <= 0 || !stock.ValidMoveType(moveType) {
 		utils.RespondError(w, http.StatusBadRequest, "Invalid transaction payload")
 		return
 	}
 
+	if stock.IsAdjustment(moveType) && reasonCode == "" {
+		utils.RespondError(w, http.StatusBadRequest, "reason_code is required for adjustments")
+		return
+	}
+
+	// Biaya perolehan OPTIONAL, hanya untuk movement masuk
+	var unitCost *money.Amount
+	if unitCostVal != "" {
+		if !stock.IsInbound(moveType) {
+			utils.RespondError(w, http.StatusBadRequest, stock.ErrCostOnOutbound.Error())
+			return
+		}
+		parsed, err := money.Parse(unitCostVal)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		unitCost = &parsed
+	}
+
 	tx, err := db.DB.Begin()
 	if err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
@@ -66,57 +151,129 @@ func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 	}
 	defer tx.Rollback()
 
-	var currentQty int64
+	// Barcode hasil scan menentukan product dan satuan default
+	if barcode != "" {
+		if err := stock.ValidBarcode(barcode); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		b, err := stock.FindBarcode(tx, barcode)
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+		if productID != 0 && productID != b.ProductID {
+			utils.RespondError(w, http.StatusBadRequest, "Barcode "+barcode+" does not belong to product "+strconv.FormatInt(productID, 10))
+			return
+		}
+		productID = b.ProductID
+		if unit == "" {
+			unit = b.UnitCode
+		}
+	}
 
-	// Lock stock row to prevent race conditions
-	err = tx.QueryRow(`
-        SELECT quantity
-        FROM stocks
-        WHERE product_id = $1
-        FOR UPDATE
-    `, productID).Scan(&currentQty)
+	var reasonID int64
+	if reasonCode != "" {
+		reasonID, err = stock.FindReason(tx, reasonCode, moveType)
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+	}
 
+	// Quantity disimpan dalam satuan dasar product
+	enteredQty := qty
+	qty, unitID, err := stock.ToBase(tx, productID, unit, enteredQty)
 	if err != nil {
-		utils.RespondError(w, http.StatusBadRequest, "Stock not found: "+err.Error())
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
 		return
 	}
+	if unitID == 0 {
+		enteredQty = 0
+	}
 
-	// Apply movement
-	if moveType == "OUT" {
-		if currentQty-qty < 0 {
-			utils.RespondError(w, http.StatusConflict, "Insufficient stock")
-			return
+	// unit_cost berlaku per satuan yang diinput
+	var costValue *money.Amount
+	if unitCost != nil {
+		value := unitCost.Mul(qty)
+		if unitID != 0 {
+			value = unitCost.Mul(enteredQty)
 		}
-		currentQty -= qty
-	} else {
-		currentQty += qty
+		costValue = &value
 	}
 
-	// Update stock
-	_, err = tx.Exec(`
-        UPDATE stocks
-        SET quantity = $1, updated_at = $2
-        WHERE product_id = $3
-    `, currentQty, time.Now(), productID)
+	key := stock.Key{ProductID: productID, WarehouseID: warehouseID}
+
+	// Kit dipecah menjadi movement per komponen
+	movements, err := stock.ExpandKit(tx, stock.Movement{
+		Key:        key,
+		UserID:     userID,
+		Quantity:   qty,
+		MoveType:   moveType,
+		ReasonID:   reasonID,
+		Notes:      notes,
+		LotNumber:  lotNumber,
+		ExpiryDate: expiryDate,
+		Serials:    serials,
+		CostValue:  costValue,
 
+		EnteredUnitID:   unitID,
+		EnteredQuantity: enteredQty,
+	})
 	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, "Failed updating stock: "+err.Error())
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
 		return
 	}
 
-	// Insert transaction history
-	var txId int64
-	err = tx.QueryRow(`
-        INSERT INTO transactions (product_id, user_id, quantity, move_type)
-        VALUES ($1, $2, $3, $4)
-		RETURNING id
-    `, productID, userID, qty, moveType).Scan(&txId)
-
+	// Lock stock row to prevent race conditions
+	balances, err := stock.Lock(tx, stock.MovementKeys(movements))
 	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, "Failed creating transaction: "+err.Error())
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
 		return
 	}
 
+	// Apply movement & insert transaction history
+	var txId int64
+	var lots []stock.LotQty
+	var costValueTotal money.Amount
+	components := []TransactionKitComponent{}
+	for i, m := range movements {
+		id, err := stock.Apply(tx, balances, m)
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+
+		// Biaya kit adalah total COGS komponennya
+		cost, err := stock.CostOf(tx, id)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		costValueTotal += cost.Value
+
+		// Lot yang terpakai (hasil FEFO) dikembalikan di response
+		movedLots, err := stock.TransactionLots(tx, id)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if i == 0 {
+			txId = id
+		}
+		if m.KitProductID == 0 {
+			lots = movedLots
+			continue
+		}
+		components = append(components, TransactionKitComponent{
+			TransactionID: id,
+			ProductID:     m.ProductID,
+			Quantity:      m.Quantity,
+			Lots:          transactionLots(movedLots),
+		})
+	}
+
 	if err := tx.Commit(); err != nil {
 		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
 		return
@@ -125,11 +282,30 @@ func CreateTransaction(w http.ResponseWriter, r *http.Request) {
 	utils.RespondSuccess(
 		w,
 		TransactionCreateData{
-			ID:        txId,
-			ProductID: productID,
-			Quantity:  qty,
-			MoveType:  moveType,
+			ID:          txId,
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			UserID:      userID,
+			Quantity:    qty,
+			Unit:        unit,
+			UnitQty:     enteredQty,
+			MoveType:    moveType,
+			ReasonCode:  reasonCode,
+			Notes:       notes,
+			Lots:        transactionLots(lots),
+			Serials:     serials,
+			Components:  components,
+			UnitCost:    costValueTotal.MulDiv(1, qty),
+			CostValue:   costValueTotal,
 		},
 		"Transaction created successfully",
 	)
 }
+
+func transactionLots(lots []stock.LotQty) []TransactionLot {
+	result := make([]TransactionLot, 0, len(lots))
+	for _, l := range lots {
+		result = append(result, TransactionLot{LotNumber: l.LotNumber, ExpiryDate: l.ExpiryDate, Quantity: l.Quantity})
+	}
+	return result
+}
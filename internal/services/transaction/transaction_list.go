@@ -2,23 +2,54 @@ package services
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 )
 
 type TransactionListData struct {
-	ID           int64     `json:"id" example:"1"`
-	ProductName  string    `json:"product_name" example:"Mie Sedap Goreng"`
-	ProductSKU   string    `json:"product_sku" example:"MIE001"`
-	ProductBrand string    `json:"product_brand" example:"Sedap"`
-	ProductPrice int64     `json:"product_price" example:"5000"`
-	PICName      string    `json:"pic_name" example:"John Doe"`
-	Quantity     int64     `json:"quantity" example:"10"`
-	MoveType     string    `json:"move_type" example:"in"`
-	CreatedAt    time.Time `json:"created_at" example:"2024-12-14T20:15:30Z"` // ISO 8601 format
+	ID           int64         `json:"id" example:"1"`
+	ProductName  string        `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU   string        `json:"product_sku" example:"MIE001"`
+	ProductBrand string        `json:"product_brand" example:"Sedap"`
+	UnitPrice    *money.Amount `json:"unit_price" swaggertype:"number" example:"5000.00"` // harga product saat movement terjadi
+	Currency     *string       `json:"currency" example:"IDR"`
+	UnitCost     *money.Amount `json:"unit_cost" swaggertype:"number" example:"3000.00"`    // biaya per satuan dasar
+	CostValue    *money.Amount `json:"cost_value" swaggertype:"number" example:"240000.00"` // biaya perolehan (masuk) atau COGS (keluar)
+	PICName      string        `json:"pic_name" example:"John Doe"`
+	Warehouse    string        `json:"warehouse" example:"Main Warehouse"`
+	Quantity     int64         `json:"quantity" example:"80"` // dalam satuan dasar
+	BaseUnit     *string       `json:"base_unit" example:"PCS"`
+	EnteredUnit  *string       `json:"entered_unit" example:"CTN"`
+	EnteredQty   *int64        `json:"entered_quantity" example:"2"`
+	MoveType     string        `json:"move_type" example:"in"`
+	TransferID   *int64        `json:"transfer_id" example:"1"`
+	DocumentID   *int64        `json:"document_id" example:"1"`
+	DocumentRef  *string       `json:"document_ref" example:"SJ-2025-0001"`
+	AssemblyID   *int64        `json:"assembly_order_id" example:"1"`
+	ReasonCode   *string       `json:"reason_code" example:"DAMAGE"`
+	ReasonName   *string       `json:"reason_name" example:"Barang rusak"`
+	Notes        *string       `json:"notes" example:"Kardus basah"`
+	LotNumbers   *string       `json:"lot_numbers" example:"LOT-2025-11A, LOT-2025-12B"` // lot yang disentuh, hanya product lot-tracked
+	Serials      *string       `json:"serial_numbers" example:"SN-0001, SN-0002"`        // hanya product serialized
+	KitSKU       *string       `json:"kit_sku" example:"SKU-20251214201530-777"`         // kit asal jika baris ini komponen kit
+	KitQuantity  *int64        `json:"kit_quantity" example:"2"`
+	CreatedAt    time.Time     `json:"created_at" example:"2024-12-14T20:15:30Z"` // ISO 8601 format
 }
+
+// Baris transaksi yang dikelompokkan per dokumen (group_by=document).
+// Transaksi tanpa dokumen menjadi grup sendiri dengan document_id null.
+type TransactionDocumentGroup struct {
+	DocumentID  *int64                `json:"document_id" example:"1"`
+	DocumentRef *string               `json:"document_ref" example:"SJ-2025-0001"`
+	DocType     *string               `json:"doc_type" example:"RECEIPT"`
+	Lines       []TransactionListData `json:"lines"`
+}
+
 type TransactionListSuccessResp struct {
 	Status  string `json:"status" example:"success"`
 	Message string `json:"message" example:"Transaction fetched successfully"`
@@ -37,6 +68,11 @@ type TransactionListFailResp struct {
 // @Produce json
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param warehouse_id query int false "Warehouse ID"
+// @Param document_id query int false "Only lines of this document"
+// @Param move_type query string false "IN | OUT | ADJ_IN | ADJ_OUT"
+// @Param reason_code query string false "Reason code"
+// @Param group_by query string false "document: group lines by their movement document"
 // @Success 200 {object} services.TransactionListData
 // @Failure 400 {object} services.TransactionListFailResp
 // @Failure 500 {object} services.TransactionListFailResp
@@ -45,27 +81,76 @@ type TransactionListFailResp struct {
 func GetTransactionList(w http.ResponseWriter, r *http.Request) {
 	startDate := r.URL.Query().Get("start_date")
 	endDate := r.URL.Query().Get("end_date")
+	warehouseID := r.URL.Query().Get("warehouse_id")
+	documentID := r.URL.Query().Get("document_id")
+	groupBy := r.URL.Query().Get("group_by")
+	moveType := strings.ToUpper(r.URL.Query().Get("move_type"))
+	reasonCode := strings.ToUpper(r.URL.Query().Get("reason_code"))
+
+	if groupBy != "" && groupBy != "document" {
+		utils.RespondError(w, http.StatusBadRequest, "group_by must be document")
+		return
+	}
 
 	query := `
-		SELECT tr.id, p.name as product_name, p.sku, p.brand, COALESCE(CAST(p.price AS INT), 0) as price, u.name as pic_name, tr.quantity, tr.move_type, tr.created_at
+		SELECT tr.id, p.name as product_name, p.sku, p.brand, tr.unit_price, tr.currency, tr.unit_cost, tr.cost_value, u.name as pic_name, COALESCE(wh.name, '') as warehouse, tr.quantity, bu.code, eu.code, tr.entered_quantity, tr.move_type, tr.transfer_id, tr.document_id, d.reference_no, tr.assembly_order_id, d.doc_type, rc.code, rc.name, tr.notes,
+			(SELECT STRING_AGG(sl.lot_number, ', ' ORDER BY tl.id) FROM transaction_lots tl JOIN stock_lots sl ON sl.id = tl.lot_id WHERE tl.transaction_id = tr.id),
+			(SELECT STRING_AGG(sn.serial_number, ', ' ORDER BY ts.id) FROM transaction_serials ts JOIN serial_numbers sn ON sn.id = ts.serial_id WHERE ts.transaction_id = tr.id),
+			kp.sku, tr.kit_quantity,
+			tr.created_at
 		FROM transactions tr
 		LEFT JOIN products p ON tr.product_id = p.id
 		LEFT JOIN users u ON tr.user_id = u.id
+		LEFT JOIN warehouses wh ON tr.warehouse_id = wh.id
+		LEFT JOIN stock_documents d ON tr.document_id = d.id
+		LEFT JOIN reason_codes rc ON tr.reason_code_id = rc.id
+		LEFT JOIN units bu ON bu.id = p.base_unit_id
+		LEFT JOIN units eu ON eu.id = tr.entered_unit_id
+		LEFT JOIN products kp ON kp.id = tr.kit_product_id
 	`
 
 	var args []interface{}
-	where := ""
+	conditions := []string{}
 
 	// Build WHERE condition
-	if startDate != "" && endDate != "" {
-		where = "WHERE tr.created_at::date BETWEEN $1 AND $2"
-		args = append(args, startDate, endDate)
-	} else if startDate != "" {
-		where = "WHERE tr.created_at::date >= $1"
+	if startDate != "" {
 		args = append(args, startDate)
-	} else if endDate != "" {
-		where = "WHERE tr.created_at::date <= $1"
+		conditions = append(conditions, "tr.created_at::date >= $"+strconv.Itoa(len(args)))
+	}
+	if endDate != "" {
 		args = append(args, endDate)
+		conditions = append(conditions, "tr.created_at::date <= $"+strconv.Itoa(len(args)))
+	}
+	if warehouseID != "" {
+		id, err := strconv.ParseInt(warehouseID, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "tr.warehouse_id = $"+strconv.Itoa(len(args)))
+	}
+	if documentID != "" {
+		id, err := strconv.ParseInt(documentID, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "document_id must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "tr.document_id = $"+strconv.Itoa(len(args)))
+	}
+	if moveType != "" {
+		args = append(args, moveType)
+		conditions = append(conditions, "tr.move_type = $"+strconv.Itoa(len(args)))
+	}
+	if reasonCode != "" {
+		args = append(args, reasonCode)
+		conditions = append(conditions, "UPPER(rc.code) = $"+strconv.Itoa(len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
 	}
 
 	order := " ORDER BY tr.created_at DESC"
@@ -78,18 +163,40 @@ func GetTransactionList(w http.ResponseWriter, r *http.Request) {
 	defer rows.Close()
 
 	var data []TransactionListData
+	var groups []TransactionDocumentGroup
+	groupIndex := map[int64]int{}
 
 	for rows.Next() {
 		var t TransactionListData
+		var docType *string
 		if err := rows.Scan(
 			&t.ID,
 			&t.ProductName,
 			&t.ProductSKU,
 			&t.ProductBrand,
-			&t.ProductPrice,
+			&t.UnitPrice,
+			&t.Currency,
+			&t.UnitCost,
+			&t.CostValue,
 			&t.PICName,
+			&t.Warehouse,
 			&t.Quantity,
+			&t.BaseUnit,
+			&t.EnteredUnit,
+			&t.EnteredQty,
 			&t.MoveType,
+			&t.TransferID,
+			&t.DocumentID,
+			&t.DocumentRef,
+			&t.AssemblyID,
+			&docType,
+			&t.ReasonCode,
+			&t.ReasonName,
+			&t.Notes,
+			&t.LotNumbers,
+			&t.Serials,
+			&t.KitSKU,
+			&t.KitQuantity,
 			&t.CreatedAt,
 		); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing transactions: "+err.Error())
@@ -97,6 +204,29 @@ func GetTransactionList(w http.ResponseWriter, r *http.Request) {
 		}
 
 		data = append(data, t)
+
+		if groupBy == "document" {
+			if t.DocumentID == nil {
+				groups = append(groups, TransactionDocumentGroup{Lines: []TransactionListData{t}})
+				continue
+			}
+			if i, ok := groupIndex[*t.DocumentID]; ok {
+				groups[i].Lines = append(groups[i].Lines, t)
+				continue
+			}
+			groupIndex[*t.DocumentID] = len(groups)
+			groups = append(groups, TransactionDocumentGroup{
+				DocumentID:  t.DocumentID,
+				DocumentRef: t.DocumentRef,
+				DocType:     docType,
+				Lines:       []TransactionListData{t},
+			})
+		}
+	}
+
+	if groupBy == "document" {
+		utils.RespondSuccess(w, groups, "Transactions fetched successfully")
+		return
 	}
 
 	utils.RespondSuccess(w, data, "Transactions fetched successfully")
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type TransferLineRequest struct {
+	ProductID int64    `json:"product_id" example:"1"`
+	Quantity  int64    `json:"quantity" example:"10"`
+	Serials   []string `json:"serial_numbers" example:"SN-0001"` // wajib untuk product serialized, satu per unit
+}
+
+type TransferCreateRequest struct {
+	FromWarehouseID int64                 `json:"from_warehouse_id" example:"1"`
+	ToWarehouseID   int64                 `json:"to_warehouse_id" example:"2"`
+	Notes           string                `json:"notes" example:"Restock toko"`
+	Lines           []TransferLineRequest `json:"lines"`
+}
+
+type TransferLine struct {
+	ProductID        int64    `json:"product_id" example:"1"`
+	Quantity         int64    `json:"quantity" example:"10"`
+	OutTransactionID int64    `json:"out_transaction_id" example:"11"`
+	InTransactionID  int64    `json:"in_transaction_id" example:"12"`
+	Serials          []string `json:"serial_numbers,omitempty" example:"SN-0001"`
+}
+
+type TransferCreateData struct {
+	ID              int64          `json:"id" example:"1"`
+	FromWarehouseID int64          `json:"from_warehouse_id" example:"1"`
+	ToWarehouseID   int64          `json:"to_warehouse_id" example:"2"`
+	UserID          int64          `json:"user_id" example:"1"`
+	Notes           string         `json:"notes" example:"Restock toko"`
+	CreatedAt       time.Time      `json:"created_at" example:"2024-12-14T20:15:30Z"`
+	Lines           []TransferLine `json:"lines"`
+}
+
+type TransferCreateSuccessResp struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Transfer created successfully"`
+	Data    TransferCreateData `json:"data"`
+}
+
+type TransferCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to create transfer"`
+}
+
+// CreateTransfer godoc
+// @Summary Create stock transfer
+// @Description Pindahkan stok antar warehouse dalam satu DB transaction. Setiap baris dicatat sebagai pasangan OUT/IN di transactions dengan transfer_id yang sama. Untuk product lot-tracked lot diambil FEFO dan dipindah apa adanya ke warehouse tujuan; product serialized wajib menyebut serial_numbers yang dipindah.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param body body services.TransferCreateRequest true "Transfer document"
+// @Param Idempotency-Key header string false "Unique key per movement; retries with the same key return the original response"
+// @Success 200 {object} services.TransferCreateSuccessResp
+// @Failure 400 {object} services.TransferCreateFailResp
+// @Failure 401 {object} services.TransferCreateFailResp
+// @Failure 409 {object} services.TransferCreateFailResp
+// @Failure 422 {object} services.TransferCreateFailResp
+// @Failure 500 {object} services.TransferCreateFailResp
+// @Router /stocklab-api/v1/transfers/create [post]
+// @Security BearerAuth
+func CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	principal, ok := authService.PrincipalFromContext(r.Context())
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req TransferCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+
+	if req.FromWarehouseID == 0 || req.ToWarehouseID == 0 || len(req.Lines) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "from_warehouse_id, to_warehouse_id and lines are required")
+		return
+	}
+	if req.FromWarehouseID == req.ToWarehouseID {
+		utils.RespondError(w, http.StatusBadRequest, "Source and destination warehouse must be different")
+		return
+	}
+
+	// Gabungkan product yang sama supaya satu product hanya satu pasang OUT/IN
+	quantities := map[int64]int64{}
+	serials := map[int64][]string{}
+	for _, line := range req.Lines {
+		if line.ProductID == 0 || line.Quantity <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "Invalid transfer line")
+			return
+		}
+		quantities[line.ProductID] += line.Quantity
+		serials[line.ProductID] = append(serials[line.ProductID], line.Serials...)
+	}
+
+	productIDs := make([]int64, 0, len(quantities))
+	for id := range quantities {
+		productIDs = append(productIDs, id)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	// Lock baris stok asal dan tujuan sekaligus
+	keys := []stock.Key{}
+	for _, id := range productIDs {
+		keys = append(keys,
+			stock.Key{ProductID: id, WarehouseID: req.FromWarehouseID},
+			stock.Key{ProductID: id, WarehouseID: req.ToWarehouseID},
+		)
+	}
+	balances, err := stock.Lock(tx, keys)
+	if err != nil {
+		utils.RespondError(w, stock.StatusCode(err), err.Error())
+		return
+	}
+
+	resp := TransferCreateData{
+		FromWarehouseID: req.FromWarehouseID,
+		ToWarehouseID:   req.ToWarehouseID,
+		UserID:          principal.UserID,
+		Notes:           req.Notes,
+		Lines:           []TransferLine{},
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO stock_transfers (from_warehouse_id, to_warehouse_id, user_id, notes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, req.FromWarehouseID, req.ToWarehouseID, principal.UserID, req.Notes).Scan(&resp.ID, &resp.CreatedAt)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create transfer: "+err.Error())
+		return
+	}
+
+	for _, productID := range productIDs {
+		line := TransferLine{ProductID: productID, Quantity: quantities[productID], Serials: serials[productID]}
+
+		line.OutTransactionID, err = stock.Apply(tx, balances, stock.Movement{
+			Key:        stock.Key{ProductID: productID, WarehouseID: req.FromWarehouseID},
+			UserID:     principal.UserID,
+			Quantity:   line.Quantity,
+			MoveType:   stock.MoveOut,
+			TransferID: resp.ID,
+			Serials:    line.Serials,
+		})
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+
+		// Product lot-tracked: lot yang keluar (FEFO) masuk dengan nomor dan kedaluwarsa yang sama
+		lots, err := stock.TransactionLots(tx, line.OutTransactionID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// Biaya yang keluar dari gudang asal menjadi biaya perolehan di gudang tujuan
+		cost, err := stock.CostOf(tx, line.OutTransactionID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		line.InTransactionID, err = stock.Apply(tx, balances, stock.Movement{
+			Key:        stock.Key{ProductID: productID, WarehouseID: req.ToWarehouseID},
+			UserID:     principal.UserID,
+			Quantity:   line.Quantity,
+			MoveType:   stock.MoveIn,
+			TransferID: resp.ID,
+			Lots:       lots,
+			Serials:    line.Serials,
+			CostValue:  &cost.Value,
+		})
+		if err != nil {
+			utils.RespondError(w, stock.StatusCode(err), err.Error())
+			return
+		}
+
+		resp.Lines = append(resp.Lines, line)
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Transfer created successfully")
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type TransferDetailLine struct {
+	ProductID        int64  `json:"product_id" example:"1"`
+	ProductName      string `json:"product_name" example:"Mie Sedap Goreng"`
+	ProductSKU       string `json:"product_sku" example:"SKU-20251214201530-042"`
+	Quantity         int64  `json:"quantity" example:"10"`
+	OutTransactionID int64  `json:"out_transaction_id" example:"11"`
+	InTransactionID  int64  `json:"in_transaction_id" example:"12"`
+}
+
+type TransferDetail struct {
+	TransferListData
+	FromWarehouseID int64                `json:"from_warehouse_id" example:"1"`
+	ToWarehouseID   int64                `json:"to_warehouse_id" example:"2"`
+	Lines           []TransferDetailLine `json:"lines"`
+}
+
+type TransferDetailSuccessResp struct {
+	Status  string         `json:"status" example:"success"`
+	Message string         `json:"message" example:"Transfer fetched successfully"`
+	Data    TransferDetail `json:"data"`
+}
+
+type TransferDetailFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch transfer"`
+}
+
+// GetTransferDetail godoc
+// @Summary Stock transfer detail
+// @Description Menampilkan header transfer beserta pasangan transaksi OUT/IN per product
+// @Tags transfers
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} services.TransferDetailSuccessResp
+// @Failure 400 {object} services.TransferDetailFailResp
+// @Failure 404 {object} services.TransferDetailFailResp
+// @Failure 500 {object} services.TransferDetailFailResp
+// @Router /stocklab-api/v1/transfers/detail/{id} [get]
+// @Security BearerAuth
+func GetTransferDetail(w http.ResponseWriter, r *http.Request) {
+	transferID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid transfer id")
+		return
+	}
+
+	var t TransferDetail
+	err = db.DB.QueryRow(`
+		SELECT st.id, st.from_warehouse_id, st.to_warehouse_id, COALESCE(wf.name, ''), COALESCE(wt.name, ''),
+			COALESCE(u.name, ''), COALESCE(st.notes, ''), st.created_at
+		FROM stock_transfers st
+		LEFT JOIN warehouses wf ON wf.id = st.from_warehouse_id
+		LEFT JOIN warehouses wt ON wt.id = st.to_warehouse_id
+		LEFT JOIN users u ON u.id = st.user_id
+		WHERE st.id = $1
+	`, transferID).Scan(&t.ID, &t.FromWarehouseID, &t.ToWarehouseID, &t.FromWarehouse, &t.ToWarehouse, &t.PICName, &t.Notes, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		utils.RespondError(w, http.StatusNotFound, "transfer not found")
+		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	rows, err := db.DB.Query(`
+		SELECT o.product_id, COALESCE(p.name, ''), COALESCE(p.sku, ''), o.quantity, o.id, COALESCE(i.id, 0)
+		FROM transactions o
+		LEFT JOIN transactions i ON i.transfer_id = o.transfer_id AND i.product_id = o.product_id AND i.move_type = 'IN'
+		LEFT JOIN products p ON p.id = o.product_id
+		WHERE o.transfer_id = $1 AND o.move_type = 'OUT'
+		ORDER BY o.id
+	`, transferID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer rows.Close()
+
+	t.Lines = []TransferDetailLine{}
+	for rows.Next() {
+		var l TransferDetailLine
+		if err := rows.Scan(&l.ProductID, &l.ProductName, &l.ProductSKU, &l.Quantity, &l.OutTransactionID, &l.InTransactionID); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		t.Lines = append(t.Lines, l)
+		t.TotalLines++
+		t.TotalQuantity += l.Quantity
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, t, "Transfer fetched successfully")
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type TransferListData struct {
+	ID            int64     `json:"id" example:"1"`
+	FromWarehouse string    `json:"from_warehouse" example:"Main Warehouse"`
+	ToWarehouse   string    `json:"to_warehouse" example:"Toko"`
+	PICName       string    `json:"pic_name" example:"John Doe"`
+	Notes         string    `json:"notes" example:"Restock toko"`
+	TotalLines    int64     `json:"total_lines" example:"3"`
+	TotalQuantity int64     `json:"total_quantity" example:"30"`
+	CreatedAt     time.Time `json:"created_at" example:"2024-12-14T20:15:30Z"`
+}
+
+type TransferListSuccessResp struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Transfers fetched successfully"`
+	Data    []TransferListData `json:"data"`
+}
+
+type TransferListFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch transfers"`
+}
+
+// GetTransferList godoc
+// @Summary List stock transfers
+// @Description List transfer documents between warehouses
+// @Tags transfers
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param warehouse_id query int false "Source or destination warehouse ID"
+// @Success 200 {object} services.TransferListSuccessResp
+// @Failure 400 {object} services.TransferListFailResp
+// @Failure 500 {object} services.TransferListFailResp
+// @Router /stocklab-api/v1/transfers [get]
+// @Security BearerAuth
+func GetTransferList(w http.ResponseWriter, r *http.Request) {
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+	warehouseID := r.URL.Query().Get("warehouse_id")
+
+	query := `
+		SELECT st.id, COALESCE(wf.name, ''), COALESCE(wt.name, ''), COALESCE(u.name, ''), COALESCE(st.notes, ''),
+			COUNT(tr.id) FILTER (WHERE tr.move_type = 'OUT'),
+			COALESCE(SUM(tr.quantity) FILTER (WHERE tr.move_type = 'OUT'), 0),
+			st.created_at
+		FROM stock_transfers st
+		LEFT JOIN warehouses wf ON wf.id = st.from_warehouse_id
+		LEFT JOIN warehouses wt ON wt.id = st.to_warehouse_id
+		LEFT JOIN users u ON u.id = st.user_id
+		LEFT JOIN transactions tr ON tr.transfer_id = st.id
+	`
+
+	var args []interface{}
+	conditions := []string{}
+
+	if startDate != "" {
+		args = append(args, startDate)
+		conditions = append(conditions, "st.created_at::date >= $"+strconv.Itoa(len(args)))
+	}
+	if endDate != "" {
+		args = append(args, endDate)
+		conditions = append(conditions, "st.created_at::date <= $"+strconv.Itoa(len(args)))
+	}
+	if warehouseID != "" {
+		id, err := strconv.ParseInt(warehouseID, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		args = append(args, id)
+		conditions = append(conditions, "(st.from_warehouse_id = $"+strconv.Itoa(len(args))+" OR st.to_warehouse_id = $"+strconv.Itoa(len(args))+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.DB.Query(query+where+" GROUP BY st.id, wf.name, wt.name, u.name ORDER BY st.created_at DESC", args...)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed fetching transfers: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	data := []TransferListData{}
+
+	for rows.Next() {
+		var t TransferListData
+		if err := rows.Scan(&t.ID, &t.FromWarehouse, &t.ToWarehouse, &t.PICName, &t.Notes, &t.TotalLines, &t.TotalQuantity, &t.CreatedAt); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed parsing transfers: "+err.Error())
+			return
+		}
+
+		data = append(data, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading transfers: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, data, "Transfers fetched successfully")
+}
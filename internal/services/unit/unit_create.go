@@ -0,0 +1,72 @@
+package services
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type UnitCreateSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Unit created successfully"`
+	Data    Unit   `json:"data"`
+}
+
+type UnitCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid parameter"`
+}
+
+// CreateUnit godoc
+// @Summary Create unit
+// @Description Create a unit of measure
+// @Tags units
+// @Accept multipart/form-data
+// @Produce json
+// @Param code formData string true "code"
+// @Param name formData string true "name"
+// @Success 200 {object} services.UnitCreateSuccessResp
+// @Failure 400 {object} services.UnitCreateFailResp
+// @Failure 403 {object} services.UnitCreateFailResp
+// @Failure 409 {object} services.UnitCreateFailResp
+// @Failure 500 {object} services.UnitCreateFailResp
+// @Router /stocklab-api/v1/units/create [post]
+// @Security BearerAuth
+func CreateUnit(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(r.FormValue("code")))
+	name := strings.TrimSpace(r.FormValue("name"))
+
+	if code == "" || name == "" {
+		utils.RespondError(w, http.StatusBadRequest, "code and name are required")
+		return
+	}
+
+	// Cek apakah code sudah ada
+	var exists bool
+	err := db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM units WHERE UPPER(code) = $1)", code).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if exists {
+		utils.RespondError(w, http.StatusConflict, code+" is already registered")
+		return
+	}
+
+	resp := Unit{Code: code, Name: name}
+	err = db.DB.QueryRow(`INSERT INTO units (code, name) VALUES ($1, $2) RETURNING id`, code, name).Scan(&resp.ID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create unit: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, resp, "Unit created successfully")
+}
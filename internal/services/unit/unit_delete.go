@@ -0,0 +1,81 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type UnitDeleteSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Unit deleted successfully"`
+}
+
+type UnitDeleteFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to delete unit"`
+}
+
+// DeleteUnit godoc
+// @Summary Delete unit
+// @Description Delete a unit that is not used by any product or transaction
+// @Tags units
+// @Produce json
+// @Param id path int true "Unit ID"
+// @Success 200 {object} services.UnitDeleteSuccessResp
+// @Failure 400 {object} services.UnitDeleteFailResp
+// @Failure 403 {object} services.UnitDeleteFailResp
+// @Failure 404 {object} services.UnitDeleteFailResp
+// @Failure 409 {object} services.UnitDeleteFailResp
+// @Failure 500 {object} services.UnitDeleteFailResp
+// @Router /stocklab-api/v1/units/delete/{id} [delete]
+// @Security BearerAuth
+func DeleteUnit(w http.ResponseWriter, r *http.Request) {
+	unitID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Unit Id must be a number")
+		return
+	}
+
+	// Cek apakah unit ada
+	var exists bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM units WHERE id=$1)", unitID).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !exists {
+		utils.RespondError(w, http.StatusNotFound, "Unit not found")
+		return
+	}
+
+	// Cek apakah sudah dipakai product atau transaksi
+	var used bool
+	err = db.DB.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM products WHERE base_unit_id=$1)
+			OR EXISTS(SELECT 1 FROM product_units WHERE unit_id=$1)
+			OR EXISTS(SELECT 1 FROM transactions WHERE entered_unit_id=$1)
+	`, unitID).Scan(&used)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if used {
+		utils.RespondError(w, http.StatusConflict, "Cannot delete unit that is used by products or transactions")
+		return
+	}
+
+	if _, err = db.DB.Exec("DELETE FROM units WHERE id=$1", unitID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete unit: "+err.Error())
+		return
+	}
+
+	// Response sukses
+	response := map[string]interface{}{
+		"id": unitID,
+	}
+	utils.RespondSuccess(w, response, "Unit deleted successfully")
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Unit of measure blueprint
+type Unit struct {
+	ID   int64  `json:"id" example:"1"`
+	Code string `json:"code" example:"CTN"`
+	Name string `json:"name" example:"Karton"`
+}
+
+type UnitSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Units fetched successfully"`
+	Data    []Unit `json:"data"`
+}
+
+type UnitFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch units"`
+}
+
+// GetUnitList godoc
+// @Summary Get list of units
+// @Description Get all units of measure that can be used as base or alternate unit of a product
+// @Tags units
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} services.UnitSuccessResp
+// @Failure 500 {object} services.UnitFailResp
+// @Router /stocklab-api/v1/units [get]
+// @Security BearerAuth
+func GetUnitList(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.DB.Query("SELECT id, code, name FROM units ORDER BY code ASC")
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch units: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	units := []Unit{}
+
+	for rows.Next() {
+		var u Unit
+		if err := rows.Scan(&u.ID, &u.Code, &u.Name); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan units: "+err.Error())
+			return
+		}
+
+		units = append(units, u)
+	}
+
+	// Cek apakah ada error saat iterasi rows
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading units: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, units, "Units fetched successfully")
+}
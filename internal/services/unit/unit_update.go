@@ -0,0 +1,73 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type UnitUpdateSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Unit updated successfully"`
+	Data    Unit   `json:"data"`
+}
+
+type UnitUpdateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid parameter"`
+}
+
+// UpdateUnit godoc
+// @Summary Update unit
+// @Description Update the name of a unit. The code cannot change because products and history refer to it.
+// @Tags units
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Unit ID"
+// @Param name formData string true "name"
+// @Success 200 {object} services.UnitUpdateSuccessResp
+// @Failure 400 {object} services.UnitUpdateFailResp
+// @Failure 403 {object} services.UnitUpdateFailResp
+// @Failure 404 {object} services.UnitUpdateFailResp
+// @Failure 500 {object} services.UnitUpdateFailResp
+// @Router /stocklab-api/v1/units/update/{id} [put]
+// @Security BearerAuth
+func UpdateUnit(w http.ResponseWriter, r *http.Request) {
+	unitID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Unit Id must be a number")
+		return
+	}
+
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		utils.RespondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	var updated Unit
+	err = db.DB.QueryRow(
+		"UPDATE units SET name=$1, updated_at=NOW() WHERE id=$2 RETURNING id, code, name",
+		name, unitID,
+	).Scan(&updated.ID, &updated.Code, &updated.Name)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			utils.RespondError(w, http.StatusNotFound, "Unit not found")
+			return
+		}
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update unit: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, updated, "Unit updated successfully")
+}
@@ -52,6 +52,7 @@ type UserCreateFailResp struct {
 // @Param avatar formData file true "Avatar image"
 // @Success 200 {object} services.UserCreateSuccessResp
 // @Failure 400 {object} services.UserCreateFailResp
+// @Failure 403 {object} services.UserCreateFailResp
 // @Failure 500 {object} services.UserCreateFailResp
 // @Router /stocklab-api/v1/users/create [post]
 // @Security BearerAuth
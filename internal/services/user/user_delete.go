@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 	"github.com/go-chi/chi/v5"
 )
@@ -29,6 +30,7 @@ type UserDeleteFailResp struct {
 // @Produce  json
 // @Success 200 {object} services.UserDeleteSuccessResp
 // @Failure 400 {object} services.UserDeleteFailResp
+// @Failure 403 {object} services.UserDeleteFailResp
 // @Failure 500 {object} services.UserDeleteFailResp
 // @Param id path int true "User ID"
 // @Router /stocklab-api/v1/users/delete/{id} [delete]
@@ -66,6 +68,12 @@ func DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Cabut semua session milik user yang dihapus
+	if err := authService.RevokeUserSessions(int64(id)); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to revoke sessions: "+err.Error())
+		return
+	}
+
 	// Response sukses
 	response := map[string]interface{}{
 		"id": id,
@@ -21,7 +21,7 @@ type UserDetail struct {
 	Phone  string    `json:"phone" example:"081234567890"`
 	Role   string    `json:"role" example:"staff"`
 	Joined time.Time `json:"joined" example:"2025-12-14"`
-	Avatar string    `json:"avatar"example:"base64imagestring"`
+	Avatar string    `json:"avatar" example:"base64imagestring"`
 }
 
 // UserDetailSuccessResp untuk response detail user
@@ -45,6 +45,7 @@ type UserDetailFailResp struct {
 // @Produce  json
 // @Success 200 {object} services.UserDetailSuccessResp
 // @Failure 404 {object} services.UserDetailFailResp
+// @Failure 403 {object} services.UserDetailFailResp
 // @Failure 500 {object} services.UserDetailFailResp
 // @Param id path int true "User ID"
 // @Router /stocklab-api/v1/users/detail/{id} [get]
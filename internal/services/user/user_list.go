@@ -36,6 +36,7 @@ type UserListFailResp struct {
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} services.UserListSuccessResp
+// @Failure 403 {object} services.UserListFailResp
 // @Failure 500 {object} services.UserListFailResp
 // @Router /stocklab-api/v1/users [get]
 // @Security BearerAuth
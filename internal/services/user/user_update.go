@@ -1,6 +1,7 @@
 package services
 
 import (
+	"database/sql"
 	"encoding/base64"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/Arrafll/StockLab-Go/internal/db"
+	authService "github.com/Arrafll/StockLab-Go/internal/services/auth"
 	"github.com/Arrafll/StockLab-Go/internal/utils"
 	"github.com/go-chi/chi/v5"
 	"golang.org/x/crypto/bcrypt"
@@ -18,6 +20,7 @@ type UserUpdate struct {
 	Password string `json:"password" example:"password123"`
 	Name     string `json:"name" example:"Andre"`
 	Phone    string `json:"phone" example:"09999999999"`
+	Role     string `json:"role" example:"staff"`
 	Avatar   string `json:"avatar" form:"avatar" example:"file"`
 }
 
@@ -52,10 +55,12 @@ type UserUpdateFailResp struct {
 // @Param password formData string false "Password"
 // @Param name formData string false "Name"
 // @Param phone formData string false "Phone"
+// @Param role formData string false "Role (admin | staff)"
 // @Param avatar formData file false "Avatar image"
 // @Success 200 {object} services.UserUpdateSuccessResp
 // @Failure 400 {object} services.UserUpdateFailResp
 // @Failure 404 {object} services.UserUpdateFailResp
+// @Failure 403 {object} services.UserUpdateFailResp
 // @Failure 500 {object} services.UserUpdateFailResp
 // @Router /stocklab-api/v1/users/update/{id} [put]
 // @Security BearerAuth
@@ -82,6 +87,12 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 	password := r.FormValue("password")
 	name := r.FormValue("name")
 	phone := r.FormValue("phone")
+	role := strings.ToLower(strings.TrimSpace(r.FormValue("role")))
+
+	if role != "" && role != authService.RoleAdmin && role != authService.RoleStaff {
+		utils.RespondError(w, http.StatusBadRequest, "Role must be admin or staff")
+		return
+	}
 
 	// Ambil file avatar jika ada
 	var avatarBytes []byte
@@ -96,15 +107,14 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Cek apakah user ada
-	var exists bool
-	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id=$1)", userID).Scan(&exists)
-	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
-		return
-	}
-	if !exists {
+	var currentRole string
+	err = db.DB.QueryRow("SELECT COALESCE(role, '') FROM users WHERE id=$1", userID).Scan(&currentRole)
+	if err == sql.ErrNoRows {
 		utils.RespondError(w, http.StatusNotFound, "User not found")
 		return
+	} else if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
 	}
 
 	// Cek email unik (hanya jika email diupdate)
@@ -154,6 +164,11 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 		args = append(args, phone)
 		argID++
 	}
+	if role != "" {
+		setParts = append(setParts, "role=$"+strconv.Itoa(argID))
+		args = append(args, role)
+		argID++
+	}
 	if avatarBytes != nil {
 		setParts = append(setParts, "avatar=$"+strconv.Itoa(argID))
 		args = append(args, avatarBytes)
@@ -176,6 +191,14 @@ func UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Ganti password atau role membatalkan semua session user tersebut
+	if password != "" || (role != "" && role != currentRole) {
+		if err := authService.RevokeUserSessions(int64(userID)); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to revoke sessions: "+err.Error())
+			return
+		}
+	}
+
 	// Convert avatar to base64 string
 	if avatarDB != nil {
 		updatedUser.Avatar = base64.StdEncoding.EncodeToString(avatarDB)
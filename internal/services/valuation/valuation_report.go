@@ -0,0 +1,166 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+const (
+	GroupByProduct   = "product"
+	GroupByCategory  = "category"
+	GroupByWarehouse = "warehouse"
+)
+
+// Nilai persediaan satu product, category atau warehouse
+type ValuationLine struct {
+	ID          int64        `json:"id" example:"1"`
+	Code        string       `json:"code" example:"SKU-000042"` // SKU, sku_code category atau code warehouse
+	Name        string       `json:"name" example:"Mie Sedap Goreng"`
+	Quantity    int64        `json:"quantity" example:"150"` // satuan dasar
+	Value       money.Amount `json:"value" swaggertype:"number" example:"450000.00"`
+	AverageCost money.Amount `json:"average_cost" swaggertype:"number" example:"3000.00"`
+}
+
+type ValuationReport struct {
+	AsOf          time.Time       `json:"as_of" example:"2025-12-31T23:59:59Z"`
+	GroupBy       string          `json:"group_by" example:"product"`
+	TotalQuantity int64           `json:"total_quantity" example:"1500"`
+	TotalValue    money.Amount    `json:"total_value" swaggertype:"number" example:"4500000.00"`
+	Lines         []ValuationLine `json:"lines"`
+}
+
+type ValuationSuccessResp struct {
+	Status  string          `json:"status" example:"success"`
+	Message string          `json:"message" example:"Valuation fetched successfully"`
+	Data    ValuationReport `json:"data"`
+}
+
+type ValuationFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"group_by must be product, category or warehouse"`
+}
+
+// GetValuation godoc
+// @Summary Inventory valuation
+// @Description Nilai persediaan per product, category atau warehouse pada waktu as_of, dihitung dari biaya perolehan movement masuk dikurangi COGS movement keluar sampai waktu tersebut. Stok yang sudah ada sebelum costing aktif bernilai nol.
+// @Tags valuation
+// @Produce json
+// @Param as_of query string false "Valuation time, RFC3339 or YYYY-MM-DD (end of that day); default now"
+// @Param group_by query string false "product (default) | category | warehouse"
+// @Param warehouse_id query int false "Only stock in this warehouse"
+// @Param category_id query int false "Only products in this category"
+// @Success 200 {object} services.ValuationSuccessResp
+// @Failure 400 {object} services.ValuationFailResp
+// @Failure 500 {object} services.ValuationFailResp
+// @Router /stocklab-api/v1/valuation [get]
+// @Security BearerAuth
+func GetValuation(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	// Waktu valuasi OPTIONAL, tanggal saja berarti akhir hari tersebut
+	asOf := time.Now().UTC()
+	if val := q.Get("as_of"); val != "" {
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			var day time.Time
+			day, err = time.Parse("2006-01-02", val)
+			t = day.Add(24*time.Hour - time.Second)
+		}
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "as_of must be RFC3339 or YYYY-MM-DD")
+			return
+		}
+		asOf = t
+	}
+
+	groupBy := strings.ToLower(strings.TrimSpace(q.Get("group_by")))
+	if groupBy == "" {
+		groupBy = GroupByProduct
+	}
+
+	var key, code, name, join string
+	switch groupBy {
+	case GroupByProduct:
+		key, code, name = "p.id", "p.sku", "p.name"
+	case GroupByCategory:
+		key, code, name = "c.id", "COALESCE(c.sku_code, '')", "COALESCE(c.name, '')"
+		join = "LEFT JOIN categories c ON c.id = p.category_id"
+	case GroupByWarehouse:
+		key, code, name = "wh.id", "wh.code", "wh.name"
+		join = "JOIN warehouses wh ON wh.id = v.warehouse_id"
+	default:
+		utils.RespondError(w, http.StatusBadRequest, "group_by must be product, category or warehouse")
+		return
+	}
+
+	// Filter warehouse dan category OPTIONAL
+	var warehouseID, categoryID interface{}
+	if val := q.Get("warehouse_id"); val != "" {
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "warehouse_id must be number")
+			return
+		}
+		warehouseID = id
+	}
+	if val := q.Get("category_id"); val != "" {
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "category_id must be number")
+			return
+		}
+		categoryID = id
+	}
+
+	rows, err := db.DB.Query(`
+		WITH v AS (
+			SELECT tr.product_id, tr.warehouse_id,
+				SUM(`+stock.SignedQuantitySQL("tr")+`) AS quantity,
+				SUM(`+stock.SignedCostSQL("tr")+`) AS value
+			FROM transactions tr
+			WHERE tr.created_at <= $1 AND ($2::bigint IS NULL OR tr.warehouse_id = $2)
+			GROUP BY tr.product_id, tr.warehouse_id
+		)
+		SELECT COALESCE(`+key+`, 0), `+code+`, `+name+`, SUM(v.quantity), SUM(v.value)
+		FROM v
+		JOIN products p ON p.id = v.product_id
+		`+join+`
+		WHERE $3::bigint IS NULL OR p.category_id = $3
+		GROUP BY 1, 2, 3
+		HAVING SUM(v.quantity) <> 0 OR SUM(v.value) <> 0
+		ORDER BY 3, 1
+	`, asOf, warehouseID, categoryID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch valuation: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	report := ValuationReport{AsOf: asOf, GroupBy: groupBy, Lines: []ValuationLine{}}
+	for rows.Next() {
+		var l ValuationLine
+		if err := rows.Scan(&l.ID, &l.Code, &l.Name, &l.Quantity, &l.Value); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan valuation: "+err.Error())
+			return
+		}
+		l.AverageCost = l.Value.MulDiv(1, l.Quantity)
+
+		report.TotalQuantity += l.Quantity
+		report.TotalValue += l.Value
+		report.Lines = append(report.Lines, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading valuation: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, report, "Valuation fetched successfully")
+}
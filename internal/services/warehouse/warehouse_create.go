@@ -0,0 +1,112 @@
+package services
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+type WarehouseCreateData struct {
+	ID      int64  `json:"id" example:"1"`
+	Code    string `json:"code" example:"WH-JKT"`
+	Name    string `json:"name" example:"Gudang Jakarta"`
+	Address string `json:"address" example:"Jl. Sudirman No. 1"`
+}
+
+type WarehouseCreateSuccessResp struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Warehouse created successfully"`
+	Data    WarehouseCreateData `json:"data"`
+}
+
+type WarehouseCreateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid parameter"`
+}
+
+// CreateWarehouse godoc
+// @Summary Create warehouse
+// @Description Create a stock location. A zero stock row is created for every existing product.
+// @Tags warehouses
+// @Accept multipart/form-data
+// @Produce json
+// @Param code formData string true "code"
+// @Param name formData string true "name"
+// @Param address formData string false "address"
+// @Success 200 {object} services.WarehouseCreateSuccessResp
+// @Failure 400 {object} services.WarehouseCreateFailResp
+// @Failure 403 {object} services.WarehouseCreateFailResp
+// @Failure 409 {object} services.WarehouseCreateFailResp
+// @Failure 500 {object} services.WarehouseCreateFailResp
+// @Router /stocklab-api/v1/warehouses/create [post]
+// @Security BearerAuth
+func CreateWarehouse(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(r.FormValue("code")))
+	name := strings.TrimSpace(r.FormValue("name"))
+	address := strings.TrimSpace(r.FormValue("address"))
+
+	if code == "" || name == "" {
+		utils.RespondError(w, http.StatusBadRequest, "code and name are required")
+		return
+	}
+
+	// Cek apakah code sudah ada
+	var exists bool
+	err := db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM warehouses WHERE UPPER(code) = $1)", code).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if exists {
+		utils.RespondError(w, http.StatusConflict, code+" is already registered")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	var warehouseID int64
+	err = tx.QueryRow(
+		`INSERT INTO warehouses (code, name, address) VALUES ($1, $2, $3) RETURNING id`,
+		code, name, address,
+	).Scan(&warehouseID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create warehouse: "+err.Error())
+		return
+	}
+
+	// Siapkan baris stok kosong untuk semua product di lokasi baru.
+	// Parent product dan kit tidak punya stok sendiri.
+	_, err = tx.Exec(`
+		INSERT INTO stocks (product_id, warehouse_id, quantity)
+		SELECT id, $1, 0 FROM products WHERE NOT is_parent AND NOT is_kit
+	`, warehouseID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to create stock: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, WarehouseCreateData{
+		ID:      warehouseID,
+		Code:    code,
+		Name:    name,
+		Address: address,
+	}, "Warehouse created successfully")
+}
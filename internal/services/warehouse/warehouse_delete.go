@@ -0,0 +1,99 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type WarehouseDeleteSuccessResp struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Warehouse deleted successfully"`
+}
+
+type WarehouseDeleteFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to delete warehouse"`
+}
+
+// DeleteWarehouse godoc
+// @Summary Delete warehouse
+// @Description Delete a warehouse. Only allowed when it holds no stock and has no transaction history.
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} services.WarehouseDeleteSuccessResp
+// @Failure 400 {object} services.WarehouseDeleteFailResp
+// @Failure 403 {object} services.WarehouseDeleteFailResp
+// @Failure 404 {object} services.WarehouseDeleteFailResp
+// @Failure 409 {object} services.WarehouseDeleteFailResp
+// @Failure 500 {object} services.WarehouseDeleteFailResp
+// @Router /stocklab-api/v1/warehouses/delete/{id} [delete]
+// @Security BearerAuth
+func DeleteWarehouse(w http.ResponseWriter, r *http.Request) {
+	// Ambil ID dari URL
+	idStr := chi.URLParam(r, "id")
+	warehouseID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Warehouse Id must be a number")
+		return
+	}
+
+	// Cek apakah warehouse ada
+	var exists bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM warehouses WHERE id=$1)", warehouseID).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !exists {
+		utils.RespondError(w, http.StatusNotFound, "Warehouse not found")
+		return
+	}
+
+	// Cek apakah masih ada stok atau riwayat transaksi di lokasi ini
+	var inUse bool
+	err = db.DB.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM stocks WHERE warehouse_id=$1 AND quantity <> 0)
+		    OR EXISTS(SELECT 1 FROM transactions WHERE warehouse_id=$1)
+	`, warehouseID).Scan(&inUse)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if inUse {
+		utils.RespondError(w, http.StatusConflict, "Cannot delete warehouse, it still has stock or transaction history")
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to start transaction: "+err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("DELETE FROM stocks WHERE warehouse_id=$1", warehouseID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete stock: "+err.Error())
+		return
+	}
+
+	if _, err = tx.Exec("DELETE FROM warehouses WHERE id=$1", warehouseID); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to delete warehouse: "+err.Error())
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Commit failed: "+err.Error())
+		return
+	}
+
+	// Response sukses
+	response := map[string]interface{}{
+		"id": warehouseID,
+	}
+	utils.RespondSuccess(w, response, "Warehouse deleted successfully")
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+)
+
+// Warehouse blueprint
+type Warehouse struct {
+	ID       int64  `json:"id" example:"1"`
+	Code     string `json:"code" example:"WH-JKT"`
+	Name     string `json:"name" example:"Gudang Jakarta"`
+	Address  string `json:"address" example:"Jl. Sudirman No. 1"`
+	Quantity int64  `json:"quantity" example:"1500"`
+}
+
+type WarehouseSuccessResp struct {
+	Status  string      `json:"status" example:"success"`
+	Message string      `json:"message" example:"Warehouses fetched successfully"`
+	Data    []Warehouse `json:"data"`
+}
+
+type WarehouseFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Failed to fetch warehouses"`
+}
+
+// GetWarehouseList godoc
+// @Summary Get list of warehouses
+// @Description Get all warehouses (stock locations) with their total stock quantity
+// @Tags warehouses
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} services.WarehouseSuccessResp
+// @Failure 500 {object} services.WarehouseFailResp
+// @Router /stocklab-api/v1/warehouses [get]
+// @Security BearerAuth
+func GetWarehouseList(w http.ResponseWriter, r *http.Request) {
+	rows, err := db.DB.Query(`
+		SELECT w.id, w.code, w.name, COALESCE(w.address, ''), COALESCE(SUM(s.quantity), 0)
+		FROM warehouses w
+		LEFT JOIN stocks s ON s.warehouse_id = w.id
+		GROUP BY w.id
+		ORDER BY w.id ASC
+	`)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to fetch warehouses: "+err.Error())
+		return
+	}
+	defer rows.Close()
+
+	warehouses := []Warehouse{}
+
+	for rows.Next() {
+		var wh Warehouse
+		if err := rows.Scan(&wh.ID, &wh.Code, &wh.Name, &wh.Address, &wh.Quantity); err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Failed to scan warehouses: "+err.Error())
+			return
+		}
+
+		warehouses = append(warehouses, wh)
+	}
+
+	// Cek apakah ada error saat iterasi rows
+	if err = rows.Err(); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Error reading warehouses: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, warehouses, "Warehouses fetched successfully")
+}
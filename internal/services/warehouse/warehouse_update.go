@@ -0,0 +1,128 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Arrafll/StockLab-Go/internal/db"
+	"github.com/Arrafll/StockLab-Go/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type WarehouseUpdateSuccessResp struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Warehouse updated successfully"`
+	Data    WarehouseCreateData `json:"data"`
+}
+
+type WarehouseUpdateFailResp struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Invalid parameter"`
+}
+
+// UpdateWarehouse godoc
+// @Summary Update warehouse
+// @Description Update an existing warehouse
+// @Tags warehouses
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Param code formData string false "code"
+// @Param name formData string false "name"
+// @Param address formData string false "address"
+// @Success 200 {object} services.WarehouseUpdateSuccessResp
+// @Failure 400 {object} services.WarehouseUpdateFailResp
+// @Failure 403 {object} services.WarehouseUpdateFailResp
+// @Failure 404 {object} services.WarehouseUpdateFailResp
+// @Failure 409 {object} services.WarehouseUpdateFailResp
+// @Failure 500 {object} services.WarehouseUpdateFailResp
+// @Router /stocklab-api/v1/warehouses/update/{id} [put]
+// @Security BearerAuth
+func UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
+	// Ambil ID dari URL
+	idStr := chi.URLParam(r, "id")
+	warehouseID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Warehouse Id must be a number")
+		return
+	}
+
+	// Parse multipart form (max 10MB)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid form data: "+err.Error())
+		return
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(r.FormValue("code")))
+	name := strings.TrimSpace(r.FormValue("name"))
+	address := strings.TrimSpace(r.FormValue("address"))
+
+	// Cek apakah warehouse ada
+	var exists bool
+	err = db.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM warehouses WHERE id=$1)", warehouseID).Scan(&exists)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+		return
+	}
+	if !exists {
+		utils.RespondError(w, http.StatusNotFound, "Warehouse not found")
+		return
+	}
+
+	// Cek code unik (hanya jika code diupdate)
+	if code != "" {
+		var codeExists bool
+		err = db.DB.QueryRow(
+			"SELECT EXISTS(SELECT 1 FROM warehouses WHERE UPPER(code) = $1 AND id <> $2)",
+			code, warehouseID,
+		).Scan(&codeExists)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Database error: "+err.Error())
+			return
+		}
+		if codeExists {
+			utils.RespondError(w, http.StatusConflict, code+" is already registered")
+			return
+		}
+	}
+
+	// Build query dinamis
+	setParts := []string{}
+	args := []interface{}{}
+	argID := 1
+
+	if code != "" {
+		setParts = append(setParts, "code=$"+strconv.Itoa(argID))
+		args = append(args, code)
+		argID++
+	}
+	if name != "" {
+		setParts = append(setParts, "name=$"+strconv.Itoa(argID))
+		args = append(args, name)
+		argID++
+	}
+	if address != "" {
+		setParts = append(setParts, "address=$"+strconv.Itoa(argID))
+		args = append(args, address)
+		argID++
+	}
+
+	if len(setParts) == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "No fields to update")
+		return
+	}
+
+	setParts = append(setParts, "updated_at=NOW()")
+	query := "UPDATE warehouses SET " + strings.Join(setParts, ", ") + " WHERE id=$" + strconv.Itoa(argID) + " RETURNING id, code, name, COALESCE(address, '')"
+	args = append(args, warehouseID)
+
+	var updated WarehouseCreateData
+	err = db.DB.QueryRow(query, args...).Scan(&updated.ID, &updated.Code, &updated.Name, &updated.Address)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "Failed to update warehouse: "+err.Error())
+		return
+	}
+
+	utils.RespondSuccess(w, updated, "Warehouse updated successfully")
+}
@@ -0,0 +1,184 @@
+package sku
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Pola yang dipakai jika tidak ada pola default di sku_patterns
+const DefaultPattern = "SKU-{SEQ:6}"
+
+// Panjang maksimum SKU (kolom products.sku)
+const MaxLength = 100
+
+// Batas percobaan jika SKU hasil generate sudah dipakai SKU manual
+const maxAttempts = 10
+
+var (
+	ErrInvalidPattern = errors.New("Invalid SKU pattern")
+	ErrInvalidSKU     = errors.New("Invalid SKU")
+	ErrSKUExists      = errors.New("SKU already exists")
+)
+
+var tokenRe = regexp.MustCompile(`\{([A-Z]+)(?::(\d+))?\}`)
+
+// ValidatePattern memastikan pola hanya memakai token yang dikenal
+// ({CATEGORY}, {BRAND}, {SEQ} atau {SEQ:n}) dan tepat satu {SEQ}
+// supaya setiap SKU hasil generate unik.
+func ValidatePattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("%w: pattern is required", ErrInvalidPattern)
+	}
+
+	seq := 0
+	for _, m := range tokenRe.FindAllStringSubmatch(pattern, -1) {
+		switch m[1] {
+		case "SEQ":
+			seq++
+			if m[2] != "" {
+				if n, _ := strconv.Atoi(m[2]); n < 1 || n > 12 {
+					return fmt.Errorf("%w: {SEQ:n} width must be between 1 and 12", ErrInvalidPattern)
+				}
+			}
+		case "CATEGORY", "BRAND":
+			if m[2] != "" {
+				return fmt.Errorf("%w: {%s} does not take a width", ErrInvalidPattern, m[1])
+			}
+		default:
+			return fmt.Errorf("%w: unknown token {%s}", ErrInvalidPattern, m[1])
+		}
+	}
+	if seq != 1 {
+		return fmt.Errorf("%w: pattern must contain exactly one {SEQ} token", ErrInvalidPattern)
+	}
+
+	// Sisa pola di luar token tidak boleh berisi kurung kurawal atau spasi
+	literal := tokenRe.ReplaceAllString(pattern, "")
+	if strings.ContainsAny(literal, "{} \t\n") {
+		return fmt.Errorf("%w: unexpected character in %q", ErrInvalidPattern, pattern)
+	}
+	return nil
+}
+
+// Validate memeriksa SKU yang diinput manual
+func Validate(code string) error {
+	if code == "" || len(code) > MaxLength {
+		return fmt.Errorf("%w: must be 1 to %d characters", ErrInvalidSKU, MaxLength)
+	}
+	if strings.IndexFunc(code, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%w: must not contain spaces", ErrInvalidSKU)
+	}
+	return nil
+}
+
+var codeRe = regexp.MustCompile(`^[A-Z0-9]{1,10}$`)
+
+// ValidateCode memeriksa kode category untuk token {CATEGORY}
+func ValidateCode(code string) error {
+	if !codeRe.MatchString(code) {
+		return fmt.Errorf("%w: sku_code must be 1 to 10 letters or digits", ErrInvalidSKU)
+	}
+	return nil
+}
+
+// Code menyusun kode pendek dari nama: 3 huruf/angka pertama, huruf besar
+func Code(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			if b.Len() == 3 {
+				break
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
+
+// Render mengganti token pola dengan kode category, kode brand dan nomor urut
+func Render(pattern, categoryCode, brandCode string, seq int64) string {
+	return tokenRe.ReplaceAllStringFunc(pattern, func(tok string) string {
+		m := tokenRe.FindStringSubmatch(tok)
+		switch m[1] {
+		case "CATEGORY":
+			return categoryCode
+		case "BRAND":
+			return brandCode
+		case "SEQ":
+			width, _ := strconv.Atoi(m[2])
+			return fmt.Sprintf("%0*d", width, seq)
+		}
+		return tok
+	})
+}
+
+// Generate membuat SKU baru untuk category dan brand memakai pola category
+// (atau pola default) dan nomor urut dari product_sku_seq.
+func Generate(tx *sql.Tx, categoryID int64, brand string) (string, error) {
+	var pattern, categoryName string
+	var categoryCode sql.NullString
+	err := tx.QueryRow(`
+		SELECT COALESCE(
+			(SELECT pattern FROM sku_patterns WHERE category_id = $1),
+			(SELECT pattern FROM sku_patterns WHERE category_id IS NULL),
+			$2
+		), COALESCE(c.name, ''), c.sku_code
+		FROM (SELECT 1) one
+		LEFT JOIN categories c ON c.id = $1
+	`, categoryID, DefaultPattern).Scan(&pattern, &categoryName, &categoryCode)
+	if err != nil {
+		return "", err
+	}
+
+	catCode := categoryCode.String
+	if catCode == "" {
+		catCode = Code(categoryName)
+	}
+	brandCode := Code(brand)
+
+	// Nomor urut selalu unik, tapi SKU manual bisa saja sudah memakai hasilnya
+	for i := 0; i < maxAttempts; i++ {
+		var seq int64
+		if err := tx.QueryRow(`SELECT nextval('product_sku_seq')`).Scan(&seq); err != nil {
+			return "", err
+		}
+		code := Render(pattern, catCode, brandCode, seq)
+
+		taken, err := Exists(tx, code, 0)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("%w: could not generate a free SKU after %d attempts", ErrSKUExists, maxAttempts)
+}
+
+// Exists mengecek apakah SKU sudah dipakai product lain (selain excludeID)
+func Exists(tx *sql.Tx, code string, excludeID int64) (bool, error) {
+	var taken bool
+	err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM products WHERE LOWER(sku) = LOWER($1) AND id <> $2)`, code, excludeID).Scan(&taken)
+	return taken, err
+}
+
+// StatusCode memetakan error SKU ke HTTP status
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrSKUExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidPattern), errors.Is(err, ErrInvalidSKU):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
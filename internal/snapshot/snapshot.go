@@ -0,0 +1,122 @@
+package snapshot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/stock"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrSnapshotExists    = errors.New("A snapshot already exists at this time")
+	ErrSnapshotTooRecent = errors.New("snapshot_at must be at least 5 minutes in the past")
+)
+
+// Create menyimpan saldo stok per product per warehouse pada waktu at, dihitung dari
+// snapshot sebelumnya ditambah transaksi sesudahnya. userID 0 untuk job terjadwal.
+func Create(tx *sql.Tx, at time.Time, notes string, userID int64) (int64, error) {
+	// Transaksi yang masih berjalan bisa di-commit dengan created_at sebelum snapshot_at
+	if at.After(time.Now().Add(-stock.SnapshotLag)) {
+		return 0, ErrSnapshotTooRecent
+	}
+
+	// Subquery saldo tidak melihat snapshot yang baru di-insert di CTE yang sama,
+	// jadi saldo dihitung dari snapshot sebelumnya
+	_, err := tx.Exec(`
+		WITH snap AS (
+			INSERT INTO stock_snapshots (snapshot_at, notes, created_by)
+			VALUES ($1, NULLIF($2, ''), $3)
+			RETURNING id
+		)
+		INSERT INTO stock_snapshot_lines (snapshot_id, product_id, warehouse_id, quantity)
+		SELECT snap.id, b.product_id, b.warehouse_id, b.quantity
+		FROM snap, (`+stock.BalanceAsOfSQL("$1::timestamptz")+`) b
+		WHERE b.quantity <> 0
+	`, at, notes, nullID(userID))
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return 0, ErrSnapshotExists
+		}
+		return 0, err
+	}
+
+	var id int64
+	err = tx.QueryRow(`SELECT id FROM stock_snapshots WHERE snapshot_at = $1`, at).Scan(&id)
+	return id, err
+}
+
+// Schedule membuat snapshot setiap interval sampai ctx selesai, supaya laporan
+// stok per tanggal tidak perlu menjumlahkan seluruh ledger. Snapshot dilewati jika
+// tidak ada transaksi baru sejak snapshot terakhir.
+func Schedule(ctx context.Context, db *sql.DB, interval time.Duration, info, errLog *log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			id, err := createScheduled(db, time.Now().UTC().Add(-stock.SnapshotLag).Truncate(time.Second))
+			if err != nil {
+				errLog.Printf("Stock snapshot failed: %v", err)
+				continue
+			}
+			if id != 0 {
+				info.Printf("Stock snapshot #%d created", id)
+			}
+		}
+	}
+}
+
+// createScheduled membuat snapshot pada at, atau mengembalikan 0 jika tidak ada
+// transaksi sejak snapshot terakhir
+func createScheduled(db *sql.DB, at time.Time) (int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var moved bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM transactions
+			WHERE created_at <= $1
+				AND created_at > COALESCE((SELECT MAX(snapshot_at) FROM stock_snapshots WHERE snapshot_at <= $1), '-infinity'::timestamptz)
+		)
+	`, at).Scan(&moved)
+	if err != nil || !moved {
+		return 0, err
+	}
+
+	id, err := Create(tx, at, "Scheduled snapshot", 0)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func nullID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// StatusCode memetakan error snapshot ke HTTP status
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrSnapshotExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrSnapshotTooRecent):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,82 @@
+package stock
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrInvalidBarcode  = errors.New("Invalid barcode")
+	ErrBarcodeNotFound = errors.New("Barcode not found")
+)
+
+// ValidBarcode memeriksa panjang (EAN-8, UPC-A, EAN-13, GTIN-14) dan check digit GS1
+func ValidBarcode(code string) error {
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return fmt.Errorf("%w %q: must be 8, 12, 13 or 14 digits", ErrInvalidBarcode, code)
+	}
+
+	sum := 0
+	for i := len(code) - 1; i >= 0; i-- {
+		c := code[i]
+		if c < '0' || c > '9' {
+			return fmt.Errorf("%w %q: must contain digits only", ErrInvalidBarcode, code)
+		}
+		if i == len(code)-1 {
+			continue
+		}
+		// Bobot 3 dan 1 bergantian dari digit tepat sebelum check digit
+		digit := int(c - '0')
+		if (len(code)-1-i)%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	if check := (10 - sum%10) % 10; int(code[len(code)-1]-'0') != check {
+		return fmt.Errorf("%w %q: check digit should be %d", ErrInvalidBarcode, code, check)
+	}
+	return nil
+}
+
+// BarcodeVariants mengembalikan bentuk lain barcode yang sama:
+// UPC-A 12 digit juga dibaca scanner sebagai EAN-13 dengan awalan 0, dan sebaliknya
+func BarcodeVariants(code string) []string {
+	code = strings.TrimSpace(code)
+	variants := []string{code}
+	switch {
+	case len(code) == 12:
+		variants = append(variants, "0"+code)
+	case len(code) == 13 && code[0] == '0':
+		variants = append(variants, code[1:])
+	}
+	return variants
+}
+
+// Barcode terdaftar beserta product dan satuan yang diwakilinya
+type Barcode struct {
+	ProductID int64
+	UnitCode  string // kosong = satuan dasar product
+}
+
+// FindBarcode mencari product dan satuan untuk barcode hasil scan
+func FindBarcode(tx *sql.Tx, code string) (Barcode, error) {
+	var b Barcode
+	err := tx.QueryRow(`
+		SELECT pb.product_id, COALESCE(u.code, '')
+		FROM product_barcodes pb
+		LEFT JOIN units u ON u.id = pb.unit_id
+		WHERE pb.barcode = ANY($1::text[])
+		LIMIT 1
+	`, pq.Array(BarcodeVariants(code))).Scan(&b.ProductID, &b.UnitCode)
+	if err == sql.ErrNoRows {
+		return b, fmt.Errorf("%w: %s", ErrBarcodeNotFound, code)
+	}
+	return b, err
+}
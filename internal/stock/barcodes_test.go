@@ -0,0 +1,61 @@
+package stock
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{"EAN-8", "96385074", false},
+		{"UPC-A", "036000291452", false},
+		{"EAN-13", "4006381333931", false},
+		{"GTIN-14", "10012345678902", false},
+		{"EAN-13 check digit 0", "8992761134020", false},
+		{"wrong check digit", "4006381333932", true},
+		{"too short", "1234567", true},
+		{"length between formats", "12345678901", true},
+		{"too long", "123456789012345", true},
+		{"letters", "40063813339A1", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidBarcode(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidBarcode(%q) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidBarcode) {
+				t.Errorf("ValidBarcode(%q) error = %v, want ErrInvalidBarcode", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestBarcodeVariants(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []string
+	}{
+		{"UPC-A gets EAN-13 form", "036000291452", []string{"036000291452", "0036000291452"}},
+		{"EAN-13 with leading zero gets UPC-A form", "0036000291452", []string{"0036000291452", "036000291452"}},
+		{"EAN-13 without leading zero", "4006381333931", []string{"4006381333931"}},
+		{"EAN-8", "96385074", []string{"96385074"}},
+		{"surrounding spaces trimmed", " 036000291452 ", []string{"036000291452", "0036000291452"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BarcodeVariants(tt.code); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BarcodeVariants(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
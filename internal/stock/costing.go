@@ -0,0 +1,276 @@
+package stock
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Arrafll/StockLab-Go/internal/money"
+)
+
+const (
+	CostFIFO    = "FIFO" // keluar memakai layer biaya tertua lebih dulu
+	CostAverage = "AVG"  // moving weighted average, satu layer per warehouse
+)
+
+var (
+	ErrInvalidCostingMethod = errors.New("Invalid costing method")
+	ErrCostOnOutbound       = errors.New("Unit cost can only be given for inbound movements")
+)
+
+// ValidCostingMethod memeriksa metode costing product
+func ValidCostingMethod(method string) error {
+	if method != CostFIFO && method != CostAverage {
+		return fmt.Errorf("%w %q: must be %s or %s", ErrInvalidCostingMethod, method, CostFIFO, CostAverage)
+	}
+	return nil
+}
+
+// Cost adalah nilai persediaan satu movement: biaya perolehan untuk movement masuk,
+// harga pokok (COGS) untuk movement keluar. Value selalu positif.
+type Cost struct {
+	UnitCost money.Amount
+	Value    money.Amount
+}
+
+// applyCost membukukan biaya movement ke cost_layers. Movement masuk membuka
+// (atau untuk AVG menambah) layer dengan nilai m.CostValue, atau biaya rata-rata
+// saat ini jika kosong. Movement keluar mengambil layer tertua lebih dulu; untuk
+// AVG hanya ada satu layer sehingga hasilnya biaya rata-rata.
+// Layer masuk baru ditautkan ke transaksi lewat linkCostLayer setelah insert.
+func applyCost(tx *sql.Tx, bal Balance, m Movement) (Cost, int64, error) {
+	if IsInbound(m.MoveType) {
+		value := money.Amount(0)
+		if m.CostValue != nil {
+			value = *m.CostValue
+		} else {
+			avg, err := averageCost(tx, m.Key)
+			if err != nil {
+				return Cost{}, 0, err
+			}
+			value = avg.Mul(m.Quantity)
+		}
+
+		layerID, err := addCostLayer(tx, bal.CostingMethod, m.Key, m.Quantity, value)
+		if err != nil {
+			return Cost{}, 0, err
+		}
+		return Cost{UnitCost: value.MulDiv(1, m.Quantity), Value: value}, layerID, nil
+	}
+
+	if m.CostValue != nil {
+		return Cost{}, 0, ErrCostOnOutbound
+	}
+	value, err := consumeCostLayers(tx, m.Key, m.Quantity)
+	if err != nil {
+		return Cost{}, 0, err
+	}
+	return Cost{UnitCost: value.MulDiv(1, m.Quantity), Value: value}, 0, nil
+}
+
+func addCostLayer(tx *sql.Tx, method string, k Key, qty int64, value money.Amount) (int64, error) {
+	var layerID int64
+
+	// AVG: gabungkan ke layer yang masih terbuka supaya biaya menjadi rata-rata tertimbang
+	if method == CostAverage {
+		err := tx.QueryRow(`
+			UPDATE cost_layers
+			SET quantity = quantity + $3, remaining = remaining + $3, remaining_value = remaining_value + $4,
+				unit_cost = ROUND((remaining_value + $4) / (remaining + $3), 2), updated_at = NOW()
+			WHERE id = (
+				SELECT id FROM cost_layers
+				WHERE product_id = $1 AND warehouse_id = $2 AND remaining > 0
+				ORDER BY created_at, id LIMIT 1
+			)
+			RETURNING id
+		`, k.ProductID, k.WarehouseID, qty, value).Scan(&layerID)
+		if err == nil {
+			return 0, nil // layer lama tetap tertaut ke transaksi pembukanya
+		} else if err != sql.ErrNoRows {
+			return 0, err
+		}
+	}
+
+	err := tx.QueryRow(`
+		INSERT INTO cost_layers (product_id, warehouse_id, quantity, remaining, remaining_value, unit_cost)
+		VALUES ($1, $2, $3, $3, $4, ROUND($4::numeric / $3, 2))
+		RETURNING id
+	`, k.ProductID, k.WarehouseID, qty, value).Scan(&layerID)
+	return layerID, err
+}
+
+// costLayer adalah sisa satu layer biaya yang masih terbuka
+type costLayer struct {
+	id        int64
+	remaining int64
+	value     money.Amount
+}
+
+// layerTake adalah quantity dan nilai yang diambil dari satu layer
+type layerTake struct {
+	layerID  int64
+	quantity int64
+	value    money.Amount
+}
+
+// consumeCostLayers mengambil qty dari layer tertua dan mengembalikan nilainya.
+// Quantity yang tidak tertutup layer (stok sebelum costing aktif) bernilai nol.
+func consumeCostLayers(tx *sql.Tx, k Key, qty int64) (money.Amount, error) {
+	rows, err := tx.Query(`
+		SELECT id, remaining, remaining_value
+		FROM cost_layers
+		WHERE product_id = $1 AND warehouse_id = $2 AND remaining > 0
+		ORDER BY created_at, id
+		FOR UPDATE
+	`, k.ProductID, k.WarehouseID)
+	if err != nil {
+		return 0, err
+	}
+
+	var layers []costLayer
+	for rows.Next() {
+		var l costLayer
+		if err := rows.Scan(&l.id, &l.remaining, &l.value); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		layers = append(layers, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	takes, total := takeCostLayers(layers, qty)
+	for _, t := range takes {
+		if _, err := tx.Exec(`
+			UPDATE cost_layers
+			SET remaining = remaining - $2, remaining_value = remaining_value - $3, updated_at = NOW()
+			WHERE id = $1
+		`, t.layerID, t.quantity, t.value); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+// takeCostLayers membagi qty ke layer berurutan (tertua lebih dulu) dan mengembalikan
+// bagian tiap layer beserta total nilainya. Layer sebagian bernilai proporsional.
+func takeCostLayers(layers []costLayer, qty int64) ([]layerTake, money.Amount) {
+	var takes []layerTake
+	total := money.Amount(0)
+	for _, l := range layers {
+		if qty == 0 {
+			break
+		}
+		take := l.remaining
+		if qty < take {
+			take = qty
+		}
+
+		// Layer yang habis diambil seluruh nilainya supaya tidak ada sisa pembulatan
+		portion := l.value
+		if take < l.remaining {
+			portion = l.value.MulDiv(take, l.remaining)
+		}
+
+		takes = append(takes, layerTake{layerID: l.id, quantity: take, value: portion})
+		total += portion
+		qty -= take
+	}
+	return takes, total
+}
+
+// averageCost adalah biaya rata-rata stok product di warehouse, atau di semua
+// warehouse jika warehouse tersebut belum punya layer. Nol jika belum ada biaya sama sekali.
+func averageCost(tx *sql.Tx, k Key) (money.Amount, error) {
+	var value money.Amount
+	var qty int64
+	err := tx.QueryRow(`
+		SELECT COALESCE(SUM(remaining_value), 0), COALESCE(SUM(remaining), 0)
+		FROM cost_layers
+		WHERE product_id = $1 AND warehouse_id = $2 AND remaining > 0
+	`, k.ProductID, k.WarehouseID).Scan(&value, &qty)
+	if err != nil {
+		return 0, err
+	}
+	if qty == 0 {
+		err = tx.QueryRow(`
+			SELECT COALESCE(SUM(remaining_value), 0), COALESCE(SUM(remaining), 0)
+			FROM cost_layers
+			WHERE product_id = $1 AND remaining > 0
+		`, k.ProductID).Scan(&value, &qty)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return value.MulDiv(1, qty), nil
+}
+
+// UnitCostSQL mengembalikan ekspresi SQL biaya satuan saat ini seperti averageCost:
+// rata-rata layer terbuka di warehouse, atau di semua warehouse jika warehouse tersebut
+// belum punya layer, dan nol jika belum ada biaya sama sekali.
+func UnitCostSQL(product, warehouse string) string {
+	avg := "SELECT ROUND(SUM(remaining_value) / NULLIF(SUM(remaining), 0), 2) FROM cost_layers WHERE remaining > 0 AND product_id = " + product
+	return "COALESCE((" + avg + " AND warehouse_id = " + warehouse + "), (" + avg + "), 0)"
+}
+
+// linkCostLayer menautkan layer masuk ke transaksi yang membukanya
+func linkCostLayer(tx *sql.Tx, layerID, txID int64) error {
+	if layerID == 0 {
+		return nil
+	}
+	_, err := tx.Exec(`UPDATE cost_layers SET transaction_id = $1 WHERE id = $2`, txID, layerID)
+	return err
+}
+
+// CostOf mengembalikan biaya yang dibukukan untuk transaksi, mis. COGS sisi OUT
+// transfer yang dipakai sebagai biaya sisi IN.
+func CostOf(tx *sql.Tx, txID int64) (Cost, error) {
+	var c Cost
+	err := tx.QueryRow(`SELECT COALESCE(unit_cost, 0), COALESCE(cost_value, 0) FROM transactions WHERE id = $1`, txID).
+		Scan(&c.UnitCost, &c.Value)
+	return c, err
+}
+
+// SetCostingMethod mengganti metode costing product. Beralih ke AVG menggabungkan
+// layer yang masih terbuka per warehouse menjadi satu layer dengan biaya rata-rata.
+func SetCostingMethod(tx *sql.Tx, productID int64, method string) error {
+	if err := ValidCostingMethod(method); err != nil {
+		return err
+	}
+
+	// Kunci stok product supaya tidak ada movement di tengah penggabungan layer
+	if _, err := tx.Exec(`SELECT 1 FROM stocks WHERE product_id = $1 ORDER BY warehouse_id FOR UPDATE`, productID); err != nil {
+		return err
+	}
+
+	if method == CostAverage {
+		if _, err := tx.Exec(`
+			WITH merged AS (
+				SELECT warehouse_id, MIN(id) AS keep_id, SUM(remaining) AS remaining, SUM(remaining_value) AS remaining_value
+				FROM cost_layers
+				WHERE product_id = $1 AND remaining > 0
+				GROUP BY warehouse_id
+				HAVING COUNT(*) > 1
+			), kept AS (
+				UPDATE cost_layers cl
+				SET remaining = m.remaining, remaining_value = m.remaining_value,
+					unit_cost = ROUND(m.remaining_value / m.remaining, 2), updated_at = NOW()
+				FROM merged m
+				WHERE cl.id = m.keep_id
+				RETURNING cl.id
+			)
+			UPDATE cost_layers cl
+			SET remaining = 0, remaining_value = 0, updated_at = NOW()
+			FROM merged m
+			WHERE cl.product_id = $1 AND cl.warehouse_id = m.warehouse_id AND cl.remaining > 0 AND cl.id <> m.keep_id
+		`, productID); err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Exec(`UPDATE products SET costing_method = $1 WHERE id = $2`, method, productID)
+	return err
+}
@@ -0,0 +1,93 @@
+package stock
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Arrafll/StockLab-Go/internal/money"
+)
+
+func TestTakeCostLayers(t *testing.T) {
+	layers := []costLayer{
+		{id: 1, remaining: 10, value: 100000}, // 10 x 100.00
+		{id: 2, remaining: 5, value: 60000},   // 5 x 120.00
+		{id: 3, remaining: 3, value: 1000},    // 3 x 3.33, nilai tidak habis dibagi
+	}
+
+	tests := []struct {
+		name      string
+		layers    []costLayer
+		qty       int64
+		wantTakes []layerTake
+		wantTotal money.Amount
+	}{
+		{
+			name:      "partial oldest layer",
+			layers:    layers,
+			qty:       4,
+			wantTakes: []layerTake{{1, 4, 40000}},
+			wantTotal: 40000,
+		},
+		{
+			name:      "exactly one layer",
+			layers:    layers,
+			qty:       10,
+			wantTakes: []layerTake{{1, 10, 100000}},
+			wantTotal: 100000,
+		},
+		{
+			name:      "spans two layers",
+			layers:    layers,
+			qty:       12,
+			wantTakes: []layerTake{{1, 10, 100000}, {2, 2, 24000}},
+			wantTotal: 124000,
+		},
+		{
+			name:      "partial layer is rounded",
+			layers:    layers[2:],
+			qty:       1,
+			wantTakes: []layerTake{{3, 1, 333}},
+			wantTotal: 333,
+		},
+		{
+			name:      "emptied layer takes its whole value",
+			layers:    []costLayer{{id: 3, remaining: 2, value: 667}},
+			qty:       2,
+			wantTakes: []layerTake{{3, 2, 667}},
+			wantTotal: 667,
+		},
+		{
+			name:      "quantity beyond layers is valued at zero",
+			layers:    layers,
+			qty:       25,
+			wantTakes: []layerTake{{1, 10, 100000}, {2, 5, 60000}, {3, 3, 1000}},
+			wantTotal: 161000,
+		},
+		{
+			name:      "no layers",
+			layers:    nil,
+			qty:       5,
+			wantTakes: nil,
+			wantTotal: 0,
+		},
+		{
+			name:      "zero quantity",
+			layers:    layers,
+			qty:       0,
+			wantTakes: nil,
+			wantTotal: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			takes, total := takeCostLayers(tt.layers, tt.qty)
+			if !reflect.DeepEqual(takes, tt.wantTakes) {
+				t.Errorf("takes = %v, want %v", takes, tt.wantTakes)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package stock
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrKitNoStock  = errors.New("Kit has no stock of its own, its availability comes from its components")
+	ErrKitInbound  = errors.New("Kits can only be issued, receive or adjust the components instead")
+	ErrKitTracking = errors.New("Lot and serial numbers cannot be given for a kit")
+)
+
+// Satu baris bill of materials: jumlah komponen (satuan dasar) per 1 kit
+type KitComponent struct {
+	ProductID int64
+	Quantity  int64
+}
+
+// KitComponents mengambil bill of materials kit, kosong jika product bukan kit
+func KitComponents(tx *sql.Tx, productID int64) ([]KitComponent, error) {
+	rows, err := tx.Query(`
+		SELECT kc.component_id, kc.quantity
+		FROM kit_components kc
+		JOIN products p ON p.id = kc.kit_id AND p.is_kit
+		WHERE kc.kit_id = $1
+		ORDER BY kc.component_id
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	components := []KitComponent{}
+	for rows.Next() {
+		var c KitComponent
+		if err := rows.Scan(&c.ProductID, &c.Quantity); err != nil {
+			return nil, err
+		}
+		components = append(components, c)
+	}
+
+	return components, rows.Err()
+}
+
+// ExpandKit mengganti movement keluar sebuah kit dengan satu movement per komponen,
+// sehingga stok semua komponen berkurang dalam DB transaction yang sama.
+// Movement untuk product biasa dikembalikan apa adanya.
+func ExpandKit(tx *sql.Tx, m Movement) ([]Movement, error) {
+	components, err := KitComponents(tx, m.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) == 0 {
+		return []Movement{m}, nil
+	}
+
+	if IsInbound(m.MoveType) {
+		return nil, fmt.Errorf("%w: product %d", ErrKitInbound, m.ProductID)
+	}
+	if m.LotNumber != "" || len(m.Lots) > 0 || len(m.Serials) > 0 {
+		return nil, fmt.Errorf("%w: product %d", ErrKitTracking, m.ProductID)
+	}
+
+	movements := make([]Movement, 0, len(components))
+	for _, c := range components {
+		movements = append(movements, Movement{
+			Key:          Key{ProductID: c.ProductID, WarehouseID: m.WarehouseID},
+			UserID:       m.UserID,
+			Quantity:     m.Quantity * c.Quantity,
+			MoveType:     m.MoveType,
+			TransferID:   m.TransferID,
+			DocumentID:   m.DocumentID,
+			AssemblyID:   m.AssemblyID,
+			ReasonID:     m.ReasonID,
+			CountID:      m.CountID,
+			Notes:        m.Notes,
+			KitProductID: m.ProductID,
+			KitQuantity:  m.Quantity,
+		})
+	}
+
+	return movements, nil
+}
+
+// MovementKeys mengumpulkan key stocks yang harus di-lock untuk movements
+func MovementKeys(movements []Movement) []Key {
+	keys := make([]Key, 0, len(movements))
+	for _, m := range movements {
+		keys = append(keys, m.Key)
+	}
+	return keys
+}
+
+// missingStockError membedakan kit (memang tidak punya baris stocks) dari stok yang belum dibuat
+func missingStockError(tx *sql.Tx, k Key) error {
+	var isKit bool
+	err := tx.QueryRow(`SELECT is_kit FROM products WHERE id = $1`, k.ProductID).Scan(&isKit)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if isKit {
+		return fmt.Errorf("%w: product %d", ErrKitNoStock, k.ProductID)
+	}
+	return fmt.Errorf("%w for product %d at warehouse %d", ErrStockNotFound, k.ProductID, k.WarehouseID)
+}
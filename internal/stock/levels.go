@@ -0,0 +1,51 @@
+package stock
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LevelsSQL mengembalikan query stok beserta ambang reorder yang berlaku.
+// Kolom: product_id, warehouse_id, quantity, reorder_point, safety_stock, max_level.
+//
+// byLocation=false menjumlahkan stok semua warehouse dan memakai ambang di products
+// (warehouse_id selalu NULL). Parent product dan kit tidak punya baris stocks sendiri
+// (stoknya ada di varian atau komponen) sehingga tidak ikut. byLocation=true satu baris per product x warehouse,
+// ambang diambil dari override di stocks jika ada.
+func LevelsSQL(byLocation bool) string {
+	if byLocation {
+		return `
+			SELECT s.product_id, s.warehouse_id::bigint AS warehouse_id, s.quantity,
+				COALESCE(s.reorder_point, p.reorder_point) AS reorder_point,
+				COALESCE(s.safety_stock, p.safety_stock) AS safety_stock,
+				COALESCE(s.max_level, p.max_level) AS max_level
+			FROM stocks s
+			JOIN products p ON p.id = s.product_id
+		`
+	}
+
+	return `
+		SELECT p.id AS product_id, NULL::bigint AS warehouse_id, COALESCE(SUM(s.quantity), 0) AS quantity,
+			p.reorder_point, p.safety_stock, p.max_level
+		FROM products p
+		LEFT JOIN stocks s ON s.product_id = p.id
+		WHERE NOT p.is_parent AND NOT p.is_kit
+		GROUP BY p.id
+	`
+}
+
+var ErrInvalidLevels = errors.New("Invalid stock levels")
+
+// ValidateLevels memastikan safety_stock <= reorder_point <= max_level (max_level opsional)
+func ValidateLevels(reorderPoint, safetyStock int, maxLevel *int) error {
+	if reorderPoint < 0 || safetyStock < 0 {
+		return fmt.Errorf("%w: reorder_point and safety_stock must not be negative", ErrInvalidLevels)
+	}
+	if safetyStock > reorderPoint {
+		return fmt.Errorf("%w: safety_stock must not exceed reorder_point", ErrInvalidLevels)
+	}
+	if maxLevel != nil && *maxLevel < reorderPoint {
+		return fmt.Errorf("%w: max_level must not be lower than reorder_point", ErrInvalidLevels)
+	}
+	return nil
+}
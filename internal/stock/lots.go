@@ -0,0 +1,255 @@
+package stock
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultLot menampung stok product lot-tracked yang tidak diketahui lot-nya,
+// misalnya stok lama saat tracking diaktifkan atau selisih lebih hasil stock opname
+const DefaultLot = "UNLABELLED"
+
+var (
+	ErrLotRequired   = errors.New("Lot number is required")
+	ErrLotNotFound   = errors.New("Lot not found")
+	ErrLotNotTracked = errors.New("Product is not lot tracked")
+	ErrInvalidLot    = errors.New("Invalid lot")
+)
+
+// LotQty adalah quantity satu lot yang ditambah atau dikurangi oleh satu movement
+type LotQty struct {
+	LotID      int64
+	LotNumber  string
+	ExpiryDate string // YYYY-MM-DD, kosong jika lot tanpa tanggal kedaluwarsa
+	Quantity   int64
+}
+
+// applyLots membukukan movement ke stock_lots untuk product lot-tracked.
+// Masuk wajib menyebut lot (kecuali ADJ_IN yang masuk ke DefaultLot),
+// keluar memakai lot yang disebut atau FEFO: lot dengan kedaluwarsa terdekat dulu.
+func applyLots(tx *sql.Tx, bal Balance, m Movement) ([]LotQty, error) {
+	if !bal.LotTracked {
+		if m.LotNumber != "" || len(m.Lots) > 0 {
+			return nil, fmt.Errorf("%w: product %d", ErrLotNotTracked, m.ProductID)
+		}
+		return nil, nil
+	}
+
+	if IsInbound(m.MoveType) {
+		return receiveLots(tx, m)
+	}
+	return issueLots(tx, m)
+}
+
+func receiveLots(tx *sql.Tx, m Movement) ([]LotQty, error) {
+	lots := m.Lots
+	if len(lots) == 0 {
+		number := m.LotNumber
+		if number == "" {
+			if m.MoveType != MoveAdjustIn {
+				return nil, fmt.Errorf("%w for product %d", ErrLotRequired, m.ProductID)
+			}
+			number = DefaultLot
+		}
+		lots = []LotQty{{LotNumber: number, ExpiryDate: m.ExpiryDate, Quantity: m.Quantity}}
+	}
+
+	var total int64
+	result := make([]LotQty, 0, len(lots))
+	for _, l := range lots {
+		l.LotNumber = strings.TrimSpace(l.LotNumber)
+		if l.LotNumber == "" {
+			return nil, fmt.Errorf("%w for product %d", ErrLotRequired, m.ProductID)
+		}
+		if l.ExpiryDate != "" {
+			if _, err := time.Parse("2006-01-02", l.ExpiryDate); err != nil {
+				return nil, fmt.Errorf("%w %s: expiry_date must be YYYY-MM-DD", ErrInvalidLot, l.LotNumber)
+			}
+		}
+
+		// Baris stocks sudah di-lock, jadi lot yang sama tidak bisa dibuat dua kali bersamaan
+		var expiry sql.NullString
+		err := tx.QueryRow(`
+			SELECT id, TO_CHAR(expiry_date, 'YYYY-MM-DD')
+			FROM stock_lots
+			WHERE product_id = $1 AND warehouse_id = $2 AND lot_number = $3
+			FOR UPDATE
+		`, m.ProductID, m.WarehouseID, l.LotNumber).Scan(&l.LotID, &expiry)
+
+		switch {
+		case err == sql.ErrNoRows:
+			err = tx.QueryRow(`
+				INSERT INTO stock_lots (product_id, warehouse_id, lot_number, expiry_date, quantity)
+				VALUES ($1, $2, $3, NULLIF($4, '')::date, $5)
+				RETURNING id
+			`, m.ProductID, m.WarehouseID, l.LotNumber, l.ExpiryDate, l.Quantity).Scan(&l.LotID)
+		case err != nil:
+			return nil, err
+		default:
+			if l.ExpiryDate != "" && expiry.Valid && expiry.String != l.ExpiryDate {
+				return nil, fmt.Errorf("%w %s: already has expiry date %s", ErrInvalidLot, l.LotNumber, expiry.String)
+			}
+			if l.ExpiryDate == "" {
+				l.ExpiryDate = expiry.String
+			}
+			_, err = tx.Exec(`
+				UPDATE stock_lots
+				SET quantity = quantity + $1, expiry_date = COALESCE(expiry_date, NULLIF($2, '')::date), updated_at = NOW()
+				WHERE id = $3
+			`, l.Quantity, l.ExpiryDate, l.LotID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		total += l.Quantity
+		result = append(result, l)
+	}
+
+	if total != m.Quantity {
+		return nil, fmt.Errorf("lot quantities (%d) do not match movement quantity (%d)", total, m.Quantity)
+	}
+
+	return result, nil
+}
+
+func issueLots(tx *sql.Tx, m Movement) ([]LotQty, error) {
+	if len(m.Lots) > 0 {
+		return nil, fmt.Errorf("%w: outbound movement takes a single lot number", ErrInvalidLot)
+	}
+
+	var candidates []LotQty
+	var err error
+	if m.LotNumber != "" {
+		candidates, err = lockLots(tx, `
+			SELECT id, lot_number, COALESCE(TO_CHAR(expiry_date, 'YYYY-MM-DD'), ''), quantity
+			FROM stock_lots
+			WHERE product_id = $1 AND warehouse_id = $2 AND lot_number = $3
+			FOR UPDATE
+		`, m.ProductID, m.WarehouseID, m.LotNumber)
+		if err == nil && len(candidates) == 0 {
+			return nil, fmt.Errorf("%w: %s for product %d at warehouse %d", ErrLotNotFound, m.LotNumber, m.ProductID, m.WarehouseID)
+		}
+	} else {
+		// FEFO. OUT biasa tidak mengambil lot yang sudah kedaluwarsa;
+		// pemusnahan barang kedaluwarsa dicatat sebagai ADJ_OUT
+		expiredFilter := ""
+		if m.MoveType == MoveOut {
+			expiredFilter = " AND (expiry_date IS NULL OR expiry_date >= CURRENT_DATE)"
+		}
+		candidates, err = lockLots(tx, `
+			SELECT id, lot_number, COALESCE(TO_CHAR(expiry_date, 'YYYY-MM-DD'), ''), quantity
+			FROM stock_lots
+			WHERE product_id = $1 AND warehouse_id = $2 AND quantity > 0`+expiredFilter+`
+			ORDER BY expiry_date ASC NULLS LAST, id
+			FOR UPDATE
+		`, m.ProductID, m.WarehouseID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	need := m.Quantity
+	result := []LotQty{}
+	for _, l := range candidates {
+		if need == 0 {
+			break
+		}
+		take := l.Quantity
+		if take > need {
+			take = need
+		}
+		if take == 0 {
+			continue
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE stock_lots SET quantity = quantity - $1, updated_at = NOW() WHERE id = $2
+		`, take, l.LotID); err != nil {
+			return nil, err
+		}
+
+		l.Quantity = take
+		result = append(result, l)
+		need -= take
+	}
+
+	if need > 0 {
+		if m.LotNumber != "" {
+			return nil, fmt.Errorf("%w in lot %s for product %d at warehouse %d", ErrInsufficientStock, m.LotNumber, m.ProductID, m.WarehouseID)
+		}
+		return nil, fmt.Errorf("%w for product %d at warehouse %d: %d short in unexpired lots", ErrInsufficientStock, m.ProductID, m.WarehouseID, need)
+	}
+
+	return result, nil
+}
+
+func lockLots(tx *sql.Tx, query string, args ...interface{}) ([]LotQty, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots := []LotQty{}
+	for rows.Next() {
+		var l LotQty
+		if err := rows.Scan(&l.LotID, &l.LotNumber, &l.ExpiryDate, &l.Quantity); err != nil {
+			return nil, err
+		}
+		lots = append(lots, l)
+	}
+
+	return lots, rows.Err()
+}
+
+func recordLots(tx *sql.Tx, txID int64, lots []LotQty) error {
+	for _, l := range lots {
+		if _, err := tx.Exec(`
+			INSERT INTO transaction_lots (transaction_id, lot_id, quantity) VALUES ($1, $2, $3)
+		`, txID, l.LotID, l.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TransactionLots mengambil lot yang disentuh satu baris transactions,
+// misalnya untuk meneruskan lot dari sisi OUT ke sisi IN sebuah transfer
+func TransactionLots(tx *sql.Tx, txID int64) ([]LotQty, error) {
+	return lockLots(tx, `
+		SELECT l.id, l.lot_number, COALESCE(TO_CHAR(l.expiry_date, 'YYYY-MM-DD'), ''), tl.quantity
+		FROM transaction_lots tl
+		JOIN stock_lots l ON l.id = tl.lot_id
+		WHERE tl.transaction_id = $1
+		ORDER BY tl.id
+	`, txID)
+}
+
+// OpenDefaultLots dipanggil saat lot tracking diaktifkan untuk product:
+// semua stok yang ada dipindahkan ke DefaultLot supaya total lot sama dengan stocks.
+// Lot lama (dari periode tracking sebelumnya) dinolkan.
+func OpenDefaultLots(tx *sql.Tx, productID int64) error {
+	if _, err := tx.Exec(`SELECT 1 FROM stocks WHERE product_id = $1 ORDER BY warehouse_id FOR UPDATE`, productID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE stock_lots SET quantity = 0, updated_at = NOW() WHERE product_id = $1 AND quantity > 0
+	`, productID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO stock_lots (product_id, warehouse_id, lot_number, quantity)
+		SELECT product_id, warehouse_id, $2, quantity
+		FROM stocks
+		WHERE product_id = $1 AND quantity > 0
+		ON CONFLICT (product_id, warehouse_id, lot_number)
+		DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = NOW()
+	`, productID, DefaultLot)
+	return err
+}
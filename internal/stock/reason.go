@@ -0,0 +1,49 @@
+package stock
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ReasonDirectionIn   = "IN"
+	ReasonDirectionOut  = "OUT"
+	ReasonDirectionBoth = "BOTH"
+
+	// ReasonCount dipakai untuk penyesuaian hasil stock opname
+	ReasonCount = "COUNT"
+
+	// ReasonReconcile dipakai untuk koreksi ledger hasil rekonsiliasi (lihat PostLedgerCorrection)
+	ReasonReconcile = "RECONCILE"
+)
+
+var (
+	ErrReasonNotFound   = errors.New("Reason code not found")
+	ErrReasonNotAllowed = errors.New("Reason code not allowed")
+)
+
+// FindReason mencari reason code aktif dan memastikan arahnya cocok dengan move type
+func FindReason(tx *sql.Tx, code string, moveType string) (int64, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	var id int64
+	var direction string
+	err := tx.QueryRow(`
+		SELECT id, direction FROM reason_codes WHERE UPPER(code) = $1 AND is_active = TRUE
+	`, code).Scan(&id, &direction)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("%w: %s", ErrReasonNotFound, code)
+	} else if err != nil {
+		return 0, err
+	}
+
+	if direction != ReasonDirectionBoth {
+		if (direction == ReasonDirectionIn) != IsInbound(moveType) {
+			return 0, fmt.Errorf("%w: %s can only be used for %s movements", ErrReasonNotAllowed, code, direction)
+		}
+	}
+
+	return id, nil
+}
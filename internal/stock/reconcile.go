@@ -0,0 +1,61 @@
+package stock
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// LedgerQuantity adalah saldo product di warehouse menurut transactions (IN - OUT)
+func LedgerQuantity(tx *sql.Tx, k Key) (int64, error) {
+	var qty int64
+	err := tx.QueryRow(`
+		SELECT COALESCE(SUM(`+SignedQuantitySQL("tr")+`), 0)
+		FROM transactions tr
+		WHERE tr.product_id = $1 AND tr.warehouse_id = $2
+	`, k.ProductID, k.WarehouseID).Scan(&qty)
+	return qty, err
+}
+
+// PostLedgerCorrection mencatat adjustment di transactions supaya ledger kembali sama
+// dengan stocks.quantity. Berbeda dengan Apply, stocks, lot, serial dan cost layer tidak
+// diubah: stok fisik dianggap benar dan hanya riwayatnya yang dilengkapi, tanpa biaya.
+// Selisih dihitung ulang setelah baris stocks di-lock; 0 berarti tidak ada yang diposting.
+func PostLedgerCorrection(tx *sql.Tx, k Key, userID int64, notes string) (int64, int64, error) {
+	balances, err := Lock(tx, []Key{k})
+	if err != nil {
+		return 0, 0, err
+	}
+	ledger, err := LedgerQuantity(tx, k)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	diff := balances[k].Quantity - ledger
+	if diff == 0 {
+		return 0, 0, nil
+	}
+
+	moveType, qty := MoveAdjustIn, diff
+	if diff < 0 {
+		moveType, qty = MoveAdjustOut, -diff
+	}
+	reasonID, err := FindReason(tx, ReasonReconcile, moveType)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Product bisa saja sudah dihapus, harga dicatat jika masih ada
+	var txID int64
+	err = tx.QueryRow(`
+		INSERT INTO transactions (product_id, warehouse_id, user_id, quantity, move_type, reason_code_id, notes, unit_price, currency, unit_cost, cost_value)
+		SELECT $1, $2, $3, $4, $5, $6, NULLIF($7, ''), p.price, p.currency, 0, 0
+		FROM (SELECT 1) one
+		LEFT JOIN products p ON p.id = $1
+		RETURNING id
+	`, k.ProductID, k.WarehouseID, userID, qty, moveType, reasonID, notes).Scan(&txID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("post ledger correction for product %d at warehouse %d: %w", k.ProductID, k.WarehouseID, err)
+	}
+
+	return txID, diff, nil
+}
@@ -0,0 +1,50 @@
+package stock
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Reserve mengalokasikan qty dari stok yang tersedia tanpa mengurangi stok fisik.
+// Baris stocks harus sudah di-lock lewat Lock dalam tx yang sama.
+func Reserve(tx *sql.Tx, balances Balances, key Key, qty int64) error {
+	bal, ok := balances[key]
+	if !ok {
+		return fmt.Errorf("%w for product %d at warehouse %d", ErrStockNotFound, key.ProductID, key.WarehouseID)
+	}
+	if bal.Available() < qty {
+		return fmt.Errorf("%w for product %d at warehouse %d: %d available", ErrInsufficientStock, key.ProductID, key.WarehouseID, bal.Available())
+	}
+
+	bal.Reserved += qty
+	return saveReserved(tx, balances, key, bal)
+}
+
+// Release melepas reservasi sebesar qty (tidak pernah di bawah nol).
+// Baris stocks harus sudah di-lock lewat Lock dalam tx yang sama.
+func Release(tx *sql.Tx, balances Balances, key Key, qty int64) error {
+	bal, ok := balances[key]
+	if !ok {
+		return fmt.Errorf("%w for product %d at warehouse %d", ErrStockNotFound, key.ProductID, key.WarehouseID)
+	}
+
+	bal.Reserved -= qty
+	if bal.Reserved < 0 {
+		bal.Reserved = 0
+	}
+	return saveReserved(tx, balances, key, bal)
+}
+
+func saveReserved(tx *sql.Tx, balances Balances, key Key, bal Balance) error {
+	_, err := tx.Exec(`
+		UPDATE stocks
+		SET reserved_quantity = $1, updated_at = $2
+		WHERE product_id = $3 AND warehouse_id = $4
+	`, bal.Reserved, time.Now(), key.ProductID, key.WarehouseID)
+	if err != nil {
+		return err
+	}
+	balances[key] = bal
+	return nil
+}
@@ -0,0 +1,187 @@
+package stock
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	SerialInStock = "IN_STOCK"
+	SerialOut     = "OUT"
+)
+
+var (
+	ErrSerialRequired = errors.New("Serial numbers are required")
+	ErrSerialNotFound = errors.New("Serial number not in stock")
+	ErrSerialInStock  = errors.New("Serial number already in stock")
+	ErrNotSerialized  = errors.New("Product is not serialized")
+
+	// ErrSerializeWithStock dikembalikan jika serial tracking diaktifkan saat product masih punya stok
+	ErrSerializeWithStock = errors.New("Serial tracking can only be enabled while the product has no stock")
+)
+
+// applySerials membukukan movement product serialized per unit.
+// Jumlah nomor seri harus sama dengan quantity, sehingga stocks.quantity
+// selalu sama dengan jumlah nomor seri IN_STOCK di warehouse tersebut.
+func applySerials(tx *sql.Tx, bal Balance, m Movement) ([]int64, error) {
+	if !bal.Serialized {
+		if len(m.Serials) > 0 {
+			return nil, fmt.Errorf("%w: product %d", ErrNotSerialized, m.ProductID)
+		}
+		return nil, nil
+	}
+
+	serials := make([]string, 0, len(m.Serials))
+	seen := map[string]bool{}
+	for _, s := range m.Serials {
+		s = strings.TrimSpace(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		serials = append(serials, s)
+	}
+	if int64(len(serials)) != m.Quantity {
+		return nil, fmt.Errorf("%w for product %d: %d unique serial(s) given for quantity %d", ErrSerialRequired, m.ProductID, len(serials), m.Quantity)
+	}
+
+	ids := make([]int64, 0, len(serials))
+	for _, s := range serials {
+		var id int64
+		var err error
+		if IsInbound(m.MoveType) {
+			id, err = receiveSerial(tx, m, s)
+		} else {
+			id, err = issueSerial(tx, m, s)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func receiveSerial(tx *sql.Tx, m Movement, serial string) (int64, error) {
+	var id int64
+	var status string
+	var warehouseID int64
+	err := tx.QueryRow(`
+		SELECT id, status, warehouse_id FROM serial_numbers
+		WHERE product_id = $1 AND serial_number = $2
+		FOR UPDATE
+	`, m.ProductID, serial).Scan(&id, &status, &warehouseID)
+
+	switch {
+	case err == sql.ErrNoRows:
+		err = tx.QueryRow(`
+			INSERT INTO serial_numbers (product_id, serial_number, warehouse_id, status)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`, m.ProductID, serial, m.WarehouseID, SerialInStock).Scan(&id)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return 0, fmt.Errorf("%w: %s of product %d", ErrSerialInStock, serial, m.ProductID)
+		}
+		return id, err
+	case err != nil:
+		return 0, err
+	case status == SerialInStock:
+		return 0, fmt.Errorf("%w: %s of product %d at warehouse %d", ErrSerialInStock, serial, m.ProductID, warehouseID)
+	}
+
+	// Unit yang pernah keluar masuk kembali (retur, transfer)
+	_, err = tx.Exec(`
+		UPDATE serial_numbers SET warehouse_id = $1, status = $2, updated_at = NOW() WHERE id = $3
+	`, m.WarehouseID, SerialInStock, id)
+	return id, err
+}
+
+func issueSerial(tx *sql.Tx, m Movement, serial string) (int64, error) {
+	var id int64
+	err := tx.QueryRow(`
+		SELECT id FROM serial_numbers
+		WHERE product_id = $1 AND serial_number = $2 AND warehouse_id = $3 AND status = $4
+		FOR UPDATE
+	`, m.ProductID, serial, m.WarehouseID, SerialInStock).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("%w: %s of product %d at warehouse %d", ErrSerialNotFound, serial, m.ProductID, m.WarehouseID)
+	} else if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`UPDATE serial_numbers SET status = $1, updated_at = NOW() WHERE id = $2`, SerialOut, id)
+	return id, err
+}
+
+func recordSerials(tx *sql.Tx, txID int64, serialIDs []int64) error {
+	for _, id := range serialIDs {
+		if _, err := tx.Exec(`
+			INSERT INTO transaction_serials (transaction_id, serial_id) VALUES ($1, $2)
+		`, txID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TransactionSerials mengambil nomor seri yang dipindahkan oleh satu baris transactions
+func TransactionSerials(tx *sql.Tx, txID int64) ([]string, error) {
+	rows, err := tx.Query(`
+		SELECT sn.serial_number
+		FROM transaction_serials ts
+		JOIN serial_numbers sn ON sn.id = ts.serial_id
+		WHERE ts.transaction_id = $1
+		ORDER BY ts.id
+	`, txID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	serials := []string{}
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		serials = append(serials, s)
+	}
+
+	return serials, rows.Err()
+}
+
+// EnableSerials dipanggil saat serial tracking diaktifkan untuk product.
+// Stok harus nol supaya stocks.quantity sama dengan jumlah nomor seri sejak awal;
+// nomor seri dari periode tracking sebelumnya dianggap sudah keluar.
+func EnableSerials(tx *sql.Tx, productID int64) error {
+	rows, err := tx.Query(`SELECT quantity FROM stocks WHERE product_id = $1 ORDER BY warehouse_id FOR UPDATE`, productID)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for rows.Next() {
+		var qty int64
+		if err := rows.Scan(&qty); err != nil {
+			rows.Close()
+			return err
+		}
+		total += qty
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if total != 0 {
+		return fmt.Errorf("%w: product %d has %d in stock", ErrSerializeWithStock, productID, total)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE serial_numbers SET status = $1, updated_at = NOW() WHERE product_id = $2 AND status = $3
+	`, SerialOut, productID, SerialInStock)
+	return err
+}
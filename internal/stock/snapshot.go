@@ -0,0 +1,28 @@
+package stock
+
+import "time"
+
+// SnapshotLag adalah jarak minimum snapshot_at dari waktu sekarang. Transaksi DB
+// yang masih berjalan memakai created_at saat dimulai, jadi snapshot yang terlalu
+// dekat dengan sekarang bisa melewatkan movement yang baru di-commit setelahnya.
+const SnapshotLag = 5 * time.Minute
+
+// BalanceAsOfSQL mengembalikan subquery saldo stok per (product_id, warehouse_id)
+// pada waktu at (ekspresi SQL timestamptz, mis. "$1"): isi snapshot terakhir yang
+// tidak lebih baru dari at ditambah movement sesudah snapshot sampai at.
+// Tanpa snapshot seluruh ledger transactions dijumlahkan.
+func BalanceAsOfSQL(at string) string {
+	return `
+		SELECT b.product_id, b.warehouse_id, SUM(b.quantity) AS quantity
+		FROM (
+			SELECT sl.product_id, sl.warehouse_id, sl.quantity
+			FROM stock_snapshot_lines sl
+			WHERE sl.snapshot_id = (SELECT id FROM stock_snapshots WHERE snapshot_at <= ` + at + ` ORDER BY snapshot_at DESC LIMIT 1)
+			UNION ALL
+			SELECT tr.product_id, tr.warehouse_id, ` + SignedQuantitySQL("tr") + `
+			FROM transactions tr
+			WHERE tr.created_at <= ` + at + `
+				AND tr.created_at > COALESCE((SELECT MAX(snapshot_at) FROM stock_snapshots WHERE snapshot_at <= ` + at + `), '-infinity'::timestamptz)
+		) b
+		GROUP BY b.product_id, b.warehouse_id`
+}
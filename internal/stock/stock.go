@@ -0,0 +1,289 @@
+package stock
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Arrafll/StockLab-Go/internal/money"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrStockNotFound     = errors.New("Stock not found")
+	ErrInsufficientStock = errors.New("Insufficient stock")
+)
+
+const (
+	MoveIn        = "IN"
+	MoveOut       = "OUT"
+	MoveAdjustIn  = "ADJ_IN"  // koreksi stok bertambah (stock count, barang ditemukan)
+	MoveAdjustOut = "ADJ_OUT" // koreksi stok berkurang (rusak, hilang, sampel)
+)
+
+// IsInbound bernilai true jika move type menambah stok
+func IsInbound(moveType string) bool {
+	return moveType == MoveIn || moveType == MoveAdjustIn
+}
+
+// IsAdjustment bernilai true untuk move type penyesuaian stok
+func IsAdjustment(moveType string) bool {
+	return moveType == MoveAdjustIn || moveType == MoveAdjustOut
+}
+
+// ValidMoveType memeriksa apakah move type dikenal
+func ValidMoveType(moveType string) bool {
+	switch moveType {
+	case MoveIn, MoveOut, MoveAdjustIn, MoveAdjustOut:
+		return true
+	}
+	return false
+}
+
+// SignedQuantitySQL mengembalikan ekspresi SQL quantity bertanda (+ masuk, - keluar)
+// untuk tabel transactions dengan alias yang diberikan.
+func SignedQuantitySQL(alias string) string {
+	return "CASE WHEN " + alias + ".move_type IN ('" + MoveIn + "', '" + MoveAdjustIn + "') THEN " + alias + ".quantity ELSE -" + alias + ".quantity END"
+}
+
+// SignedCostSQL mengembalikan ekspresi SQL nilai persediaan bertanda (+ biaya masuk, - COGS keluar)
+// untuk tabel transactions dengan alias yang diberikan.
+func SignedCostSQL(alias string) string {
+	return "CASE WHEN " + alias + ".move_type IN ('" + MoveIn + "', '" + MoveAdjustIn + "') THEN COALESCE(" + alias + ".cost_value, 0) ELSE -COALESCE(" + alias + ".cost_value, 0) END"
+}
+
+// Key menunjuk satu baris stocks (product di satu warehouse)
+type Key struct {
+	ProductID   int64
+	WarehouseID int64
+}
+
+// Balance adalah isi satu baris stocks: stok fisik dan bagian yang sudah dialokasikan ke sales order
+type Balance struct {
+	Quantity   int64
+	Reserved   int64
+	LotTracked bool // stok product juga dicatat per lot di stock_lots
+	Serialized bool // stok product dicatat per unit di serial_numbers
+
+	CostingMethod string // FIFO atau AVG, lihat applyCost
+}
+
+// Available adalah stok yang masih boleh dijual atau dikeluarkan
+func (b Balance) Available() int64 {
+	return b.Quantity - b.Reserved
+}
+
+// Balances menyimpan baris stocks yang sudah di-lock dalam satu DB transaction
+type Balances map[Key]Balance
+
+// Movement adalah satu baris pergerakan stok yang akan dicatat di tabel transactions
+type Movement struct {
+	Key
+	UserID     int64
+	Quantity   int64 // selalu positif, arah ditentukan MoveType
+	MoveType   string
+	TransferID int64 // 0 jika bukan bagian dari transfer
+	DocumentID int64 // 0 jika bukan bagian dari dokumen penerimaan/pengeluaran
+	AssemblyID int64 // 0 jika bukan hasil penyelesaian assembly order
+	ReasonID   int64 // 0 jika tanpa reason code
+	CountID    int64 // 0 jika bukan hasil stock opname
+	Notes      string
+	LotNumber  string   // masuk: lot tujuan; keluar: lot yang diambil, kosong = FEFO
+	ExpiryDate string   // YYYY-MM-DD, untuk lot masuk
+	Lots       []LotQty // masuk ke beberapa lot sekaligus (mis. sisi IN transfer), menggantikan LotNumber
+	Serials    []string // nomor seri unit yang masuk/keluar, wajib untuk product serialized
+
+	// Satuan dan quantity seperti yang diinput user (lihat ToBase); Quantity selalu satuan dasar
+	EnteredUnitID   int64 // 0 jika input sudah dalam satuan dasar
+	EnteredQuantity int64
+
+	// Kit asal movement komponen (lihat ExpandKit)
+	KitProductID int64 // 0 jika bukan bagian dari kit
+	KitQuantity  int64 // jumlah kit yang dikeluarkan
+
+	// Total biaya perolehan movement masuk; nil = biaya rata-rata saat ini (lihat applyCost)
+	CostValue *money.Amount
+}
+
+// Lock mengunci baris stocks untuk semua key dengan SELECT ... FOR UPDATE.
+// Urutan lock selalu (product_id, warehouse_id) agar tidak terjadi deadlock
+// antar request yang menyentuh baris yang sama.
+func Lock(tx *sql.Tx, keys []Key) (Balances, error) {
+	sorted := uniqueSorted(keys)
+
+	productIDs := make([]int64, len(sorted))
+	warehouseIDs := make([]int64, len(sorted))
+	for i, k := range sorted {
+		productIDs[i] = k.ProductID
+		warehouseIDs[i] = k.WarehouseID
+	}
+
+	rows, err := tx.Query(`
+		SELECT s.product_id, s.warehouse_id, s.quantity, s.reserved_quantity, COALESCE(p.is_lot_tracked, FALSE), COALESCE(p.is_serialized, FALSE),
+			COALESCE(p.costing_method, 'FIFO')
+		FROM stocks s
+		JOIN UNNEST($1::bigint[], $2::bigint[]) AS k(product_id, warehouse_id)
+			ON k.product_id = s.product_id AND k.warehouse_id = s.warehouse_id
+		LEFT JOIN products p ON p.id = s.product_id
+		ORDER BY s.product_id, s.warehouse_id
+		FOR UPDATE OF s
+	`, pq.Array(productIDs), pq.Array(warehouseIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := Balances{}
+	for rows.Next() {
+		var k Key
+		var b Balance
+		if err := rows.Scan(&k.ProductID, &k.WarehouseID, &b.Quantity, &b.Reserved, &b.LotTracked, &b.Serialized, &b.CostingMethod); err != nil {
+			return nil, err
+		}
+		balances[k] = b
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, k := range sorted {
+		if _, ok := balances[k]; !ok {
+			return nil, missingStockError(tx, k)
+		}
+	}
+
+	return balances, nil
+}
+
+// Apply membukukan satu movement: update stocks lalu insert ke transactions.
+// Baris stocks harus sudah di-lock lewat Lock dalam tx yang sama.
+// OUT tidak boleh memakai stok yang sudah di-reserve; untuk fulfilment sales order
+// reservasi dilepas dulu lewat Release. Adjustment hanya dibatasi stok fisik.
+// Untuk product lot-tracked quantity juga dibukukan ke stock_lots (lihat applyLots),
+// untuk product serialized per nomor seri (lihat applySerials). Biaya persediaan
+// dibukukan ke cost_layers dan dicatat di transaksi (lihat applyCost).
+func Apply(tx *sql.Tx, balances Balances, m Movement) (int64, error) {
+	bal, ok := balances[m.Key]
+	if !ok {
+		return 0, fmt.Errorf("%w for product %d at warehouse %d", ErrStockNotFound, m.ProductID, m.WarehouseID)
+	}
+
+	if !ValidMoveType(m.MoveType) {
+		return 0, fmt.Errorf("invalid move type %q", m.MoveType)
+	}
+
+	current := bal.Quantity
+	if IsInbound(m.MoveType) {
+		current += m.Quantity
+	} else {
+		if current-m.Quantity < 0 || (m.MoveType == MoveOut && bal.Available() < m.Quantity) {
+			return 0, fmt.Errorf("%w for product %d at warehouse %d", ErrInsufficientStock, m.ProductID, m.WarehouseID)
+		}
+		current -= m.Quantity
+	}
+
+	lots, err := applyLots(tx, bal, m)
+	if err != nil {
+		return 0, err
+	}
+	serialIDs, err := applySerials(tx, bal, m)
+	if err != nil {
+		return 0, err
+	}
+	cost, layerID, err := applyCost(tx, bal, m)
+	if err != nil {
+		return 0, err
+	}
+
+	// Catatan penyesuaian terakhir disimpan juga di stocks.notes
+	if IsAdjustment(m.MoveType) && m.Notes != "" {
+		_, err = tx.Exec(`
+			UPDATE stocks
+			SET quantity = $1, notes = $2, updated_at = $3
+			WHERE product_id = $4 AND warehouse_id = $5
+		`, current, m.Notes, time.Now(), m.ProductID, m.WarehouseID)
+	} else {
+		_, err = tx.Exec(`
+			UPDATE stocks
+			SET quantity = $1, updated_at = $2
+			WHERE product_id = $3 AND warehouse_id = $4
+		`, current, time.Now(), m.ProductID, m.WarehouseID)
+	}
+	if err != nil {
+		return 0, err
+	}
+	bal.Quantity = current
+	balances[m.Key] = bal
+
+	// Harga satuan dicatat dari harga product saat movement terjadi
+	var txID int64
+	err = tx.QueryRow(`
+		INSERT INTO transactions (product_id, warehouse_id, user_id, quantity, move_type, transfer_id, document_id, reason_code_id, notes, stock_count_id, entered_unit_id, entered_quantity, kit_product_id, kit_quantity, assembly_order_id, unit_price, currency, unit_cost, cost_value)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13, $14, $15, p.price, p.currency, $16, $17
+		FROM products p WHERE p.id = $1
+		RETURNING id
+	`, m.ProductID, m.WarehouseID, m.UserID, m.Quantity, m.MoveType, nullID(m.TransferID), nullID(m.DocumentID), nullID(m.ReasonID), m.Notes, nullID(m.CountID),
+		nullID(m.EnteredUnitID), nullID(m.EnteredQuantity), nullID(m.KitProductID), nullID(m.KitQuantity), nullID(m.AssemblyID), cost.UnitCost, cost.Value).Scan(&txID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := linkCostLayer(tx, layerID, txID); err != nil {
+		return 0, err
+	}
+
+	if err := recordLots(tx, txID, lots); err != nil {
+		return 0, err
+	}
+	if err := recordSerials(tx, txID, serialIDs); err != nil {
+		return 0, err
+	}
+
+	return txID, nil
+}
+
+func uniqueSorted(keys []Key) []Key {
+	seen := map[Key]bool{}
+	result := []Key{}
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			result = append(result, k)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ProductID != result[j].ProductID {
+			return result[i].ProductID < result[j].ProductID
+		}
+		return result[i].WarehouseID < result[j].WarehouseID
+	})
+	return result
+}
+
+func nullID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// StatusCode memetakan error dari package ini ke HTTP status code
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrInsufficientStock), errors.Is(err, ErrSerialInStock), errors.Is(err, ErrSerializeWithStock):
+		return http.StatusConflict
+	case errors.Is(err, ErrStockNotFound), errors.Is(err, ErrReasonNotFound), errors.Is(err, ErrReasonNotAllowed),
+		errors.Is(err, ErrLotRequired), errors.Is(err, ErrLotNotFound), errors.Is(err, ErrLotNotTracked), errors.Is(err, ErrInvalidLot),
+		errors.Is(err, ErrSerialRequired), errors.Is(err, ErrSerialNotFound), errors.Is(err, ErrNotSerialized),
+		errors.Is(err, ErrUnitNotFound), errors.Is(err, ErrUnitNotAllowed),
+		errors.Is(err, ErrKitNoStock), errors.Is(err, ErrKitInbound), errors.Is(err, ErrKitTracking),
+		errors.Is(err, ErrInvalidBarcode), errors.Is(err, ErrBarcodeNotFound),
+		errors.Is(err, ErrInvalidCostingMethod), errors.Is(err, ErrCostOnOutbound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
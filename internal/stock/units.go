@@ -0,0 +1,41 @@
+package stock
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnitNotFound   = errors.New("Unit not found")
+	ErrUnitNotAllowed = errors.New("Unit not allowed for product")
+)
+
+// ToBase mengonversi quantity dalam satuan unitCode ke satuan dasar product.
+// unitCode kosong berarti quantity sudah dalam satuan dasar (unitID 0).
+func ToBase(tx *sql.Tx, productID int64, unitCode string, qty int64) (baseQty int64, unitID int64, err error) {
+	unitCode = strings.ToUpper(strings.TrimSpace(unitCode))
+	if unitCode == "" {
+		return qty, 0, nil
+	}
+
+	var factor sql.NullInt64
+	err = tx.QueryRow(`
+		SELECT u.id, CASE WHEN p.base_unit_id = u.id THEN 1 ELSE pu.factor END
+		FROM units u
+		JOIN products p ON p.id = $1
+		LEFT JOIN product_units pu ON pu.product_id = p.id AND pu.unit_id = u.id
+		WHERE UPPER(u.code) = $2
+	`, productID, unitCode).Scan(&unitID, &factor)
+	if err == sql.ErrNoRows {
+		return 0, 0, fmt.Errorf("%w: %s", ErrUnitNotFound, unitCode)
+	} else if err != nil {
+		return 0, 0, err
+	}
+	if !factor.Valid {
+		return 0, 0, fmt.Errorf("%w: %s is not configured for product %d", ErrUnitNotAllowed, unitCode, productID)
+	}
+
+	return qty * factor.Int64, unitID, nil
+}
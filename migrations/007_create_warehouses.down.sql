@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_transactions_warehouse_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS warehouse_id;
+
+DROP INDEX IF EXISTS idx_stocks_warehouse_id;
+DROP INDEX IF EXISTS idx_stocks_product_warehouse;
+ALTER TABLE stocks DROP COLUMN IF EXISTS warehouse_id;
+
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS warehouses (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Lokasi default untuk stok yang sudah ada sebelum multi-warehouse
+INSERT INTO warehouses (code, name) VALUES ('MAIN', 'Main Warehouse');
+
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS warehouse_id INT;
+UPDATE stocks SET warehouse_id = (SELECT id FROM warehouses WHERE code = 'MAIN') WHERE warehouse_id IS NULL;
+ALTER TABLE stocks ALTER COLUMN warehouse_id SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_stocks_product_warehouse ON stocks(product_id, warehouse_id);
+CREATE INDEX idx_stocks_warehouse_id ON stocks(warehouse_id);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS warehouse_id INT;
+UPDATE transactions SET warehouse_id = (SELECT id FROM warehouses WHERE code = 'MAIN') WHERE warehouse_id IS NULL;
+ALTER TABLE transactions ALTER COLUMN warehouse_id SET NOT NULL;
+
+CREATE INDEX idx_transactions_warehouse_id ON transactions(warehouse_id);
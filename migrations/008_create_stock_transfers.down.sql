@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_transactions_transfer_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_id;
+
+DROP INDEX IF EXISTS idx_stock_transfers_to_warehouse_id;
+DROP INDEX IF EXISTS idx_stock_transfers_from_warehouse_id;
+DROP TABLE IF EXISTS stock_transfers;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    from_warehouse_id INT NOT NULL,
+    to_warehouse_id INT NOT NULL,
+    user_id INT NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_transfers_from_warehouse_id ON stock_transfers(from_warehouse_id);
+CREATE INDEX idx_stock_transfers_to_warehouse_id ON stock_transfers(to_warehouse_id);
+
+-- Baris OUT dan IN dari satu transfer saling terhubung lewat transfer_id
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS transfer_id INT NULL;
+CREATE INDEX idx_transactions_transfer_id ON transactions(transfer_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_document_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS document_id;
+
+DROP INDEX IF EXISTS idx_stock_documents_doc_date;
+DROP TABLE IF EXISTS stock_documents;
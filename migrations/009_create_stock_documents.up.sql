@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS stock_documents (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    doc_type VARCHAR(20) NOT NULL, -- RECEIPT | ISSUE
+    reference_no VARCHAR(100) UNIQUE,
+    partner VARCHAR(255),
+    notes TEXT,
+    doc_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    warehouse_id INT NOT NULL,
+    user_id INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_documents_doc_date ON stock_documents(doc_date);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS document_id INT NULL;
+CREATE INDEX idx_transactions_document_id ON transactions(document_id);
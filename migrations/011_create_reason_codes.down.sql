@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_reason_code_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS notes;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reason_code_id;
+
+DROP TABLE IF EXISTS reason_codes;
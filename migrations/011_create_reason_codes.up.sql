@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS reason_codes (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    direction VARCHAR(10) NOT NULL DEFAULT 'BOTH', -- IN | OUT | BOTH
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO reason_codes (code, name, direction) VALUES
+    ('DAMAGE', 'Barang rusak', 'OUT'),
+    ('THEFT', 'Barang hilang / dicuri', 'OUT'),
+    ('EXPIRED', 'Barang kadaluarsa', 'OUT'),
+    ('SAMPLE', 'Sampel / promosi', 'OUT'),
+    ('FOUND', 'Barang ditemukan', 'IN'),
+    ('COUNT', 'Koreksi stock opname', 'BOTH');
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reason_code_id INT NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS notes TEXT NULL;
+CREATE INDEX idx_transactions_reason_code_id ON transactions(reason_code_id);
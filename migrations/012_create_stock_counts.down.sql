@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_transactions_stock_count_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS stock_count_id;
+
+DROP INDEX IF EXISTS idx_stock_count_lines_count_product;
+DROP TABLE IF EXISTS stock_count_lines;
+
+DROP INDEX IF EXISTS idx_stock_counts_warehouse_id;
+DROP TABLE IF EXISTS stock_counts;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS stock_counts (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    warehouse_id INT NOT NULL,
+    category_id INT NULL, -- NULL berarti semua category
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN', -- OPEN | APPROVED | CANCELLED
+    notes TEXT,
+    created_by INT NOT NULL,
+    approved_by INT NULL,
+    approved_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_counts_warehouse_id ON stock_counts(warehouse_id);
+
+CREATE TABLE IF NOT EXISTS stock_count_lines (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    stock_count_id INT NOT NULL,
+    product_id INT NOT NULL,
+    expected_quantity INT NOT NULL, -- snapshot dari stocks saat sesi dibuat
+    counted_quantity INT NULL,
+    counted_by INT NULL,
+    counted_at TIMESTAMP WITH TIME ZONE NULL,
+    transaction_id INT NULL, -- adjustment yang diposting saat approve
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_stock_count_lines_count_product ON stock_count_lines(stock_count_id, product_id);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS stock_count_id INT NULL;
+CREATE INDEX idx_transactions_stock_count_id ON transactions(stock_count_id);
@@ -0,0 +1,9 @@
+ALTER TABLE stocks DROP CONSTRAINT IF EXISTS chk_stocks_levels;
+ALTER TABLE stocks DROP COLUMN IF EXISTS max_level;
+ALTER TABLE stocks DROP COLUMN IF EXISTS safety_stock;
+ALTER TABLE stocks DROP COLUMN IF EXISTS reorder_point;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_levels;
+ALTER TABLE products DROP COLUMN IF EXISTS max_level;
+ALTER TABLE products DROP COLUMN IF EXISTS safety_stock;
+ALTER TABLE products DROP COLUMN IF EXISTS reorder_point;
@@ -0,0 +1,24 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_point INT NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS safety_stock INT NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS max_level INT NULL;
+
+-- Product lama tetap memakai ambang low stock sebelumnya (< 10)
+UPDATE products SET reorder_point = 10;
+
+ALTER TABLE products ADD CONSTRAINT chk_products_levels CHECK (
+    reorder_point >= 0
+    AND safety_stock >= 0
+    AND safety_stock <= reorder_point
+    AND (max_level IS NULL OR max_level >= reorder_point)
+);
+
+-- Override per warehouse, NULL berarti ikut nilai di products
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS reorder_point INT NULL;
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS safety_stock INT NULL;
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS max_level INT NULL;
+
+ALTER TABLE stocks ADD CONSTRAINT chk_stocks_levels CHECK (
+    (reorder_point IS NULL OR reorder_point >= 0)
+    AND (safety_stock IS NULL OR safety_stock >= 0)
+    AND (max_level IS NULL OR max_level >= 0)
+);
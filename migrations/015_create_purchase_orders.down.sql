@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_stock_documents_purchase_order_id;
+ALTER TABLE stock_documents DROP COLUMN IF EXISTS purchase_order_id;
+
+DROP INDEX IF EXISTS idx_purchase_order_lines_order_product;
+DROP TABLE IF EXISTS purchase_order_lines;
+
+DROP INDEX IF EXISTS idx_purchase_orders_status;
+DROP INDEX IF EXISTS idx_purchase_orders_supplier_id;
+DROP TABLE IF EXISTS purchase_orders;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    po_number VARCHAR(100) UNIQUE,
+    supplier_id INT NOT NULL,
+    warehouse_id INT NOT NULL, -- lokasi tujuan penerimaan
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT', -- DRAFT | SENT | PARTIALLY_RECEIVED | RECEIVED | CANCELLED
+    order_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    expected_date DATE NULL,
+    notes TEXT,
+    created_by INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders(supplier_id);
+CREATE INDEX idx_purchase_orders_status ON purchase_orders(status);
+
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    purchase_order_id INT NOT NULL,
+    product_id INT NOT NULL,
+    ordered_quantity INT NOT NULL CHECK (ordered_quantity > 0),
+    received_quantity INT NOT NULL DEFAULT 0,
+    unit_price BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_purchase_order_lines_order_product ON purchase_order_lines(purchase_order_id, product_id);
+
+-- Dokumen penerimaan yang dibuat dari purchase order
+ALTER TABLE stock_documents ADD COLUMN IF NOT EXISTS purchase_order_id INT NULL;
+CREATE INDEX idx_stock_documents_purchase_order_id ON stock_documents(purchase_order_id);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_stock_documents_sales_order_id;
+ALTER TABLE stock_documents DROP COLUMN IF EXISTS sales_order_id;
+
+ALTER TABLE stocks DROP CONSTRAINT IF EXISTS chk_stocks_reserved;
+ALTER TABLE stocks DROP COLUMN IF EXISTS reserved_quantity;
+
+DROP INDEX IF EXISTS idx_sales_order_lines_order_product;
+DROP TABLE IF EXISTS sales_order_lines;
+
+DROP INDEX IF EXISTS idx_sales_orders_status;
+DROP INDEX IF EXISTS idx_sales_orders_customer_id;
+DROP TABLE IF EXISTS sales_orders;
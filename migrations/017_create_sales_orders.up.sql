@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS sales_orders (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    so_number VARCHAR(100) UNIQUE,
+    customer_id INT NOT NULL,
+    warehouse_id INT NOT NULL, -- lokasi asal pengiriman
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT', -- DRAFT | CONFIRMED | PARTIALLY_FULFILLED | FULFILLED | CANCELLED
+    order_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    notes TEXT,
+    created_by INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_sales_orders_customer_id ON sales_orders(customer_id);
+CREATE INDEX idx_sales_orders_status ON sales_orders(status);
+
+CREATE TABLE IF NOT EXISTS sales_order_lines (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    sales_order_id INT NOT NULL,
+    product_id INT NOT NULL,
+    ordered_quantity INT NOT NULL CHECK (ordered_quantity > 0),
+    fulfilled_quantity INT NOT NULL DEFAULT 0,
+    unit_price BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (fulfilled_quantity <= ordered_quantity)
+);
+
+CREATE UNIQUE INDEX idx_sales_order_lines_order_product ON sales_order_lines(sales_order_id, product_id);
+
+-- Stok yang sudah dialokasikan ke sales order CONFIRMED tapi belum dikirim
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS reserved_quantity INT NOT NULL DEFAULT 0;
+ALTER TABLE stocks ADD CONSTRAINT chk_stocks_reserved CHECK (reserved_quantity >= 0);
+
+-- Dokumen pengeluaran yang dibuat dari sales order
+ALTER TABLE stock_documents ADD COLUMN IF NOT EXISTS sales_order_id INT NULL;
+CREATE INDEX idx_stock_documents_sales_order_id ON stock_documents(sales_order_id);
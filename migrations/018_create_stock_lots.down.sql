@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_transaction_lots_lot_id;
+DROP INDEX IF EXISTS idx_transaction_lots_transaction_id;
+DROP TABLE IF EXISTS transaction_lots;
+
+DROP INDEX IF EXISTS idx_stock_lots_expiry_date;
+DROP INDEX IF EXISTS idx_stock_lots_product_warehouse_lot;
+DROP TABLE IF EXISTS stock_lots;
+
+ALTER TABLE products DROP COLUMN IF EXISTS is_lot_tracked;
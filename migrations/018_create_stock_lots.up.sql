@@ -0,0 +1,27 @@
+-- Product yang stoknya dicatat per lot/batch dengan tanggal kedaluwarsa
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_lot_tracked BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS stock_lots (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    product_id INT NOT NULL,
+    warehouse_id INT NOT NULL,
+    lot_number VARCHAR(100) NOT NULL,
+    expiry_date DATE NULL, -- NULL = tanpa tanggal kedaluwarsa, dikeluarkan paling akhir
+    quantity INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_stock_lots_product_warehouse_lot ON stock_lots(product_id, warehouse_id, lot_number);
+CREATE INDEX idx_stock_lots_expiry_date ON stock_lots(expiry_date) WHERE quantity > 0;
+
+-- Lot yang ditambah atau dikurangi oleh satu baris transactions
+CREATE TABLE IF NOT EXISTS transaction_lots (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    transaction_id INT NOT NULL,
+    lot_id INT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_transaction_lots_transaction_id ON transaction_lots(transaction_id);
+CREATE INDEX idx_transaction_lots_lot_id ON transaction_lots(lot_id);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_transaction_serials_serial_id;
+DROP INDEX IF EXISTS idx_transaction_serials_transaction_id;
+DROP TABLE IF EXISTS transaction_serials;
+
+DROP INDEX IF EXISTS idx_serial_numbers_stock;
+DROP INDEX IF EXISTS idx_serial_numbers_serial;
+DROP INDEX IF EXISTS idx_serial_numbers_product_serial;
+DROP TABLE IF EXISTS serial_numbers;
+
+ALTER TABLE products DROP COLUMN IF EXISTS is_serialized;
@@ -0,0 +1,26 @@
+-- Product yang dilacak per unit dengan nomor seri
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS serial_numbers (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    product_id INT NOT NULL,
+    serial_number VARCHAR(100) NOT NULL,
+    warehouse_id INT NOT NULL, -- lokasi terakhir
+    status VARCHAR(20) NOT NULL DEFAULT 'IN_STOCK', -- IN_STOCK | OUT
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_serial_numbers_product_serial ON serial_numbers(product_id, serial_number);
+CREATE INDEX idx_serial_numbers_serial ON serial_numbers(serial_number);
+CREATE INDEX idx_serial_numbers_stock ON serial_numbers(product_id, warehouse_id) WHERE status = 'IN_STOCK';
+
+-- Unit yang masuk atau keluar pada satu baris transactions
+CREATE TABLE IF NOT EXISTS transaction_serials (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    transaction_id INT NOT NULL,
+    serial_id INT NOT NULL
+);
+
+CREATE INDEX idx_transaction_serials_transaction_id ON transaction_serials(transaction_id);
+CREATE INDEX idx_transaction_serials_serial_id ON transaction_serials(serial_id);
@@ -0,0 +1,9 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS entered_quantity;
+ALTER TABLE transactions DROP COLUMN IF EXISTS entered_unit_id;
+
+DROP INDEX IF EXISTS idx_product_units_product_unit;
+DROP TABLE IF EXISTS product_units;
+
+ALTER TABLE products DROP COLUMN IF EXISTS base_unit_id;
+
+DROP TABLE IF EXISTS units;
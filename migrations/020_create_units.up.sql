@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS units (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO units (code, name) VALUES
+    ('PCS', 'Pieces'),
+    ('PACK', 'Pack'),
+    ('BOX', 'Box'),
+    ('CTN', 'Karton');
+
+-- Satuan dasar product; stocks.quantity dan transactions.quantity selalu dalam satuan ini
+ALTER TABLE products ADD COLUMN IF NOT EXISTS base_unit_id INT NULL;
+UPDATE products SET base_unit_id = (SELECT id FROM units WHERE code = 'PCS') WHERE base_unit_id IS NULL;
+
+-- Satuan alternatif product: 1 unit = factor x satuan dasar
+CREATE TABLE IF NOT EXISTS product_units (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    product_id INT NOT NULL,
+    unit_id INT NOT NULL,
+    factor INT NOT NULL CHECK (factor > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_product_units_product_unit ON product_units(product_id, unit_id);
+
+-- Quantity dan satuan seperti yang diinput user, quantity tetap disimpan dalam satuan dasar
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS entered_unit_id INT NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS entered_quantity INT NULL;
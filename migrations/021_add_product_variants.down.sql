@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_parent_id;
+
+ALTER TABLE products DROP COLUMN IF EXISTS variant_attributes;
+ALTER TABLE products DROP COLUMN IF EXISTS is_parent;
+ALTER TABLE products DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,7 @@
+-- Varian adalah product biasa (SKU, harga dan stok sendiri) yang menunjuk ke parent.
+-- Parent tidak punya baris stocks; stoknya adalah total stok semua varian.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS parent_id INT NULL;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_parent BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS variant_attributes JSONB NULL; -- mis. {"flavour": "Soto", "size": "75g"}
+
+CREATE INDEX idx_products_parent_id ON products(parent_id);
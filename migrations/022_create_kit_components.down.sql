@@ -0,0 +1,8 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS kit_quantity;
+ALTER TABLE transactions DROP COLUMN IF EXISTS kit_product_id;
+
+DROP INDEX IF EXISTS idx_kit_components_component_id;
+DROP INDEX IF EXISTS idx_kit_components_kit_component;
+DROP TABLE IF EXISTS kit_components;
+
+ALTER TABLE products DROP COLUMN IF EXISTS is_kit;
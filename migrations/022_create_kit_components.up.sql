@@ -0,0 +1,18 @@
+-- Kit / bundle: product yang tersusun dari product lain (bill of materials).
+-- Kit tidak punya baris stocks; stoknya dihitung dari stok komponen.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_kit BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS kit_components (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    kit_id INT NOT NULL,
+    component_id INT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0), -- jumlah komponen (satuan dasar) per 1 kit
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_kit_components_kit_component ON kit_components(kit_id, component_id);
+CREATE INDEX idx_kit_components_component_id ON kit_components(component_id);
+
+-- Pergerakan komponen yang berasal dari movement kit
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS kit_product_id INT NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS kit_quantity INT NULL;
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_transactions_assembly_order_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS assembly_order_id;
+
+DROP INDEX IF EXISTS idx_assembly_order_lines_order_component;
+DROP TABLE IF EXISTS assembly_order_lines;
+
+DROP INDEX IF EXISTS idx_assembly_orders_product_id;
+DROP INDEX IF EXISTS idx_assembly_orders_status;
+DROP TABLE IF EXISTS assembly_orders;
+
+DROP INDEX IF EXISTS idx_bom_lines_bom_component;
+DROP TABLE IF EXISTS bom_lines;
+
+DROP INDEX IF EXISTS idx_boms_product_id;
+DROP TABLE IF EXISTS boms;
@@ -0,0 +1,60 @@
+-- Bill of materials untuk merakit product jadi dari komponen yang punya stok sendiri
+CREATE TABLE IF NOT EXISTS boms (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code VARCHAR(50) UNIQUE,
+    product_id INT NOT NULL, -- product jadi
+    output_quantity INT NOT NULL DEFAULT 1 CHECK (output_quantity > 0), -- hasil satu kali rakit
+    notes TEXT,
+    created_by INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_boms_product_id ON boms(product_id);
+
+CREATE TABLE IF NOT EXISTS bom_lines (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    bom_id INT NOT NULL,
+    component_id INT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0), -- per output_quantity product jadi
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_bom_lines_bom_component ON bom_lines(bom_id, component_id);
+
+CREATE TABLE IF NOT EXISTS assembly_orders (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    order_number VARCHAR(100) UNIQUE,
+    bom_id INT NOT NULL,
+    product_id INT NOT NULL,
+    warehouse_id INT NOT NULL, -- lokasi komponen diambil dan product jadi disimpan
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT', -- DRAFT | COMPLETED | CANCELLED
+    planned_quantity INT NOT NULL CHECK (planned_quantity > 0),
+    produced_quantity INT NOT NULL DEFAULT 0, -- hasil aktual saat selesai
+    notes TEXT,
+    created_by INT NOT NULL,
+    completed_by INT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_assembly_orders_status ON assembly_orders(status);
+CREATE INDEX idx_assembly_orders_product_id ON assembly_orders(product_id);
+
+CREATE TABLE IF NOT EXISTS assembly_order_lines (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    assembly_order_id INT NOT NULL,
+    component_id INT NOT NULL,
+    planned_quantity INT NOT NULL, -- kebutuhan BOM untuk planned_quantity
+    consumed_quantity INT NOT NULL DEFAULT 0, -- yang benar-benar dipakai
+    scrap_quantity INT NOT NULL DEFAULT 0, -- dipakai dikurangi kebutuhan BOM untuk hasil aktual, negatif = hemat
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_assembly_order_lines_order_component ON assembly_order_lines(assembly_order_id, component_id);
+
+-- Pergerakan stok hasil penyelesaian assembly order
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS assembly_order_id INT NULL;
+CREATE INDEX idx_transactions_assembly_order_id ON transactions(assembly_order_id);
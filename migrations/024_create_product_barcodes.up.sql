@@ -0,0 +1,10 @@
+-- Barcode GS1 (EAN-8, UPC-A, EAN-13, GTIN-14) per product dan per satuan
+CREATE TABLE IF NOT EXISTS product_barcodes (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    product_id INT NOT NULL,
+    unit_id INT NULL, -- NULL = satuan dasar product
+    barcode VARCHAR(14) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_product_barcodes_product_id ON product_barcodes(product_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_sku_patterns_category_id;
+DROP TABLE IF EXISTS sku_patterns;
+ALTER TABLE categories DROP COLUMN IF EXISTS sku_code;
+DROP SEQUENCE IF EXISTS product_sku_seq;
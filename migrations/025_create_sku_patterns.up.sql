@@ -0,0 +1,18 @@
+-- Nomor urut SKU, nextval selalu unik meskipun dua product dibuat bersamaan
+CREATE SEQUENCE IF NOT EXISTS product_sku_seq;
+
+-- Kode pendek category untuk token {CATEGORY}, mis. MIE
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS sku_code VARCHAR(10);
+
+-- Pola SKU per category, category_id NULL = pola default
+CREATE TABLE IF NOT EXISTS sku_patterns (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    category_id INT NULL,
+    pattern VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_sku_patterns_category_id ON sku_patterns(COALESCE(category_id, 0));
+
+INSERT INTO sku_patterns (category_id, pattern) VALUES (NULL, 'SKU-{SEQ:6}');
@@ -0,0 +1,7 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+ALTER TABLE transactions DROP COLUMN IF EXISTS unit_price;
+DROP INDEX IF EXISTS idx_product_prices_product_id;
+DROP TABLE IF EXISTS product_prices;
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_price;
+ALTER TABLE products ALTER COLUMN price TYPE VARCHAR(50) USING price::TEXT;
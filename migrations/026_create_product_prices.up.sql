@@ -0,0 +1,38 @@
+-- Harga product disimpan sebagai desimal. Nilai lama yang memakai pemisah ribuan
+-- ("10.000" / "10,000") dibaca sebagai ribuan; nilai yang tidak bisa dibaca menjadi NULL.
+ALTER TABLE products ALTER COLUMN price TYPE NUMERIC(18,2) USING (
+    CASE
+        WHEN TRIM(price) ~ '^[0-9]+(\.[0-9]{1,2})?$' THEN TRIM(price)::NUMERIC
+        WHEN TRIM(price) ~ '^[0-9]{1,3}(\.[0-9]{3})+$' THEN REPLACE(TRIM(price), '.', '')::NUMERIC
+        WHEN TRIM(price) ~ '^[0-9]{1,3}(,[0-9]{3})+(\.[0-9]{1,2})?$' THEN REPLACE(TRIM(price), ',', '')::NUMERIC
+        ELSE NULL
+    END
+);
+ALTER TABLE products ADD CONSTRAINT chk_products_price CHECK (price >= 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+-- Riwayat harga, effective_to NULL = harga yang berlaku sekarang
+CREATE TABLE IF NOT EXISTS product_prices (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    product_id INT NOT NULL,
+    price NUMERIC(18,2) NOT NULL CHECK (price >= 0),
+    currency CHAR(3) NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    effective_to TIMESTAMP WITH TIME ZONE NULL,
+    changed_by INT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_product_prices_product_id ON product_prices(product_id, effective_from);
+
+INSERT INTO product_prices (product_id, price, currency, effective_from)
+SELECT id, price, currency, COALESCE(created_at, NOW()) FROM products WHERE price IS NOT NULL;
+
+-- Harga satuan saat movement terjadi
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS unit_price NUMERIC(18,2);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS currency CHAR(3);
+
+-- Harga lama tidak tercatat, transaksi sebelumnya memakai harga saat migrasi
+UPDATE transactions tr SET unit_price = p.price, currency = p.currency
+FROM products p
+WHERE p.id = tr.product_id AND p.price IS NOT NULL;
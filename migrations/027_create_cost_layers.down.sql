@@ -0,0 +1,5 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS cost_value;
+ALTER TABLE transactions DROP COLUMN IF EXISTS unit_cost;
+DROP INDEX IF EXISTS idx_cost_layers_open;
+DROP TABLE IF EXISTS cost_layers;
+ALTER TABLE products DROP COLUMN IF EXISTS costing_method;
@@ -0,0 +1,27 @@
+-- Metode costing per product: FIFO atau moving weighted average
+ALTER TABLE products ADD COLUMN IF NOT EXISTS costing_method VARCHAR(4) NOT NULL DEFAULT 'FIFO' CHECK (costing_method IN ('FIFO', 'AVG'));
+
+-- Layer biaya persediaan per product per warehouse. Movement masuk membuka layer,
+-- movement keluar mengurangi remaining dan remaining_value dari layer tertua.
+CREATE TABLE IF NOT EXISTS cost_layers (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    product_id INT NOT NULL,
+    warehouse_id INT NOT NULL,
+    transaction_id INT NULL, -- movement masuk yang membuka layer, NULL untuk saldo awal
+    quantity INT NOT NULL CHECK (quantity >= 0),
+    remaining INT NOT NULL CHECK (remaining >= 0),
+    remaining_value NUMERIC(18,2) NOT NULL CHECK (remaining_value >= 0),
+    unit_cost NUMERIC(18,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_cost_layers_open ON cost_layers(product_id, warehouse_id, created_at) WHERE remaining > 0;
+
+-- Biaya per movement: biaya perolehan untuk masuk, COGS untuk keluar (selalu positif)
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS unit_cost NUMERIC(18,2);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS cost_value NUMERIC(18,2);
+
+-- Stok yang sudah ada sebelum costing aktif dibuka sebagai saldo awal tanpa biaya
+INSERT INTO cost_layers (product_id, warehouse_id, quantity, remaining, remaining_value, unit_cost)
+SELECT product_id, warehouse_id, quantity, quantity, 0, 0 FROM stocks WHERE quantity > 0;
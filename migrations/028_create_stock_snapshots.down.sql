@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_created_at;
+
+DROP INDEX IF EXISTS idx_stock_snapshot_lines_snapshot_product;
+DROP TABLE IF EXISTS stock_snapshot_lines;
+
+DROP INDEX IF EXISTS idx_stock_snapshots_snapshot_at;
+DROP TABLE IF EXISTS stock_snapshots;
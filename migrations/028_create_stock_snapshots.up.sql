@@ -0,0 +1,26 @@
+-- Snapshot saldo stok per periode supaya stok pada suatu waktu tidak perlu
+-- dijumlahkan dari seluruh ledger transactions. Snapshot mencakup semua
+-- transaksi dengan created_at <= snapshot_at.
+CREATE TABLE IF NOT EXISTS stock_snapshots (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    snapshot_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    notes TEXT,
+    created_by INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_stock_snapshots_snapshot_at ON stock_snapshots(snapshot_at);
+
+-- Hanya saldo yang tidak nol yang disimpan
+CREATE TABLE IF NOT EXISTS stock_snapshot_lines (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    snapshot_id INT NOT NULL,
+    product_id INT NOT NULL,
+    warehouse_id INT NOT NULL,
+    quantity INT NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_stock_snapshot_lines_snapshot_product ON stock_snapshot_lines(snapshot_id, product_id, warehouse_id);
+
+-- Movement sesudah snapshot dicari berdasarkan waktu
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
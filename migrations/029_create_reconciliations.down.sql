@@ -0,0 +1,7 @@
+DELETE FROM reason_codes WHERE code = 'RECONCILE';
+
+DROP INDEX IF EXISTS idx_reconciliation_drifts_run_id;
+DROP TABLE IF EXISTS reconciliation_drifts;
+
+DROP INDEX IF EXISTS idx_reconciliation_runs_started_at;
+DROP TABLE IF EXISTS reconciliation_runs;
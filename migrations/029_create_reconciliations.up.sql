@@ -0,0 +1,31 @@
+-- Hasil pengecekan stocks.quantity terhadap jumlah movement di transactions
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    source VARCHAR(20) NOT NULL, -- MANUAL | SCHEDULED | COMMAND
+    checked_rows INT NOT NULL DEFAULT 0,
+    drift_count INT NOT NULL DEFAULT 0,
+    created_by INT NULL, -- NULL untuk job terjadwal dan command
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE INDEX idx_reconciliation_runs_started_at ON reconciliation_runs(started_at);
+
+CREATE TABLE IF NOT EXISTS reconciliation_drifts (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    run_id INT NOT NULL,
+    product_id INT NOT NULL,
+    warehouse_id INT NOT NULL,
+    ledger_quantity INT NOT NULL, -- jumlah IN - OUT di transactions
+    stock_quantity INT NULL,      -- NULL jika baris stocks tidak ada
+    difference INT NOT NULL,      -- stock_quantity - ledger_quantity
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN', -- OPEN | CORRECTED
+    transaction_id INT NULL, -- adjustment koreksi yang diposting
+    corrected_by INT NULL,
+    corrected_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE INDEX idx_reconciliation_drifts_run_id ON reconciliation_drifts(run_id);
+
+INSERT INTO reason_codes (code, name, direction) VALUES ('RECONCILE', 'Koreksi rekonsiliasi ledger', 'BOTH')
+ON CONFLICT (code) DO NOTHING;
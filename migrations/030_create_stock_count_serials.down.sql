@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_stock_count_serials_count_serial;
+DROP TABLE IF EXISTS stock_count_serials;
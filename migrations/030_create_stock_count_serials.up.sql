@@ -0,0 +1,13 @@
+-- Nomor seri per sesi stock opname untuk product serialized: expected dibekukan
+-- dari serial_numbers saat sesi dibuat, counted diisi dari hasil hitung
+CREATE TABLE IF NOT EXISTS stock_count_serials (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    stock_count_id INT NOT NULL,
+    product_id INT NOT NULL,
+    serial_number VARCHAR(100) NOT NULL,
+    expected BOOLEAN NOT NULL DEFAULT FALSE, -- IN_STOCK di warehouse saat sesi dibuat
+    counted BOOLEAN NOT NULL DEFAULT FALSE,
+    transaction_id INT NULL -- adjustment yang memindahkan nomor seri ini saat approve
+);
+
+CREATE UNIQUE INDEX idx_stock_count_serials_count_serial ON stock_count_serials(stock_count_id, product_id, serial_number);
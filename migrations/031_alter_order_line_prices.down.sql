@@ -0,0 +1,7 @@
+ALTER TABLE sales_order_lines DROP COLUMN IF EXISTS currency;
+ALTER TABLE sales_order_lines DROP CONSTRAINT IF EXISTS chk_sales_order_lines_unit_price;
+ALTER TABLE sales_order_lines ALTER COLUMN unit_price TYPE BIGINT USING ROUND(unit_price)::BIGINT;
+
+ALTER TABLE purchase_order_lines DROP COLUMN IF EXISTS currency;
+ALTER TABLE purchase_order_lines DROP CONSTRAINT IF EXISTS chk_purchase_order_lines_unit_price;
+ALTER TABLE purchase_order_lines ALTER COLUMN unit_price TYPE BIGINT USING ROUND(unit_price)::BIGINT;
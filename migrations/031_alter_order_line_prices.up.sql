@@ -0,0 +1,9 @@
+-- Harga baris purchase / sales order disimpan sebagai desimal dengan mata uang,
+-- sama seperti harga product. Nilai lama adalah rupiah utuh.
+ALTER TABLE purchase_order_lines ALTER COLUMN unit_price TYPE NUMERIC(18,2) USING unit_price::NUMERIC;
+ALTER TABLE purchase_order_lines ADD CONSTRAINT chk_purchase_order_lines_unit_price CHECK (unit_price >= 0);
+ALTER TABLE purchase_order_lines ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE sales_order_lines ALTER COLUMN unit_price TYPE NUMERIC(18,2) USING unit_price::NUMERIC;
+ALTER TABLE sales_order_lines ADD CONSTRAINT chk_sales_order_lines_unit_price CHECK (unit_price >= 0);
+ALTER TABLE sales_order_lines ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR';
@@ -0,0 +1,3 @@
+DELETE FROM stock_snapshot_lines WHERE snapshot_id IN (SELECT id FROM stock_snapshots WHERE created_by IS NULL);
+DELETE FROM stock_snapshots WHERE created_by IS NULL;
+ALTER TABLE stock_snapshots ALTER COLUMN created_by SET NOT NULL;
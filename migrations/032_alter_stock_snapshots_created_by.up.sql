@@ -0,0 +1,2 @@
+-- Snapshot dari job berkala (SNAPSHOT_INTERVAL) tidak dibuat oleh user
+ALTER TABLE stock_snapshots ALTER COLUMN created_by DROP NOT NULL;